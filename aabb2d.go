@@ -0,0 +1,153 @@
+package geometry
+
+import (
+	"math"
+)
+
+// An AABB2D is an axis aligned bounding box, all points with components
+// between those of Min and Max inclusive. A box with any component of Min
+// greater than the same component of Max is empty.
+type AABB2D struct {
+	Min, Max Vector2D
+}
+
+// Area returns the area of x, or 0 if x is empty.
+func (x *AABB2D) Area() float64 {
+	if x.IsEmpty() {
+		return 0
+	}
+	return (x.Max.X - x.Min.X) * (x.Max.Y - x.Min.Y)
+}
+
+// Center sets z to the center of x then returns z.
+func (x *AABB2D) Center(z *Vector2D) *Vector2D {
+	z.X = (x.Min.X + x.Max.X) / 2
+	z.Y = (x.Min.Y + x.Max.Y) / 2
+	return z
+}
+
+// ClosestPoint sets z to the point in x closest to p then returns z.
+func (x *AABB2D) ClosestPoint(p, z *Vector2D) *Vector2D {
+	z.X = math.Max(x.Min.X, math.Min(p.X, x.Max.X))
+	z.Y = math.Max(x.Min.Y, math.Min(p.Y, x.Max.Y))
+	return z
+}
+
+// Contains returns true if point p is inside or on x or false otherwise.
+func (x *AABB2D) Contains(p *Vector2D) bool {
+	return x.Min.X <= p.X && p.X <= x.Max.X && x.Min.Y <= p.Y && p.Y <= x.Max.Y
+}
+
+// Copy sets z to x then returns z.
+func (z *AABB2D) Copy(x *AABB2D) *AABB2D {
+	z.Min = x.Min
+	z.Max = x.Max
+	return z
+}
+
+// Empty sets z to the empty box, with Min infinitely large and Max infinitely
+// small so that the union of z with any box is that box, then returns z.
+func (z *AABB2D) Empty() *AABB2D {
+	z.Min.X, z.Min.Y = math.Inf(1), math.Inf(1)
+	z.Max.X, z.Max.Y = math.Inf(-1), math.Inf(-1)
+	return z
+}
+
+// Equal compares a and b then returns true if they are exactly equal or false
+// otherwise.
+func (a *AABB2D) Equal(b *AABB2D) bool {
+	return a.Min == b.Min && a.Max == b.Max
+}
+
+// Expand sets z to x grown by d on every side, or shrunk if d is negative,
+// then returns z.
+func (z *AABB2D) Expand(x *AABB2D, d float64) *AABB2D {
+	z.Min.X, z.Min.Y = x.Min.X-d, x.Min.Y-d
+	z.Max.X, z.Max.Y = x.Max.X+d, x.Max.Y+d
+	return z
+}
+
+// FromPoints sets z to the smallest box containing points then returns z. If
+// there are no points z is set to the empty box.
+func (z *AABB2D) FromPoints(points ...Vector2D) *AABB2D {
+	z.Empty()
+	for i := range points {
+		z.Min.X = math.Min(z.Min.X, points[i].X)
+		z.Min.Y = math.Min(z.Min.Y, points[i].Y)
+		z.Max.X = math.Max(z.Max.X, points[i].X)
+		z.Max.Y = math.Max(z.Max.Y, points[i].Y)
+	}
+	return z
+}
+
+// FuzzyEqual compares a and b then returns true if they are very close or
+// false otherwise.
+func (a *AABB2D) FuzzyEqual(b *AABB2D) bool {
+	return DefaultTolerance.AABB2DEqual(a, b)
+}
+
+// AABB2DEqual is like AABB2D.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) AABB2DEqual(a, b *AABB2D) bool {
+	return t.Vector2DEqual(&a.Min, &b.Min) && t.Vector2DEqual(&a.Max, &b.Max)
+}
+
+// Intersection sets z to the box common to a and b then returns z. If they
+// do not overlap z will be empty.
+func (z *AABB2D) Intersection(a, b *AABB2D) *AABB2D {
+	z.Min.X, z.Min.Y = math.Max(a.Min.X, b.Min.X), math.Max(a.Min.Y, b.Min.Y)
+	z.Max.X, z.Max.Y = math.Min(a.Max.X, b.Max.X), math.Min(a.Max.Y, b.Max.Y)
+	return z
+}
+
+// Intersects returns true if a and b overlap or touch or false otherwise.
+func (a *AABB2D) Intersects(b *AABB2D) bool {
+	return a.Min.X <= b.Max.X && b.Min.X <= a.Max.X && a.Min.Y <= b.Max.Y && b.Min.Y <= a.Max.Y
+}
+
+// IsEmpty returns true if x contains no points or false otherwise.
+func (x *AABB2D) IsEmpty() bool {
+	return !(x.Min.X <= x.Max.X && x.Min.Y <= x.Max.Y)
+}
+
+// Size sets z to the width and height of x then returns z.
+func (x *AABB2D) Size(z *Vector2D) *Vector2D {
+	z.X = x.Max.X - x.Min.X
+	z.Y = x.Max.Y - x.Min.Y
+	return z
+}
+
+// Support returns the point of x furthest in direction d.
+func (x *AABB2D) Support(d Vector2D) Vector2D {
+	p := x.Min
+	if d.X > 0 {
+		p.X = x.Max.X
+	}
+	if d.Y > 0 {
+		p.Y = x.Max.Y
+	}
+	return p
+}
+
+// Union sets z to the smallest box containing both a and b then returns z.
+func (z *AABB2D) Union(a, b *AABB2D) *AABB2D {
+	z.Min.X, z.Min.Y = math.Min(a.Min.X, b.Min.X), math.Min(a.Min.Y, b.Min.Y)
+	z.Max.X, z.Max.Y = math.Max(a.Max.X, b.Max.X), math.Max(a.Max.Y, b.Max.Y)
+	return z
+}
+
+// aabbSlab clips the parameter range [t0, t1] of a line with component p and
+// direction v against the slab between lo and hi, then returns false if the
+// range is empty or true otherwise.
+func aabbSlab(p, v, lo, hi float64, t0, t1 *float64) bool {
+	if v == 0 {
+		return lo <= p && p <= hi
+	}
+	d := 1 / v
+	a, b := (lo-p)*d, (hi-p)*d
+	if a > b {
+		a, b = b, a
+	}
+	*t0 = math.Max(*t0, a)
+	*t1 = math.Min(*t1, b)
+	return *t0 <= *t1
+}
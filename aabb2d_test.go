@@ -0,0 +1,122 @@
+package geometry
+
+import (
+	"testing"
+)
+
+func TestAABB2DArea(t *testing.T) {
+	b := &AABB2D{Vector2D{1, 2}, Vector2D{4, 4}}
+	if a := b.Area(); a != 6 {
+		t.Error("AABB2D.Area", b, "want", 6, "got", a)
+	}
+	if a := new(AABB2D).Empty().Area(); a != 0 {
+		t.Error("AABB2D.Area", "empty", "want", 0, "got", a)
+	}
+}
+
+func TestAABB2DCenterSize(t *testing.T) {
+	b := &AABB2D{Vector2D{1, 2}, Vector2D{4, 4}}
+	var c, s Vector2D
+	if !b.Center(&c).Equal(&Vector2D{2.5, 3}) {
+		t.Error("AABB2D.Center", b, "got", c)
+	}
+	if !b.Size(&s).Equal(&Vector2D{3, 2}) {
+		t.Error("AABB2D.Size", b, "got", s)
+	}
+}
+
+type aabb2DClosestPointData struct {
+	p, c Vector2D
+}
+
+var aabb2DClosestPointValues = []aabb2DClosestPointData{
+	{Vector2D{2, 3}, Vector2D{2, 3}},
+	{Vector2D{0, 0}, Vector2D{1, 2}},
+	{Vector2D{5, 3}, Vector2D{4, 3}},
+	{Vector2D{2, 9}, Vector2D{2, 4}},
+}
+
+func TestAABB2DClosestPoint(t *testing.T) {
+	b := &AABB2D{Vector2D{1, 2}, Vector2D{4, 4}}
+	var c Vector2D
+	for _, v := range aabb2DClosestPointValues {
+		if !b.ClosestPoint(&v.p, &c).Equal(&v.c) {
+			t.Error("AABB2D.ClosestPoint", b, v.p, "want", v.c, "got", c)
+		}
+	}
+}
+
+func TestAABB2DContains(t *testing.T) {
+	b := &AABB2D{Vector2D{1, 2}, Vector2D{4, 4}}
+	if !b.Contains(&Vector2D{1, 2}) || !b.Contains(&Vector2D{2, 3}) || b.Contains(&Vector2D{0, 3}) ||
+		b.Contains(&Vector2D{2, 5}) {
+		t.Error("AABB2D.Contains", b)
+	}
+	if new(AABB2D).Empty().Contains(&Vector2D{}) {
+		t.Error("AABB2D.Contains", "empty")
+	}
+}
+
+func TestAABB2DExpand(t *testing.T) {
+	b := &AABB2D{Vector2D{1, 2}, Vector2D{4, 4}}
+	var e AABB2D
+	if !e.Expand(b, 1).Equal(&AABB2D{Vector2D{0, 1}, Vector2D{5, 5}}) {
+		t.Error("AABB2D.Expand", b, "got", e)
+	}
+}
+
+func TestAABB2DFromPoints(t *testing.T) {
+	var b AABB2D
+	b.FromPoints(Vector2D{1, 5}, Vector2D{-2, 3}, Vector2D{4, -1})
+	if !b.Equal(&AABB2D{Vector2D{-2, -1}, Vector2D{4, 5}}) {
+		t.Error("AABB2D.FromPoints", "got", b)
+	}
+	if !b.FromPoints().IsEmpty() {
+		t.Error("AABB2D.FromPoints", "want empty got", b)
+	}
+}
+
+func TestAABB2DIntersectionUnion(t *testing.T) {
+	a := &AABB2D{Vector2D{0, 0}, Vector2D{2, 2}}
+	b := &AABB2D{Vector2D{1, 1}, Vector2D{3, 4}}
+	c := &AABB2D{Vector2D{5, 5}, Vector2D{6, 6}}
+	var z AABB2D
+	if !z.Intersection(a, b).Equal(&AABB2D{Vector2D{1, 1}, Vector2D{2, 2}}) || !a.Intersects(b) {
+		t.Error("AABB2D.Intersection", a, b, "got", z)
+	}
+	if !z.Intersection(a, c).IsEmpty() || a.Intersects(c) {
+		t.Error("AABB2D.Intersection", a, c, "got", z)
+	}
+	if !z.Union(a, b).Equal(&AABB2D{Vector2D{0, 0}, Vector2D{3, 4}}) {
+		t.Error("AABB2D.Union", a, b, "got", z)
+	}
+	var e AABB2D
+	if !z.Union(e.Empty(), a).Equal(a) {
+		t.Error("AABB2D.Union", "empty", a, "got", z)
+	}
+}
+
+func TestAABB2DBounds(t *testing.T) {
+	var b AABB2D
+	c := &Circle{Vector2D{1, 2}, 3}
+	if !c.Bounds(&b).Equal(&AABB2D{Vector2D{-2, -1}, Vector2D{4, 5}}) {
+		t.Error("Circle.Bounds", c, "got", b)
+	}
+	l := &Line2D{Vector2D{1, 2}, Vector2D{-3, 1}}
+	if !l.SegmentBounds(&b).Equal(&AABB2D{Vector2D{-2, 2}, Vector2D{1, 3}}) {
+		t.Error("Line2D.SegmentBounds", l, "got", b)
+	}
+	tr := NewTriangle2D(0, 1, 2, -1, 1, 3)
+	if !tr.Bounds(&b).Equal(&AABB2D{Vector2D{0, -1}, Vector2D{2, 3}}) {
+		t.Error("Triangle2D.Bounds", tr, "got", b)
+	}
+}
+
+func Benchmark_AABB2D_Union(b *testing.B) {
+	x := &AABB2D{Vector2D{0, 0}, Vector2D{2, 2}}
+	y := &AABB2D{Vector2D{1, 1}, Vector2D{3, 4}}
+	var z AABB2D
+	for i := 0; i < b.N; i++ {
+		z.Union(x, y)
+	}
+}
@@ -0,0 +1,155 @@
+package geometry
+
+import (
+	"math"
+)
+
+// An AABB3D is an axis aligned bounding box, all points with components
+// between those of Min and Max inclusive. A box with any component of Min
+// greater than the same component of Max is empty.
+type AABB3D struct {
+	Min, Max Vector3D
+}
+
+// Center sets z to the center of x then returns z.
+func (x *AABB3D) Center(z *Vector3D) *Vector3D {
+	z.X = (x.Min.X + x.Max.X) / 2
+	z.Y = (x.Min.Y + x.Max.Y) / 2
+	z.Z = (x.Min.Z + x.Max.Z) / 2
+	return z
+}
+
+// ClosestPoint sets z to the point in x closest to p then returns z.
+func (x *AABB3D) ClosestPoint(p, z *Vector3D) *Vector3D {
+	z.X = math.Max(x.Min.X, math.Min(p.X, x.Max.X))
+	z.Y = math.Max(x.Min.Y, math.Min(p.Y, x.Max.Y))
+	z.Z = math.Max(x.Min.Z, math.Min(p.Z, x.Max.Z))
+	return z
+}
+
+// Contains returns true if point p is inside or on x or false otherwise.
+func (x *AABB3D) Contains(p *Vector3D) bool {
+	return x.Min.X <= p.X && p.X <= x.Max.X && x.Min.Y <= p.Y && p.Y <= x.Max.Y &&
+		x.Min.Z <= p.Z && p.Z <= x.Max.Z
+}
+
+// Copy sets z to x then returns z.
+func (z *AABB3D) Copy(x *AABB3D) *AABB3D {
+	z.Min = x.Min
+	z.Max = x.Max
+	return z
+}
+
+// Empty sets z to the empty box, with Min infinitely large and Max infinitely
+// small so that the union of z with any box is that box, then returns z.
+func (z *AABB3D) Empty() *AABB3D {
+	z.Min.X, z.Min.Y, z.Min.Z = math.Inf(1), math.Inf(1), math.Inf(1)
+	z.Max.X, z.Max.Y, z.Max.Z = math.Inf(-1), math.Inf(-1), math.Inf(-1)
+	return z
+}
+
+// Equal compares a and b then returns true if they are exactly equal or false
+// otherwise.
+func (a *AABB3D) Equal(b *AABB3D) bool {
+	return a.Min == b.Min && a.Max == b.Max
+}
+
+// Expand sets z to x grown by d on every side, or shrunk if d is negative,
+// then returns z.
+func (z *AABB3D) Expand(x *AABB3D, d float64) *AABB3D {
+	z.Min.X, z.Min.Y, z.Min.Z = x.Min.X-d, x.Min.Y-d, x.Min.Z-d
+	z.Max.X, z.Max.Y, z.Max.Z = x.Max.X+d, x.Max.Y+d, x.Max.Z+d
+	return z
+}
+
+// FromPoints sets z to the smallest box containing points then returns z. If
+// there are no points z is set to the empty box.
+func (z *AABB3D) FromPoints(points ...Vector3D) *AABB3D {
+	z.Empty()
+	for i := range points {
+		z.Min.X = math.Min(z.Min.X, points[i].X)
+		z.Min.Y = math.Min(z.Min.Y, points[i].Y)
+		z.Min.Z = math.Min(z.Min.Z, points[i].Z)
+		z.Max.X = math.Max(z.Max.X, points[i].X)
+		z.Max.Y = math.Max(z.Max.Y, points[i].Y)
+		z.Max.Z = math.Max(z.Max.Z, points[i].Z)
+	}
+	return z
+}
+
+// FuzzyEqual compares a and b then returns true if they are very close or
+// false otherwise.
+func (a *AABB3D) FuzzyEqual(b *AABB3D) bool {
+	return DefaultTolerance.AABB3DEqual(a, b)
+}
+
+// AABB3DEqual is like AABB3D.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) AABB3DEqual(a, b *AABB3D) bool {
+	return t.Vector3DEqual(&a.Min, &b.Min) && t.Vector3DEqual(&a.Max, &b.Max)
+}
+
+// Intersection sets z to the box common to a and b then returns z. If they
+// do not overlap z will be empty.
+func (z *AABB3D) Intersection(a, b *AABB3D) *AABB3D {
+	z.Min.X, z.Min.Y, z.Min.Z = math.Max(a.Min.X, b.Min.X), math.Max(a.Min.Y, b.Min.Y), math.Max(a.Min.Z, b.Min.Z)
+	z.Max.X, z.Max.Y, z.Max.Z = math.Min(a.Max.X, b.Max.X), math.Min(a.Max.Y, b.Max.Y), math.Min(a.Max.Z, b.Max.Z)
+	return z
+}
+
+// Intersects returns true if a and b overlap or touch or false otherwise.
+func (a *AABB3D) Intersects(b *AABB3D) bool {
+	return a.Min.X <= b.Max.X && b.Min.X <= a.Max.X && a.Min.Y <= b.Max.Y && b.Min.Y <= a.Max.Y &&
+		a.Min.Z <= b.Max.Z && b.Min.Z <= a.Max.Z
+}
+
+// IsEmpty returns true if x contains no points or false otherwise.
+func (x *AABB3D) IsEmpty() bool {
+	return !(x.Min.X <= x.Max.X && x.Min.Y <= x.Max.Y && x.Min.Z <= x.Max.Z)
+}
+
+// Size sets z to the extent of x along each axis then returns z.
+func (x *AABB3D) Size(z *Vector3D) *Vector3D {
+	z.X = x.Max.X - x.Min.X
+	z.Y = x.Max.Y - x.Min.Y
+	z.Z = x.Max.Z - x.Min.Z
+	return z
+}
+
+// Support returns the point of x furthest in direction d.
+func (x *AABB3D) Support(d Vector3D) Vector3D {
+	p := x.Min
+	if d.X > 0 {
+		p.X = x.Max.X
+	}
+	if d.Y > 0 {
+		p.Y = x.Max.Y
+	}
+	if d.Z > 0 {
+		p.Z = x.Max.Z
+	}
+	return p
+}
+
+// SurfaceArea returns the surface area of x, or 0 if x is empty.
+func (x *AABB3D) SurfaceArea() float64 {
+	if x.IsEmpty() {
+		return 0
+	}
+	dx, dy, dz := x.Max.X-x.Min.X, x.Max.Y-x.Min.Y, x.Max.Z-x.Min.Z
+	return 2 * (dx*dy + dy*dz + dz*dx)
+}
+
+// Union sets z to the smallest box containing both a and b then returns z.
+func (z *AABB3D) Union(a, b *AABB3D) *AABB3D {
+	z.Min.X, z.Min.Y, z.Min.Z = math.Min(a.Min.X, b.Min.X), math.Min(a.Min.Y, b.Min.Y), math.Min(a.Min.Z, b.Min.Z)
+	z.Max.X, z.Max.Y, z.Max.Z = math.Max(a.Max.X, b.Max.X), math.Max(a.Max.Y, b.Max.Y), math.Max(a.Max.Z, b.Max.Z)
+	return z
+}
+
+// Volume returns the volume of x, or 0 if x is empty.
+func (x *AABB3D) Volume() float64 {
+	if x.IsEmpty() {
+		return 0
+	}
+	return (x.Max.X - x.Min.X) * (x.Max.Y - x.Min.Y) * (x.Max.Z - x.Min.Z)
+}
@@ -0,0 +1,88 @@
+package geometry
+
+import (
+	"testing"
+)
+
+func TestAABB3DCenterSize(t *testing.T) {
+	b := &AABB3D{Vector3D{1, 2, 3}, Vector3D{4, 4, 4}}
+	var c, s Vector3D
+	if !b.Center(&c).Equal(&Vector3D{2.5, 3, 3.5}) {
+		t.Error("AABB3D.Center", b, "got", c)
+	}
+	if !b.Size(&s).Equal(&Vector3D{3, 2, 1}) {
+		t.Error("AABB3D.Size", b, "got", s)
+	}
+}
+
+func TestAABB3DClosestPoint(t *testing.T) {
+	b := &AABB3D{Vector3D{1, 2, 3}, Vector3D{4, 4, 4}}
+	var c Vector3D
+	if !b.ClosestPoint(&Vector3D{0, 3, 9}, &c).Equal(&Vector3D{1, 3, 4}) {
+		t.Error("AABB3D.ClosestPoint", b, "got", c)
+	}
+	if !b.ClosestPoint(&Vector3D{2, 3, 3.5}, &c).Equal(&Vector3D{2, 3, 3.5}) {
+		t.Error("AABB3D.ClosestPoint", b, "got", c)
+	}
+}
+
+func TestAABB3DContains(t *testing.T) {
+	b := &AABB3D{Vector3D{1, 2, 3}, Vector3D{4, 4, 4}}
+	if !b.Contains(&Vector3D{4, 4, 4}) || !b.Contains(&Vector3D{2, 3, 3.5}) || b.Contains(&Vector3D{2, 3, 5}) {
+		t.Error("AABB3D.Contains", b)
+	}
+}
+
+func TestAABB3DFromPoints(t *testing.T) {
+	var b AABB3D
+	b.FromPoints(Vector3D{1, 5, 0}, Vector3D{-2, 3, 7}, Vector3D{4, -1, 2})
+	if !b.Equal(&AABB3D{Vector3D{-2, -1, 0}, Vector3D{4, 5, 7}}) {
+		t.Error("AABB3D.FromPoints", "got", b)
+	}
+	if !b.FromPoints().IsEmpty() {
+		t.Error("AABB3D.FromPoints", "want empty got", b)
+	}
+}
+
+func TestAABB3DIntersectionUnion(t *testing.T) {
+	a := &AABB3D{Vector3D{0, 0, 0}, Vector3D{2, 2, 2}}
+	b := &AABB3D{Vector3D{1, 1, 1}, Vector3D{3, 4, 5}}
+	c := &AABB3D{Vector3D{0, 0, 3}, Vector3D{2, 2, 4}}
+	var z AABB3D
+	if !z.Intersection(a, b).Equal(&AABB3D{Vector3D{1, 1, 1}, Vector3D{2, 2, 2}}) || !a.Intersects(b) {
+		t.Error("AABB3D.Intersection", a, b, "got", z)
+	}
+	if !z.Intersection(a, c).IsEmpty() || a.Intersects(c) {
+		t.Error("AABB3D.Intersection", a, c, "got", z)
+	}
+	if !z.Union(a, b).Equal(&AABB3D{Vector3D{0, 0, 0}, Vector3D{3, 4, 5}}) {
+		t.Error("AABB3D.Union", a, b, "got", z)
+	}
+}
+
+func TestAABB3DVolume(t *testing.T) {
+	b := &AABB3D{Vector3D{1, 2, 3}, Vector3D{4, 4, 4}}
+	var e AABB3D
+	if b.Volume() != 6 || b.SurfaceArea() != 22 || e.Expand(b, 1).Volume() != 60 {
+		t.Error("AABB3D.Volume", b, b.Volume(), b.SurfaceArea(), e.Volume())
+	}
+	if e.Empty().Volume() != 0 || e.SurfaceArea() != 0 {
+		t.Error("AABB3D.Volume", "empty", e.Volume(), e.SurfaceArea())
+	}
+}
+
+func TestAABB3DBounds(t *testing.T) {
+	var b AABB3D
+	s := &Sphere{Vector3D{1, 2, 3}, 1}
+	if !s.Bounds(&b).Equal(&AABB3D{Vector3D{0, 1, 2}, Vector3D{2, 3, 4}}) {
+		t.Error("Sphere.Bounds", s, "got", b)
+	}
+	l := &Line3D{Vector3D{1, 2, 3}, Vector3D{-3, 1, 0}}
+	if !l.SegmentBounds(&b).Equal(&AABB3D{Vector3D{-2, 2, 3}, Vector3D{1, 3, 3}}) {
+		t.Error("Line3D.SegmentBounds", l, "got", b)
+	}
+	tr := &Triangle3D{Vector3D{0, 1, 2}, Vector3D{2, -1, 0}, Vector3D{1, 3, 1}}
+	if !tr.Bounds(&b).Equal(&AABB3D{Vector3D{0, -1, 0}, Vector3D{2, 3, 2}}) {
+		t.Error("Triangle3D.Bounds", tr, "got", b)
+	}
+}
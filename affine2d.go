@@ -0,0 +1,141 @@
+package geometry
+
+import (
+	"math"
+)
+
+// An Affine2D is a 2D affine transform stored as the top two rows of a 3x3
+// homogeneous matrix in row major order. It operates on column vectors, so a
+// Vector2D v is transformed by M as M*(v, 1) and the translation is held in
+// the last column.
+type Affine2D [2][3]float64
+
+// Compose sets z to the transform that scales by scale, shears x by shear
+// times y, rotates counterclockwise by rotation, and then translates by
+// translation, then returns z. It is the inverse of Decompose.
+func (z *Affine2D) Compose(translation, scale *Vector2D, rotation, shear float64) *Affine2D {
+	s, c := math.Sincos(rotation)
+	z[0][0] = c * scale.X
+	z[0][1] = (c*shear - s) * scale.Y
+	z[0][2] = translation.X
+	z[1][0] = s * scale.X
+	z[1][1] = (s*shear + c) * scale.Y
+	z[1][2] = translation.Y
+	return z
+}
+
+// Copy sets z to x then returns z.
+func (z *Affine2D) Copy(x *Affine2D) *Affine2D {
+	*z = *x
+	return z
+}
+
+// Decompose splits x into a translation, rotation, shear, and scale such that
+// Compose with the same values reproduces x. The translation and scale are
+// stored in translation and scale and the rotation and shear are returned. A
+// reflection is represented by a negative scale.Y.
+func (x *Affine2D) Decompose(translation, scale *Vector2D) (rotation, shear float64) {
+	translation.X = x[0][2]
+	translation.Y = x[1][2]
+	scale.X = math.Sqrt(x[0][0]*x[0][0] + x[1][0]*x[1][0])
+	rotation = math.Atan2(x[1][0], x[0][0])
+	s, c := math.Sincos(rotation)
+	scale.Y = c*x[1][1] - s*x[0][1]
+	shear = (c*x[0][1] + s*x[1][1]) / scale.Y
+	return rotation, shear
+}
+
+// Determinant returns the determinant of the linear part of x.
+func (x *Affine2D) Determinant() float64 {
+	return x[0][0]*x[1][1] - x[0][1]*x[1][0]
+}
+
+// Equal compares a and b then returns true if they are exactly equal or false
+// otherwise.
+func (a *Affine2D) Equal(b *Affine2D) bool {
+	return *a == *b
+}
+
+// FuzzyEqual compares a and b then returns true if they are very close or
+// false otherwise.
+func (a *Affine2D) FuzzyEqual(b *Affine2D) bool {
+	return DefaultTolerance.Affine2DEqual(a, b)
+}
+
+// Affine2DEqual is like Affine2D.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) Affine2DEqual(a, b *Affine2D) bool {
+	return t.Equal(a[0][0], b[0][0]) && t.Equal(a[0][1], b[0][1]) && t.Equal(a[0][2], b[0][2]) &&
+		t.Equal(a[1][0], b[1][0]) && t.Equal(a[1][1], b[1][1]) && t.Equal(a[1][2], b[1][2])
+}
+
+// Identity sets z to the identity transform then returns z.
+func (z *Affine2D) Identity() *Affine2D {
+	*z = Affine2D{{1, 0, 0}, {0, 1, 0}}
+	return z
+}
+
+// Inverse sets z to the inverse of x then returns z. If x is singular the
+// elements of z will be infinite or NaN.
+func (z *Affine2D) Inverse(x *Affine2D) *Affine2D {
+	d := 1 / (x[0][0]*x[1][1] - x[0][1]*x[1][0])
+	a, b, c := x[1][1]*d, -x[0][1]*d, -x[1][0]*d
+	e := x[0][0] * d
+	tx, ty := x[0][2], x[1][2]
+	*z = Affine2D{{a, b, -(a*tx + b*ty)}, {c, e, -(c*tx + e*ty)}}
+	return z
+}
+
+// IsSimilarity returns true if x is very close to a similarity transform, one
+// made only of translation, rotation, reflection, and uniform scaling, or
+// false otherwise. Similarity transforms map circles to circles.
+func (x *Affine2D) IsSimilarity() bool {
+	l := x[0][0]*x[0][0] + x[1][0]*x[1][0]
+	return FuzzyEqual(l, x[0][1]*x[0][1]+x[1][1]*x[1][1]) &&
+		FuzzyEqual((x[0][0]*x[0][1]+x[1][0]*x[1][1])/l, 0)
+}
+
+// Multiply sets z to the composition a*b, the transform that applies b then a,
+// then returns z.
+func (z *Affine2D) Multiply(a, b *Affine2D) *Affine2D {
+	*z = Affine2D{
+		{
+			a[0][0]*b[0][0] + a[0][1]*b[1][0],
+			a[0][0]*b[0][1] + a[0][1]*b[1][1],
+			a[0][0]*b[0][2] + a[0][1]*b[1][2] + a[0][2],
+		},
+		{
+			a[1][0]*b[0][0] + a[1][1]*b[1][0],
+			a[1][0]*b[0][1] + a[1][1]*b[1][1],
+			a[1][0]*b[0][2] + a[1][1]*b[1][2] + a[1][2],
+		},
+	}
+	return z
+}
+
+// Rotation sets z to a counterclockwise rotation of angle radians about the
+// origin then returns z.
+func (z *Affine2D) Rotation(angle float64) *Affine2D {
+	s, c := math.Sincos(angle)
+	*z = Affine2D{{c, -s, 0}, {s, c, 0}}
+	return z
+}
+
+// Scaling sets z to a scale by the components of s about the origin then
+// returns z.
+func (z *Affine2D) Scaling(s *Vector2D) *Affine2D {
+	*z = Affine2D{{s.X, 0, 0}, {0, s.Y, 0}}
+	return z
+}
+
+// Shear sets z to a shear parallel to the x axis, where x is offset by k times
+// y, then returns z.
+func (z *Affine2D) Shear(k float64) *Affine2D {
+	*z = Affine2D{{1, k, 0}, {0, 1, 0}}
+	return z
+}
+
+// Translation sets z to a translation by t then returns z.
+func (z *Affine2D) Translation(t *Vector2D) *Affine2D {
+	*z = Affine2D{{1, 0, t.X}, {0, 1, t.Y}}
+	return z
+}
@@ -0,0 +1,90 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+type affine2DDecomposeData struct {
+	t, s            Vector2D
+	rotation, shear float64
+}
+
+var affine2DDecomposeValues = []affine2DDecomposeData{
+	{Vector2D{}, Vector2D{1, 1}, 0, 0},
+	{Vector2D{1, -2}, Vector2D{2, 3}, 0.5, 0},
+	{Vector2D{1, -2}, Vector2D{2, 3}, -2.5, 0.75},
+	{Vector2D{3, 4}, Vector2D{0.5, -2}, 1, -0.25},
+}
+
+func testAffine2DDecompose(d affine2DDecomposeData, t *testing.T) {
+	var m Affine2D
+	var tr, s Vector2D
+	m.Compose(&d.t, &d.s, d.rotation, d.shear)
+	rotation, shear := m.Decompose(&tr, &s)
+	if !tr.FuzzyEqual(&d.t) || !s.FuzzyEqual(&d.s) || !FuzzyEqual(rotation, d.rotation) ||
+		!FuzzyEqual(shear, d.shear) {
+		t.Error("Affine2D.Decompose", d, "got", tr, s, rotation, shear)
+	}
+}
+
+func TestAffine2DDecompose(t *testing.T) {
+	for _, v := range affine2DDecomposeValues {
+		testAffine2DDecompose(v, t)
+	}
+}
+
+func TestAffine2DCompose(t *testing.T) {
+	var m, r, s, h, tr Affine2D
+	r.Rotation(0.3)
+	s.Scaling(&Vector2D{2, 3})
+	h.Shear(0.5)
+	tr.Translation(&Vector2D{4, 5})
+	m.Multiply(&tr, m.Multiply(&r, m.Multiply(&h, &s)))
+	var c Affine2D
+	if !c.Compose(&Vector2D{4, 5}, &Vector2D{2, 3}, 0.3, 0.5).FuzzyEqual(&m) {
+		t.Error("Affine2D.Compose", "want", m, "got", c)
+	}
+}
+
+func TestAffine2DInverse(t *testing.T) {
+	var m, inv, id Affine2D
+	m.Compose(&Vector2D{1, 2}, &Vector2D{3, -4}, 0.7, 0.2)
+	if !id.Multiply(&m, inv.Inverse(&m)).FuzzyEqual(new(Affine2D).Identity()) {
+		t.Error("Affine2D.Inverse", m, "got", inv)
+	}
+	if !m.Inverse(&m).FuzzyEqual(&inv) {
+		t.Error("Affine2D.Inverse in place", "got", m)
+	}
+}
+
+func Benchmark_Affine2D_Inverse(b *testing.B) {
+	var m, r Affine2D
+	m.Compose(&Vector2D{1, 2}, &Vector2D{3, -4}, 0.7, 0.2)
+	for i := 0; i < b.N; i++ {
+		r.Inverse(&m)
+	}
+}
+
+func TestAffine2DIsSimilarity(t *testing.T) {
+	var m Affine2D
+	if !m.Compose(&Vector2D{1, 2}, &Vector2D{3, -3}, 0.7, 0).IsSimilarity() {
+		t.Error("Affine2D.IsSimilarity", m)
+	}
+	if m.Compose(&Vector2D{1, 2}, &Vector2D{3, 3}, 0.7, 0.1).IsSimilarity() {
+		t.Error("Affine2D.IsSimilarity shear", m)
+	}
+	if m.Compose(&Vector2D{1, 2}, &Vector2D{3, 3 + 1e-9}, 0.7, 0).IsSimilarity() {
+		t.Error("Affine2D.IsSimilarity scale", m)
+	}
+}
+
+func TestAffine2DMultiply(t *testing.T) {
+	var m, r Affine2D
+	var v Vector2D
+	m.Translation(&Vector2D{1, 2})
+	m.Multiply(&m, r.Rotation(math.Pi/2))
+	if !v.TransformPoint(&m, &Vector2D{1, 0}).FuzzyEqual(&Vector2D{1, 3}) {
+		t.Error("Affine2D.Multiply", "got", v)
+	}
+}
@@ -0,0 +1,684 @@
+package geometry
+
+import (
+	"math"
+	"sort"
+)
+
+// Union sets z to the region covered by a or b then returns z.
+//
+// Rings may have either orientation and the polygons of a or b may overlap
+// each other, a point being in a if it is in any of its polygons. Edges that
+// cross, touch or overlap are found exactly with Orient2D, only the positions
+// of crossing points are rounded, and points closer together than about
+// 1e-12 of the extent of the input are merged.
+//
+// The result has counterclockwise outer rings and clockwise holes, each hole
+// in the polygon whose outer ring most closely contains it. Pieces that
+// touch at a vertex are separate rings rather than one ring that touches
+// itself, and vertices in the middle of a straight edge are removed.
+func (z *MultiPolygon2D) Union(a, b *MultiPolygon2D) *MultiPolygon2D {
+	return z.boolean(a, b, func(a, b bool) bool { return a || b })
+}
+
+// Intersection sets z to the region covered by both a and b then returns z.
+// It treats its input and result as Union does.
+func (z *MultiPolygon2D) Intersection(a, b *MultiPolygon2D) *MultiPolygon2D {
+	return z.boolean(a, b, func(a, b bool) bool { return a && b })
+}
+
+// Difference sets z to the region covered by a but not b then returns z. It
+// treats its input and result as Union does.
+func (z *MultiPolygon2D) Difference(a, b *MultiPolygon2D) *MultiPolygon2D {
+	return z.boolean(a, b, func(a, b bool) bool { return a && !b })
+}
+
+// Xor sets z to the region covered by exactly one of a and b then returns z.
+// It treats its input and result as Union does.
+func (z *MultiPolygon2D) Xor(a, b *MultiPolygon2D) *MultiPolygon2D {
+	return z.boolean(a, b, func(a, b bool) bool { return a != b })
+}
+
+// boolean sets z to the region where keep returns true given whether it is in
+// a and in b then returns z.
+func (z *MultiPolygon2D) boolean(a, b *MultiPolygon2D, keep func(a, b bool) bool) *MultiPolygon2D {
+	*z = polygonBoolean2D([2][][]Vector2D{a.rings(), b.rings()}, func(w [2]int) bool {
+		return keep(w[0] != 0, w[1] != 0)
+	})
+	return z
+}
+
+// rings returns the rings of x with outer rings counterclockwise and holes
+// clockwise, so that the winding number is nonzero exactly inside x.
+func (x *MultiPolygon2D) rings() [][]Vector2D {
+	var z [][]Vector2D
+	orient := func(r []Vector2D, sign float64) {
+		if ringArea(r)*sign < 0 {
+			r = append([]Vector2D(nil), r...)
+			ringReverse(r)
+		}
+		z = append(z, r)
+	}
+	for i := range *x {
+		orient((*x)[i].Outer, 1)
+		for _, r := range (*x)[i].Holes {
+			orient(r, -1)
+		}
+	}
+	return z
+}
+
+// A boolean2D is the arrangement of the edges of two sets of rings, split
+// wherever they meet so that edges only share end points or are identical.
+type boolean2D struct {
+	points []Vector2D
+	edges  []booleanEdge2D
+	face   []int    // the face on the left of each half edge
+	winds  [][2]int // the winding numbers of each operand in each face
+}
+
+// A booleanEdge2D is an edge of the arrangement from points[u] to points[v],
+// u < v. wind[i] is the number of times rings of operand i run along the edge
+// from u to v less the number of times they run from v to u, which is how
+// much greater their winding number is to the left of the edge than to the
+// right.
+type booleanEdge2D struct {
+	u, v  int
+	wind  [2]int
+	fresh bool // cut from a longer edge or moved by the last arrange
+}
+
+// A booleanSegment2D is an edge from points[p[0]] to points[p[1]] adding wind
+// to the winding numbers on its left, and the rest of p are points it is
+// split at. Segments that aren't fresh have already been split against each
+// other.
+type booleanSegment2D struct {
+	p     []int
+	wind  [2]int
+	fresh bool
+}
+
+// polygonBoolean2D returns the region of the plane where keep returns true
+// given the winding numbers of the rings of rings[0] and rings[1].
+func polygonBoolean2D(rings [2][][]Vector2D, keep func(w [2]int) bool) MultiPolygon2D {
+	var x boolean2D
+	var segments []booleanSegment2D
+	for op, rs := range rings {
+		for _, r := range rs {
+			for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+				if r[j] == r[i] {
+					continue
+				}
+				n := len(x.points)
+				x.points = append(x.points, r[j], r[i])
+				s := booleanSegment2D{p: []int{n, n + 1}, fresh: true}
+				s.wind[op] = 1
+				segments = append(segments, s)
+			}
+		}
+	}
+	if len(segments) == 0 {
+		return nil
+	}
+	// rounded crossing points can make edges cross that didn't, so the
+	// edges are split again until they stop changing
+	for round := 0; round < 16; round++ {
+		x.split(segments)
+		if !x.arrange(segments, x.snap()) {
+			break
+		}
+		segments = segments[:0]
+		for _, e := range x.edges {
+			if e.wind != [2]int{} {
+				segments = append(segments, booleanSegment2D{[]int{e.u, e.v}, e.wind, e.fresh})
+			}
+		}
+	}
+
+	x.faces()
+	var kept [][2]int
+	for i, e := range x.edges {
+		switch l, r := keep(x.winds[x.face[2*i]]), keep(x.winds[x.face[2*i+1]]); {
+		case l && !r:
+			kept = append(kept, [2]int{e.u, e.v})
+		case r && !l:
+			kept = append(kept, [2]int{e.v, e.u})
+		}
+	}
+	return x.polygons(kept)
+}
+
+// split finds where segments meet and adds the points to their p lists.
+func (x *boolean2D) split(segments []booleanSegment2D) {
+	type span struct{ lo, hi, bottom, top float64 }
+	spans := make([]span, len(segments))
+	order := make([]int, len(segments))
+	for i, s := range segments {
+		a, b := &x.points[s.p[0]], &x.points[s.p[1]]
+		spans[i] = span{math.Min(a.X, b.X), math.Max(a.X, b.X), math.Min(a.Y, b.Y), math.Max(a.Y, b.Y)}
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return spans[order[i]].lo < spans[order[j]].lo })
+
+	// sweep in X keeping the segments whose X range may overlap the next
+	var active []int
+	for _, i := range order {
+		n := 0
+		for _, j := range active {
+			if spans[j].hi >= spans[i].lo {
+				active[n] = j
+				n++
+			}
+		}
+		active = active[:n]
+		s := &segments[i]
+		a0, a1 := x.points[s.p[0]], x.points[s.p[1]]
+		for _, j := range active {
+			t := &segments[j]
+			if (!s.fresh && !t.fresh) || spans[i].top < spans[j].bottom || spans[j].top < spans[i].bottom {
+				continue
+			}
+			b0, b1 := x.points[t.p[0]], x.points[t.p[1]]
+			if x.apart(s, t) {
+				continue
+			}
+			var z Vector2D
+			switch intersection2DLineSegments(&a0, &a1, &b0, &b1, &z) {
+			case IntersectionPoint:
+				x.splitAt(s, &a0, &a1, &z)
+				x.splitAt(t, &b0, &b1, &z)
+			case IntersectionCoincident:
+				// each is split at the ends of the other that lie on it
+				for _, p := range [2]*Vector2D{&b0, &b1} {
+					if onSegment(&a0, &a1, p) {
+						x.splitAt(s, &a0, &a1, p)
+					}
+				}
+				for _, p := range [2]*Vector2D{&a0, &a1} {
+					if onSegment(&b0, &b1, p) {
+						x.splitAt(t, &b0, &b1, p)
+					}
+				}
+			}
+		}
+		active = append(active, i)
+	}
+}
+
+// apart returns true if segments s and t share an end and leave it in
+// directions that can't overlap, so they meet only there, or false otherwise.
+// It saves exact tests on the nearly collinear pieces of a split segment.
+func (x *boolean2D) apart(s, t *booleanSegment2D) bool {
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			if s.p[i] != t.p[j] {
+				continue
+			}
+			// segments overlapping from a shared end point the same way
+			var u, v Vector2D
+			o := &x.points[s.p[i]]
+			u.Subtract(&x.points[s.p[1-i]], o)
+			v.Subtract(&x.points[t.p[1-j]], o)
+			return u.DotProduct(&v) <= 0
+		}
+	}
+	return false
+}
+
+// splitAt splits segment s, from a to b, at point p unless it is an end.
+func (x *boolean2D) splitAt(s *booleanSegment2D, a, b, p *Vector2D) {
+	if *p == *a || *p == *b {
+		return
+	}
+	s.p = append(s.p, len(x.points))
+	x.points = append(x.points, *p)
+}
+
+// snap returns for every point the index of the point it is merged with,
+// the lowest index of those within about 1e-12 of the extent of the points,
+// so input vertices are preferred to crossing points.
+func (x *boolean2D) snap() []int {
+	var bounds AABB2D
+	var size Vector2D
+	bounds.FromPoints(x.points...)
+	eps := 1e-12 * (bounds.Size(&size).Magnitude() + bounds.Min.Magnitude() + bounds.Max.Magnitude())
+	if eps == 0 {
+		eps = math.SmallestNonzeroFloat64
+	}
+	rep := make([]int, len(x.points))
+	root := func(i int) int {
+		for rep[i] != i {
+			rep[i] = rep[rep[i]]
+			i = rep[i]
+		}
+		return i
+	}
+	// points are bucketed in cells of size eps and compared with those in
+	// the neighboring cells
+	cells := make(map[[2]int64][]int)
+	for i := range x.points {
+		rep[i] = i
+		p := &x.points[i]
+		c := [2]int64{int64(math.Floor(p.X / eps)), int64(math.Floor(p.Y / eps))}
+		for dx := int64(-1); dx <= 1; dx++ {
+			for dy := int64(-1); dy <= 1; dy++ {
+				for _, j := range cells[[2]int64{c[0] + dx, c[1] + dy}] {
+					q := &x.points[j]
+					if math.Abs(p.X-q.X) > eps || math.Abs(p.Y-q.Y) > eps {
+						continue
+					}
+					if a, b := root(i), root(j); a < b {
+						rep[b] = a
+					} else if b < a {
+						rep[a] = b
+					}
+				}
+			}
+		}
+		cells[c] = append(cells[c], i)
+	}
+	for i := range rep {
+		rep[i] = root(i)
+	}
+	return rep
+}
+
+// arrange sets the edges to segments cut at their split points, with points
+// merged as rep says, then returns true if any segment was cut or had an end
+// merged or false otherwise.
+func (x *boolean2D) arrange(segments []booleanSegment2D, rep []int) bool {
+	x.edges = x.edges[:0]
+	index := make(map[[2]int]int)
+	changed := false
+	for _, s := range segments {
+		a, b := x.points[s.p[0]], x.points[s.p[1]]
+		var d Vector2D
+		d.Subtract(&b, &a)
+		along := func(i int) float64 {
+			var v Vector2D
+			return v.Subtract(&x.points[i], &a).DotProduct(&d)
+		}
+		chain := append([]int{s.p[0]}, s.p[2:]...)
+		sort.Slice(chain[1:], func(i, j int) bool { return along(chain[1+i]) < along(chain[1+j]) })
+		chain = append(chain, s.p[1])
+		n := 0
+		for _, v := range chain {
+			if v = rep[v]; n == 0 || v != chain[n-1] {
+				chain[n] = v
+				n++
+			}
+		}
+		chain = chain[:n]
+		fresh := n != 2 || chain[0] != s.p[0] || chain[1] != s.p[1]
+		changed = changed || fresh
+		for k := 1; k < len(chain); k++ {
+			u, v := chain[k-1], chain[k]
+			key, w := [2]int{u, v}, 1
+			if u > v {
+				key, w = [2]int{v, u}, -1
+			}
+			e, ok := index[key]
+			if !ok {
+				e = len(x.edges)
+				index[key] = e
+				x.edges = append(x.edges, booleanEdge2D{u: key[0], v: key[1]})
+			}
+			x.edges[e].wind[0] += w * s.wind[0]
+			x.edges[e].wind[1] += w * s.wind[1]
+			x.edges[e].fresh = x.edges[e].fresh || fresh
+		}
+	}
+	return changed
+}
+
+// tail returns the vertex half edge h leaves. Half edge 2i runs along edge i
+// from u to v and half edge 2i+1 runs back from v to u.
+func (x *boolean2D) tail(h int) int {
+	if h%2 == 0 {
+		return x.edges[h/2].u
+	}
+	return x.edges[h/2].v
+}
+
+// ccw returns true if the direction from points[v] to points[a] comes before
+// the direction to points[b] turning counterclockwise from the direction to
+// points[ref], or from +X if ref is -1, or false otherwise. The order is
+// exact.
+func (x *boolean2D) ccw(v, ref, a, b int) bool {
+	p, q := &x.points[a], &x.points[b]
+	if hp, hq := x.half(v, ref, p), x.half(v, ref, q); hp != hq {
+		return hp < hq
+	}
+	return Orient2D(&x.points[v], p, q) > 0
+}
+
+// half returns 0 if the direction from points[v] to p is less than half a turn
+// counterclockwise from the direction to points[ref], or from +X if ref is
+// -1, or 1 otherwise.
+func (x *boolean2D) half(v, ref int, p *Vector2D) int {
+	o := &x.points[v]
+	if ref < 0 {
+		if p.Y > o.Y || (p.Y == o.Y && p.X > o.X) {
+			return 0
+		}
+		return 1
+	}
+	r := &x.points[ref]
+	switch Orient2D(o, r, p) {
+	case 1:
+		return 0
+	case -1:
+		return 1
+	}
+	// p is straight ahead or straight back
+	if sign(p.X-o.X) == sign(r.X-o.X) && sign(p.Y-o.Y) == sign(r.Y-o.Y) {
+		return 0
+	}
+	return 1
+}
+
+// faces finds the faces of the arrangement, which is planar once split has
+// nothing left to split, and the winding numbers of each operand in them.
+// Edges that don't change the winding numbers are removed first.
+func (x *boolean2D) faces() {
+	n := 0
+	for _, e := range x.edges {
+		if e.wind != [2]int{} {
+			x.edges[n] = e
+			n++
+		}
+	}
+	x.edges = x.edges[:n]
+
+	// the half edges leaving each vertex in counterclockwise order
+	out := make([][]int, len(x.points))
+	for h := 0; h < 2*len(x.edges); h++ {
+		out[x.tail(h)] = append(out[x.tail(h)], h)
+	}
+	pos := make([]int, 2*len(x.edges))
+	for v, hs := range out {
+		sort.Slice(hs, func(i, j int) bool { return x.ccw(v, -1, x.tail(hs[i]^1), x.tail(hs[j]^1)) })
+		for i, h := range hs {
+			pos[h] = i
+		}
+	}
+
+	// the face on the left of h continues with the half edge leaving the
+	// head of h next clockwise from h's twin
+	x.face = make([]int, 2*len(x.edges))
+	var bounds [][]int
+	for h := range x.face {
+		x.face[h] = -1
+	}
+	for h := range x.face {
+		if x.face[h] >= 0 {
+			continue
+		}
+		f := len(bounds)
+		bounds = append(bounds, nil)
+		for g := h; x.face[g] < 0; {
+			x.face[g] = f
+			bounds[f] = append(bounds[f], g)
+			hs := out[x.tail(g^1)]
+			g = hs[(pos[g^1]+len(hs)-1)%len(hs)]
+		}
+	}
+
+	// flood each connected piece of the arrangement, finding its lowest
+	// vertex
+	piece := make([]int, len(x.points))
+	for v := range piece {
+		piece[v] = -1
+	}
+	var lows []int
+	for h := range x.face {
+		if piece[x.tail(h)] >= 0 {
+			continue
+		}
+		p, low := len(lows), x.tail(h)
+		stack := []int{low}
+		piece[low] = p
+		for len(stack) > 0 {
+			v := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if a, b := &x.points[v], &x.points[low]; a.X < b.X || (a.X == b.X && a.Y < b.Y) {
+				low = v
+			}
+			for _, g := range out[v] {
+				w := x.tail(g ^ 1)
+				if piece[w] < 0 {
+					piece[w] = p
+					stack = append(stack, w)
+				}
+			}
+		}
+		lows = append(lows, low)
+	}
+
+	// each piece has its outer face, on the left of the most counterclockwise
+	// edge leaving its lowest vertex, set from the other pieces and the rest
+	// of its faces set from that
+	x.winds = make([][2]int, len(bounds))
+	known := make([]bool, len(bounds))
+	for p, low := range lows {
+		// every edge leaves low between straight down and straight up, and
+		// the outer face is past the last one before straight up
+		hs := out[low]
+		k := 0
+		for k < len(hs) && x.half(low, -1, &x.points[x.tail(hs[k]^1)]) == 0 {
+			k++
+		}
+		outer := x.face[hs[(k+len(hs)-1)%len(hs)]]
+		x.winds[outer] = x.winding(&x.points[low], func(e *booleanEdge2D) bool { return piece[e.u] != p })
+		known[outer] = true
+		faces := []int{outer}
+		for len(faces) > 0 {
+			f := faces[len(faces)-1]
+			faces = faces[:len(faces)-1]
+			for _, g := range bounds[f] {
+				r := x.face[g^1]
+				if known[r] {
+					continue
+				}
+				// the winding numbers are greater by wind on the left
+				w := x.edges[g/2].wind
+				if g%2 == 1 {
+					w = [2]int{-w[0], -w[1]}
+				}
+				x.winds[r] = [2]int{x.winds[f][0] - w[0], x.winds[f][1] - w[1]}
+				known[r] = true
+				faces = append(faces, r)
+			}
+		}
+	}
+}
+
+// winding returns the winding numbers of each operand at p, counting the
+// edges for which count returns true, none of which may touch p.
+func (x *boolean2D) winding(p *Vector2D, count func(e *booleanEdge2D) bool) [2]int {
+	var w [2]int
+	for i := range x.edges {
+		e := &x.edges[i]
+		a, b := &x.points[e.u], &x.points[e.v]
+		// a ray in +X, with ends level with p counting as below it
+		aAbove, bAbove := a.Y > p.Y, b.Y > p.Y
+		if aAbove == bAbove || (a.X < p.X && b.X < p.X) || !count(e) {
+			continue
+		}
+		switch o := Orient2D(a, b, p); {
+		case bAbove && o > 0:
+			w[0] += e.wind[0]
+			w[1] += e.wind[1]
+		case aAbove && o < 0:
+			w[0] -= e.wind[0]
+			w[1] -= e.wind[1]
+		}
+	}
+	return w
+}
+
+// polygons returns the polygons bounded by the kept edges, each directed
+// with the polygon on its left.
+func (x *boolean2D) polygons(kept [][2]int) MultiPolygon2D {
+	out := make([][]int, len(x.points))
+	for i, e := range kept {
+		out[e[0]] = append(out[e[0]], i)
+	}
+	// next returns the unused edge leaving the end of edge e that turns left
+	// the most, the first clockwise from e back to its start, or -1
+	used := make([]bool, len(kept))
+	next := func(e int) int {
+		u, v := kept[e][0], kept[e][1]
+		z := -1
+		for _, f := range out[v] {
+			if !used[f] && (z < 0 || x.ccw(v, u, kept[z][1], kept[f][1])) {
+				z = f
+			}
+		}
+		return z
+	}
+
+	var outers, holes [][]Vector2D
+	for s := range kept {
+		if used[s] {
+			continue
+		}
+		var walk []int
+		for e := s; e >= 0; e = next(e) {
+			used[e] = true
+			walk = append(walk, kept[e][0])
+		}
+		// a walk that visits a vertex twice is cut into simple rings there
+		pos := make(map[int]int)
+		var stack []int
+		emit := func(loop []int) {
+			r := make([]Vector2D, len(loop))
+			for i, v := range loop {
+				r[i] = x.points[v]
+			}
+			r = ringRemoveCollinear(r)
+			switch ringOrientation(r) {
+			case 1:
+				outers = append(outers, r)
+			case -1:
+				holes = append(holes, r)
+			}
+		}
+		for _, v := range walk {
+			if k, ok := pos[v]; ok {
+				emit(stack[k:])
+				for _, w := range stack[k:] {
+					delete(pos, w)
+				}
+				stack = stack[:k]
+			}
+			pos[v] = len(stack)
+			stack = append(stack, v)
+		}
+		emit(stack)
+	}
+
+	// each hole belongs to the smallest outer ring containing it
+	order := make([]int, len(outers))
+	areas := make([]float64, len(outers))
+	for i, r := range outers {
+		order[i], areas[i] = i, ringArea(r)
+	}
+	sort.SliceStable(order, func(i, j int) bool { return areas[order[i]] < areas[order[j]] })
+	z := make(MultiPolygon2D, len(outers))
+	for i, r := range outers {
+		z[i].Outer = r
+	}
+	for _, h := range holes {
+		for _, i := range order {
+			if ringInside(h, outers[i]) {
+				z[i].Holes = append(z[i].Holes, h)
+				break
+			}
+		}
+	}
+	return z
+}
+
+// ringInside returns true if ring h, which doesn't cross ring r, is inside r
+// or false otherwise. A ring entirely on r is inside it.
+func ringInside(h, r []Vector2D) bool {
+	for i := range h {
+		if c := ringLocate(r, &h[i]); c != 0 {
+			return c > 0
+		}
+	}
+	var m Vector2D
+	for i, j := 0, len(h)-1; i < len(h); j, i = i, i+1 {
+		m.Add(&h[i], &h[j])
+		m.Scale(&m, 0.5)
+		if c := ringLocate(r, &m); c != 0 {
+			return c > 0
+		}
+	}
+	return true
+}
+
+// ringLocate returns 1 if p is inside ring r, 0 if it is on an edge of r or -1
+// if it is outside, decided exactly with Orient2D.
+func ringLocate(r []Vector2D, p *Vector2D) int {
+	in := -1
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		a, b := &r[j], &r[i]
+		// only edges whose box holds p can touch it and only those crossing
+		// a ray in +X from it, with ends level with p counting as below it,
+		// can change in
+		box := math.Min(a.X, b.X) <= p.X && p.X <= math.Max(a.X, b.X) &&
+			math.Min(a.Y, b.Y) <= p.Y && p.Y <= math.Max(a.Y, b.Y)
+		aAbove, bAbove := a.Y > p.Y, b.Y > p.Y
+		if !box && (aAbove == bAbove || (a.X < p.X && b.X < p.X)) {
+			continue
+		}
+		if !box && a.X > p.X && b.X > p.X {
+			in = -in
+			continue
+		}
+		o := Orient2D(a, b, p)
+		if o == 0 && box {
+			return 0
+		}
+		if aAbove != bAbove && (o > 0) == bAbove {
+			in = -in
+		}
+	}
+	return in
+}
+
+// ringOrientation returns 1 if ring r, which must not cross itself, is
+// counterclockwise, -1 if it is clockwise or 0 if it has no area, decided
+// exactly with Orient2D at its lowest vertex.
+func ringOrientation(r []Vector2D) int {
+	if len(r) < 3 {
+		return 0
+	}
+	k := 0
+	for i := range r {
+		if r[i].X < r[k].X || (r[i].X == r[k].X && r[i].Y < r[k].Y) {
+			k = i
+		}
+	}
+	return Orient2D(&r[(k+len(r)-1)%len(r)], &r[k], &r[(k+1)%len(r)])
+}
+
+// ringRemoveCollinear removes the vertices of ring r that lie on the straight
+// line between their neighbors then returns r.
+func ringRemoveCollinear(r []Vector2D) []Vector2D {
+	for removed := true; removed && len(r) >= 3; {
+		removed = false
+		for i := 0; i < len(r) && len(r) >= 3; {
+			p, q := &r[(i+len(r)-1)%len(r)], &r[(i+1)%len(r)]
+			if Orient2D(p, &r[i], q) == 0 {
+				r = append(r[:i], r[i+1:]...)
+				removed = true
+				continue
+			}
+			i++
+		}
+	}
+	return r
+}
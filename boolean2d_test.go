@@ -0,0 +1,229 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// checkMultiPolygon2D returns a description of the first way x fails to have
+// simple counterclockwise outer rings containing simple clockwise holes, or
+// "" if it doesn't.
+func checkMultiPolygon2D(x MultiPolygon2D) string {
+	for _, p := range x {
+		if ringArea(p.Outer) <= 0 {
+			return "outer ring not counterclockwise"
+		}
+		if !(&Polygon2D{Outer: p.Outer}).IsSimple() {
+			return "outer ring not simple"
+		}
+		for _, h := range p.Holes {
+			if ringArea(h) >= 0 {
+				return "hole not clockwise"
+			}
+			if !(&Polygon2D{Outer: h}).IsSimple() {
+				return "hole not simple"
+			}
+			if !ringInside(h, p.Outer) {
+				return "hole outside outer ring"
+			}
+		}
+	}
+	return ""
+}
+
+// boolean2DSquare returns the axis aligned square with corners x0, y0 and x1,
+// y1.
+func boolean2DSquare(x0, y0, x1, y1 float64) Polygon2D {
+	return Polygon2D{Outer: []Vector2D{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}}}
+}
+
+var boolean2DValues = []struct {
+	a, b                              MultiPolygon2D
+	union, intersection, difference   float64
+	unionPolygons, differencePolygons int
+}{
+	// overlapping squares
+	{
+		MultiPolygon2D{boolean2DSquare(0, 0, 2, 2)},
+		MultiPolygon2D{boolean2DSquare(1, 1, 3, 3)},
+		7, 1, 3, 1, 1,
+	},
+	// touching at a corner, clockwise b
+	{
+		MultiPolygon2D{boolean2DSquare(0, 0, 1, 1)},
+		MultiPolygon2D{{Outer: []Vector2D{{1, 1}, {1, 2}, {2, 2}, {2, 1}}}},
+		2, 0, 1, 2, 1,
+	},
+	// sharing an edge, part of an edge and the same polygon
+	{
+		MultiPolygon2D{boolean2DSquare(0, 0, 1, 1)},
+		MultiPolygon2D{boolean2DSquare(1, 0, 2, 1)},
+		2, 0, 1, 1, 1,
+	},
+	{
+		MultiPolygon2D{boolean2DSquare(0, 0, 2, 2)},
+		MultiPolygon2D{boolean2DSquare(2, 1, 3, 4)},
+		7, 0, 4, 1, 1,
+	},
+	{
+		MultiPolygon2D{polygon2DL},
+		MultiPolygon2D{polygon2DL},
+		3, 3, 0, 1, 0,
+	},
+	// a square inside a hole, and one cutting a hole
+	{
+		MultiPolygon2D{polygon2DSquareWithHole},
+		MultiPolygon2D{boolean2DSquare(1.5, 1.5, 2.5, 2.5)},
+		13, 0, 12, 2, 1,
+	},
+	{
+		MultiPolygon2D{boolean2DSquare(0, 0, 4, 4)},
+		MultiPolygon2D{boolean2DSquare(1, 1, 3, 3)},
+		16, 4, 12, 1, 1,
+	},
+	// a hole touching the outer ring at a vertex
+	{
+		MultiPolygon2D{boolean2DSquare(0, 0, 4, 4)},
+		MultiPolygon2D{{Outer: []Vector2D{{0, 2}, {2, 1}, {2, 3}}}},
+		16, 2, 14, 1, 1,
+	},
+	// overlapping polygons in one operand, and an empty operand
+	{
+		MultiPolygon2D{boolean2DSquare(0, 0, 2, 2), boolean2DSquare(1, 0, 3, 2)},
+		nil,
+		6, 0, 6, 1, 1,
+	},
+	// a bow tie whose halves touch at the crossing
+	{
+		MultiPolygon2D{{Outer: []Vector2D{{0, 0}, {2, 2}, {2, 0}, {0, 2}}}},
+		MultiPolygon2D{boolean2DSquare(0, 0, 2, 1)},
+		3, 1, 1, 1, 2,
+	},
+}
+
+func TestMultiPolygon2DBoolean(t *testing.T) {
+	for _, v := range boolean2DValues {
+		var u, i, d, x MultiPolygon2D
+		u.Union(&v.a, &v.b)
+		i.Intersection(&v.a, &v.b)
+		d.Difference(&v.a, &v.b)
+		x.Xor(&v.a, &v.b)
+		for _, r := range []MultiPolygon2D{u, i, d, x} {
+			if err := checkMultiPolygon2D(r); err != "" {
+				t.Error("MultiPolygon2D boolean", v.a, v.b, err, r)
+			}
+		}
+		if !FuzzyEqual(u.Area(), v.union) || len(u) != v.unionPolygons {
+			t.Error("MultiPolygon2D.Union", v.a, v.b, "want", v.union, "got", u.Area(), u)
+		}
+		if !FuzzyEqual(i.Area(), v.intersection) {
+			t.Error("MultiPolygon2D.Intersection", v.a, v.b, "want", v.intersection, "got", i.Area(), i)
+		}
+		if !FuzzyEqual(d.Area(), v.difference) || len(d) != v.differencePolygons {
+			t.Error("MultiPolygon2D.Difference", v.a, v.b, "want", v.difference, "got", d.Area(), d)
+		}
+		if !FuzzyEqual(x.Area(), v.union-v.intersection) {
+			t.Error("MultiPolygon2D.Xor", v.a, v.b, "want", v.union-v.intersection, "got", x.Area(), x)
+		}
+	}
+}
+
+func TestMultiPolygon2DUnionShape(t *testing.T) {
+	// squares sharing an edge merge into a rectangle without the shared
+	// edge's end points
+	var z MultiPolygon2D
+	a := MultiPolygon2D{boolean2DSquare(0, 0, 1, 1)}
+	b := MultiPolygon2D{boolean2DSquare(1, 0, 2, 1)}
+	z.Union(&a, &b)
+	if len(z) != 1 || len(z[0].Outer) != 4 || z[0].Holes != nil {
+		t.Error("MultiPolygon2D.Union rectangle got", z)
+	}
+	// cutting a square from the middle leaves a hole
+	b = MultiPolygon2D{boolean2DSquare(0.25, 0.25, 0.75, 0.75)}
+	z.Difference(&a, &b)
+	if len(z) != 1 || len(z[0].Holes) != 1 || len(z[0].Holes[0]) != 4 {
+		t.Error("MultiPolygon2D.Difference hole got", z)
+	}
+	// the result may be one of the operands
+	z.Union(&z, &b)
+	if len(z) != 1 || z[0].Holes != nil || !FuzzyEqual(z.Area(), 1) {
+		t.Error("MultiPolygon2D.Union filled got", z)
+	}
+}
+
+// boolean2DStar returns a random star shaped polygon about c with n vertices.
+func boolean2DStar(r *rand.Rand, c Vector2D, n int) Polygon2D {
+	var p Polygon2D
+	for i := 0; i < n; i++ {
+		a := 2 * math.Pi * (float64(i) + r.Float64()*0.5) / float64(n)
+		d := 0.2 + r.Float64()
+		p.Outer = append(p.Outer, Vector2D{c.X + d*math.Cos(a), c.Y + d*math.Sin(a)})
+	}
+	return p
+}
+
+func TestMultiPolygon2DBooleanRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		var a, b MultiPolygon2D
+		for j := 0; j < 2; j++ {
+			if i%4 == 0 {
+				// squares on a grid for plenty of touching and overlapping
+				// edges
+				x, y := float64(r.Intn(4)), float64(r.Intn(4))
+				a = append(a, boolean2DSquare(x, y, x+float64(1+r.Intn(3)), y+float64(1+r.Intn(3))))
+				x, y = float64(r.Intn(4)), float64(r.Intn(4))
+				b = append(b, boolean2DSquare(x, y, x+float64(1+r.Intn(3)), y+float64(1+r.Intn(3))))
+				continue
+			}
+			a = append(a, boolean2DStar(r, Vector2D{r.Float64(), r.Float64()}, 3+r.Intn(10)))
+			b = append(b, boolean2DStar(r, Vector2D{r.Float64(), r.Float64()}, 3+r.Intn(10)))
+		}
+		var u, in, d, x MultiPolygon2D
+		u.Union(&a, &b)
+		in.Intersection(&a, &b)
+		d.Difference(&a, &b)
+		x.Xor(&a, &b)
+		for _, m := range []MultiPolygon2D{u, in, d, x} {
+			if err := checkMultiPolygon2D(m); err != "" {
+				t.Fatal("MultiPolygon2D boolean", a, b, err, m)
+			}
+		}
+		// overlapping polygons within a or b only count once
+		var ua, ub MultiPolygon2D
+		ua.Union(&a, &MultiPolygon2D{})
+		ub.Union(&b, &MultiPolygon2D{})
+		if !FuzzyEqual(u.Area()+in.Area(), ua.Area()+ub.Area()) ||
+			!FuzzyEqual(x.Area(), u.Area()-in.Area()) {
+			t.Fatal("MultiPolygon2D boolean areas", a, b)
+		}
+		// points away from every edge are in the result when they should be
+		for j := 0; j < 50; j++ {
+			p := Vector2D{r.Float64()*7 - 1, r.Float64()*7 - 1}
+			ca, cb := a.Contains(&p), b.Contains(&p)
+			if ca == 0 || cb == 0 {
+				continue
+			}
+			for _, v := range []struct {
+				m    MultiPolygon2D
+				want bool
+			}{{u, ca > 0 || cb > 0}, {in, ca > 0 && cb > 0}, {d, ca > 0 && cb < 0}, {x, (ca > 0) != (cb > 0)}} {
+				if c := v.m.Contains(&p); c != 0 && (c > 0) != v.want {
+					t.Fatal("MultiPolygon2D boolean", a, b, p, "want", v.want, "got", c)
+				}
+			}
+		}
+	}
+}
+
+func Benchmark_MultiPolygon2DUnion(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	x := MultiPolygon2D{boolean2DStar(r, Vector2D{0, 0}, 500)}
+	y := MultiPolygon2D{boolean2DStar(r, Vector2D{0.5, 0}, 500)}
+	var z MultiPolygon2D
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		z.Union(&x, &y)
+	}
+}
@@ -0,0 +1,66 @@
+package geometry
+
+import (
+	"math"
+)
+
+// A Capsule3D represents all points within a given distance, R, of the line
+// segment L.
+type Capsule3D struct {
+	L Line3D
+	R float64
+}
+
+// Bounds sets z to the smallest box containing x then returns z.
+func (x *Capsule3D) Bounds(z *AABB3D) *AABB3D {
+	return z.Expand(x.L.SegmentBounds(z), x.R)
+}
+
+// Contains returns true if point p is inside or on the surface of capsule x
+// or false otherwise.
+func (x *Capsule3D) Contains(p *Vector3D) bool {
+	return Distance3DLineSegmentPointSquared(&x.L, p) <= x.R*x.R
+}
+
+// Copy sets z to x then returns z.
+func (z *Capsule3D) Copy(x *Capsule3D) *Capsule3D {
+	z.L = x.L
+	z.R = x.R
+	return z
+}
+
+// Equal returns true if the two capsules are exactly equal, with line segments
+// in either direction, or false otherwise.
+func (a *Capsule3D) Equal(b *Capsule3D) bool {
+	return a.R == b.R && a.L.SegmentEqual(&b.L)
+}
+
+// FuzzyEqual returns true if the two capsules are very close, with line
+// segments in either direction, or false otherwise.
+func (a *Capsule3D) FuzzyEqual(b *Capsule3D) bool {
+	return DefaultTolerance.Capsule3DEqual(a, b)
+}
+
+// Capsule3DEqual is like Capsule3D.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) Capsule3DEqual(a, b *Capsule3D) bool {
+	return t.Equal(a.R, b.R) && t.Line3DSegmentEqual(&a.L, &b.L)
+}
+
+// Support returns the point of x furthest in direction d.
+func (x *Capsule3D) Support(d Vector3D) Vector3D {
+	p := x.L.P
+	if d.DotProduct(&x.L.V) > 0 {
+		p.Add(&p, &x.L.V)
+	}
+	return sphereSupport(&p, x.R, &d)
+}
+
+// SurfaceArea returns the surface area of x.
+func (x *Capsule3D) SurfaceArea() float64 {
+	return 2 * math.Pi * x.R * (2*x.R + x.L.Length())
+}
+
+// Volume returns the volume of x.
+func (x *Capsule3D) Volume() float64 {
+	return math.Pi * x.R * x.R * (4*x.R/3 + x.L.Length())
+}
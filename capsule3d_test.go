@@ -0,0 +1,60 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCapsule3DBounds(t *testing.T) {
+	c := &Capsule3D{Line3D{Vector3D{1, 2, 3}, Vector3D{0, 0, 4}}, 1}
+	var b AABB3D
+	if !c.Bounds(&b).FuzzyEqual(&AABB3D{Vector3D{0, 1, 2}, Vector3D{2, 3, 8}}) {
+		t.Error("Capsule3D.Bounds", c, "got", b)
+	}
+}
+
+func TestCapsule3DContains(t *testing.T) {
+	c := &Capsule3D{Line3D{Vector3D{0, 0, 0}, Vector3D{0, 0, 4}}, 1}
+	in := []Vector3D{{0, 0, 2}, {1, 0, 2}, {0, 0, 5}, {0, 0, -1}, {0.5, 0.5, 4.5}}
+	out := []Vector3D{{1.1, 0, 2}, {0, 0, 5.1}, {0.8, 0.8, 4.5}}
+	for i := range in {
+		if !c.Contains(&in[i]) {
+			t.Error("Capsule3D.Contains", c, in[i], "want", true)
+		}
+	}
+	for i := range out {
+		if c.Contains(&out[i]) {
+			t.Error("Capsule3D.Contains", c, out[i], "want", false)
+		}
+	}
+}
+
+func TestCapsule3DEqual(t *testing.T) {
+	a := &Capsule3D{Line3D{Vector3D{0, 0, 0}, Vector3D{0, 0, 4}}, 1}
+	b := &Capsule3D{Line3D{Vector3D{0, 0, 4}, Vector3D{0, 0, -4}}, 1}
+	c := &Capsule3D{Line3D{Vector3D{0, 0, 0}, Vector3D{0, 0, 4}}, 2}
+	if !a.Equal(b) || a.Equal(c) || !a.FuzzyEqual(b) || a.FuzzyEqual(c) {
+		t.Error("Capsule3D.Equal", a, b, c)
+	}
+	var z Capsule3D
+	if !z.Copy(c).Equal(c) {
+		t.Error("Capsule3D.Copy", c, z)
+	}
+}
+
+func TestCapsule3DSupport(t *testing.T) {
+	c := &Capsule3D{Line3D{Vector3D{0, 0, 0}, Vector3D{0, 0, 4}}, 1}
+	if p := c.Support(Vector3D{0, 0, 2}); !p.FuzzyEqual(&Vector3D{0, 0, 5}) {
+		t.Error("Capsule3D.Support", c, "got", p)
+	}
+	if p := c.Support(Vector3D{-3, 0, 0}); p.X != -1 || p.Y != 0 {
+		t.Error("Capsule3D.Support", c, "got", p)
+	}
+}
+
+func TestCapsule3DVolume(t *testing.T) {
+	c := &Capsule3D{Line3D{Vector3D{0, 0, 0}, Vector3D{0, 0, 4}}, 1}
+	if !FuzzyEqual(c.Volume(), math.Pi*(4.0/3+4)) || !FuzzyEqual(c.SurfaceArea(), 2*math.Pi*6) {
+		t.Error("Capsule3D.Volume", c, c.Volume(), c.SurfaceArea())
+	}
+}
@@ -15,6 +15,20 @@ func (x *Circle) Area() float64 {
 	return math.Pi * x.R * x.R
 }
 
+// Bounds sets z to the smallest box containing x then returns z.
+func (x *Circle) Bounds(z *AABB2D) *AABB2D {
+	z.Min.X, z.Min.Y = x.C.X-x.R, x.C.Y-x.R
+	z.Max.X, z.Max.Y = x.C.X+x.R, x.C.Y+x.R
+	return z
+}
+
+// Contains returns true if point p is inside or on circle x or false
+// otherwise.
+func (x *Circle) Contains(p *Vector2D) bool {
+	dx, dy := p.X-x.C.X, p.Y-x.C.Y
+	return dx*dx+dy*dy <= x.R*x.R
+}
+
 // Copy sets z to z then returns z.
 func (z *Circle) Copy(x *Circle) *Circle {
 	z.C = x.C
@@ -52,10 +66,39 @@ func (z *Circle) FromThreePoints(p1, p2, p3 *Vector2D) *Circle {
 // Fuzzy equal returns true if the two circles are very close or false
 // otherwise.
 func (a *Circle) FuzzyEqual(b *Circle) bool {
-	return FuzzyEqual(a.C.X, b.C.X) && FuzzyEqual(a.C.Y, b.C.Y) && FuzzyEqual(a.R, b.R)
+	return DefaultTolerance.CircleEqual(a, b)
+}
+
+// CircleEqual is like Circle.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) CircleEqual(a, b *Circle) bool {
+	return t.Equal(a.C.X, b.C.X) && t.Equal(a.C.Y, b.C.Y) && t.Equal(a.R, b.R)
 }
 
 // Premimeter returns the perimeter of the circle.
 func (x *Circle) Perimeter() float64 {
 	return 2 * math.Pi * x.R
 }
+
+// Support returns the point of x furthest in direction d.
+func (x *Circle) Support(d Vector2D) Vector2D {
+	m := d.Magnitude()
+	if m == 0 {
+		return x.C
+	}
+	m = x.R / m
+	return Vector2D{x.C.X + d.X*m, x.C.Y + d.Y*m}
+}
+
+// Transform sets z to circle x transformed by m then returns z. The transform
+// must be a similarity (see Affine2D.IsSimilarity) for the result to be a
+// circle; if it is not z's radius is set to NaN. Use Ellipse.TransformCircle
+// for other transforms.
+func (z *Circle) Transform(m *Affine2D, x *Circle) *Circle {
+	r := math.NaN()
+	if m.IsSimilarity() {
+		r = x.R * math.Sqrt(math.Abs(m.Determinant()))
+	}
+	z.C.TransformPoint(m, &x.C)
+	z.R = r
+	return z
+}
@@ -0,0 +1,77 @@
+package geometry
+
+import (
+	"math"
+)
+
+// A Circle3D represents all points a given distance, R, from a point, C, in
+// the plane through C perpendicular to the unit normal N.
+type Circle3D struct {
+	C Vector3D
+	N Vector3D
+	R float64
+}
+
+// Area returns the area of the circle.
+func (x *Circle3D) Area() float64 {
+	return math.Pi * x.R * x.R
+}
+
+// Bounds sets z to the smallest box containing x then returns z.
+func (x *Circle3D) Bounds(z *AABB3D) *AABB3D {
+	// the extent along each axis is R times the sine of the angle between the
+	// axis and the normal
+	rx := x.R * math.Sqrt(math.Max(0, 1-x.N.X*x.N.X))
+	ry := x.R * math.Sqrt(math.Max(0, 1-x.N.Y*x.N.Y))
+	rz := x.R * math.Sqrt(math.Max(0, 1-x.N.Z*x.N.Z))
+	z.Min.X, z.Min.Y, z.Min.Z = x.C.X-rx, x.C.Y-ry, x.C.Z-rz
+	z.Max.X, z.Max.Y, z.Max.Z = x.C.X+rx, x.C.Y+ry, x.C.Z+rz
+	return z
+}
+
+// Copy sets z to x then returns z.
+func (z *Circle3D) Copy(x *Circle3D) *Circle3D {
+	z.C = x.C
+	z.N = x.N
+	z.R = x.R
+	return z
+}
+
+// Equal returns true if the two circles are exactly equal, with normals in
+// either direction, or false otherwise.
+func (a *Circle3D) Equal(b *Circle3D) bool {
+	return a.C == b.C && a.R == b.R &&
+		(a.N == b.N || (a.N.X == -b.N.X && a.N.Y == -b.N.Y && a.N.Z == -b.N.Z))
+}
+
+// FuzzyEqual returns true if the two circles are very close, with normals in
+// either direction, or false otherwise.
+func (a *Circle3D) FuzzyEqual(b *Circle3D) bool {
+	return DefaultTolerance.Circle3DEqual(a, b)
+}
+
+// Circle3DEqual is like Circle3D.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) Circle3DEqual(a, b *Circle3D) bool {
+	if !t.Vector3DEqual(&a.C, &b.C) || !t.Equal(a.R, b.R) {
+		return false
+	}
+	if t.Vector3DEqual(&a.N, &b.N) {
+		return true
+	}
+	var n Vector3D
+	n.Scale(&b.N, -1)
+	return t.Vector3DEqual(&a.N, &n)
+}
+
+// Perimeter returns the perimeter of the circle.
+func (x *Circle3D) Perimeter() float64 {
+	return 2 * math.Pi * x.R
+}
+
+// Plane sets z to the plane the circle lies in, with the same normal, then
+// returns z.
+func (x *Circle3D) Plane(z *Plane) *Plane {
+	z.A, z.B, z.C = x.N.X, x.N.Y, x.N.Z
+	z.D = -x.N.DotProduct(&x.C)
+	return z
+}
@@ -0,0 +1,42 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCircle3DBounds(t *testing.T) {
+	c := &Circle3D{Vector3D{1, 2, 3}, Vector3D{0, 0, 1}, 2}
+	var b AABB3D
+	if !c.Bounds(&b).FuzzyEqual(&AABB3D{Vector3D{-1, 0, 3}, Vector3D{3, 4, 3}}) {
+		t.Error("Circle3D.Bounds", c, "got", b)
+	}
+	c.N = Vector3D{math.Sqrt2 / 2, 0, math.Sqrt2 / 2}
+	if !c.Bounds(&b).FuzzyEqual(&AABB3D{Vector3D{1 - math.Sqrt2, 0, 3 - math.Sqrt2}, Vector3D{1 + math.Sqrt2, 4, 3 + math.Sqrt2}}) {
+		t.Error("Circle3D.Bounds", c, "got", b)
+	}
+}
+
+func TestCircle3DEqual(t *testing.T) {
+	a := &Circle3D{Vector3D{1, 2, 3}, Vector3D{0, 0, 1}, 2}
+	b := &Circle3D{Vector3D{1, 2, 3}, Vector3D{0, 0, -1}, 2}
+	c := &Circle3D{Vector3D{1, 2, 3}, Vector3D{0, 1, 0}, 2}
+	if !a.Equal(b) || a.Equal(c) || !a.FuzzyEqual(b) || a.FuzzyEqual(c) {
+		t.Error("Circle3D.Equal", a, b, c)
+	}
+	var z Circle3D
+	if !z.Copy(c).Equal(c) {
+		t.Error("Circle3D.Copy", c, z)
+	}
+}
+
+func TestCircle3DPlane(t *testing.T) {
+	c := &Circle3D{Vector3D{1, 2, 3}, Vector3D{0, 0, 1}, 2}
+	var p Plane
+	if !c.Plane(&p).Equal(&Plane{0, 0, 1, -3}) {
+		t.Error("Circle3D.Plane", c, "got", p)
+	}
+	if !FuzzyEqual(c.Area(), 4*math.Pi) || !FuzzyEqual(c.Perimeter(), 4*math.Pi) {
+		t.Error("Circle3D.Area", c, c.Area(), c.Perimeter())
+	}
+}
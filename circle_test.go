@@ -156,3 +156,24 @@ func TestCirclePreimeter(t *testing.T) {
 		testCirclePerimeter(v, t)
 	}
 }
+
+func TestCircleTransform(t *testing.T) {
+	var m Affine2D
+	var c Circle
+	m.Compose(&Vector2D{1, 2}, &Vector2D{2, -2}, 0.3, 0)
+	want := &Circle{Vector2D{1, 2}, 6}
+	if !c.Transform(&m, &Circle{Vector2D{}, 3}).FuzzyEqual(want) {
+		t.Error("Circle.Transform", "want", want, "got", c)
+	}
+	m.Scaling(&Vector2D{1, 2})
+	if c.Transform(&m, &Circle{Vector2D{}, 3}); !math.IsNaN(c.R) {
+		t.Error("Circle.Transform non-uniform scale", "got", c)
+	}
+}
+
+func TestCircleContains(t *testing.T) {
+	c := &Circle{Vector2D{1, 1}, 2}
+	if !c.Contains(&Vector2D{1, 3}) || !c.Contains(&Vector2D{2, 2}) || c.Contains(&Vector2D{3, 3}) {
+		t.Error("Circle.Contains", c)
+	}
+}
@@ -0,0 +1,699 @@
+package geometry
+
+import (
+	"math"
+)
+
+// A ConstrainedDelaunay2D is a triangulation in which given line segments are
+// edges and triangles in holes are removed. Otherwise it is Delaunay where the
+// segments block visibility: no point visible from inside a triangle is inside
+// its circumcircle.
+type ConstrainedDelaunay2D struct {
+	IncrementalDelaunay2D
+}
+
+// NewConstrainedDelaunay2D returns the constrained Delaunay triangulation of
+// points and segments. Segment end points not in points are appended to
+// Points, except that an end within rounding of a point is moved onto it. A
+// segment is split at any point on it and where it crosses another segment,
+// with the crossing appended to Points. Should the crossing round to within
+// rounding of the nearer end of the edge it crosses, or beyond some other
+// segment, the segment is instead bent through that end.
+//
+// Every triangle that can be reached from a hole point without crossing a
+// segment is removed. A hole point outside the convex hull removes every
+// triangle reachable from the hull, leaving only the regions enclosed by
+// segments.
+func NewConstrainedDelaunay2D(points []Vector2D, segments []Line2D, holes []Vector2D) *ConstrainedDelaunay2D {
+	x := &ConstrainedDelaunay2D{*NewIncrementalDelaunay2D(append([]Vector2D(nil), points...))}
+	ends := make([][2]int, len(segments))
+	for i := range segments {
+		var b Vector2D
+		s := &segments[i]
+		b.Add(&s.P, &s.V)
+		// the end may be a point rounded in finding V then adding it
+		tol := 0x1p-52 * (math.Max(math.Abs(s.V.X), math.Abs(s.V.Y)) + math.Max(math.Abs(b.X), math.Abs(b.Y)))
+		ends[i] = [2]int{x.vertex(s.P, 0), x.vertex(b, tol)}
+	}
+	if len(x.tri) == 0 {
+		return x
+	}
+	for _, e := range ends {
+		x.insertSegment(e[0], e[1])
+	}
+	for i := range holes {
+		x.removeHole(&holes[i])
+	}
+	// the hull bounds refinement just like a segment
+	for t, v := range x.tri {
+		for i := range v {
+			if v[i] == ghost {
+				x.fixEdge(t, i)
+			}
+		}
+	}
+	return x
+}
+
+// Refine adds points to x until no triangle has an angle smaller than
+// minAngle, in radians, or an area larger than maxArea, then returns the
+// number of points added. A limit that is not positive is ignored, and with
+// neither limit nothing is added.
+//
+// Following Ruppert, each poor triangle gets a point at its circumcenter,
+// unless that would encroach on a segment, that is lie inside the circle with
+// the segment as diameter, in which case the segment is split at its midpoint
+// instead. Refinement finishes with every triangle within the limits for
+// minAngle up to about 20.7 degrees and segments that meet at 60 degrees or
+// more. Otherwise it may leave triangles that could only be improved by adding
+// an edge shorter than a billionth of the size of the mesh.
+//
+// Segments that meet at a smaller angle are split, following Shewchuk, on
+// circles about the shared vertex with radii a power of two, so points on one
+// don't encroach on the other. The skinny triangles this leaves inside the
+// angle, whose shortest edge joins points equally far along the segments, are
+// not improved.
+func (x *ConstrainedDelaunay2D) Refine(minAngle, maxArea float64) int {
+	if len(x.tri) == 0 || (minAngle <= 0 && maxArea <= 0) {
+		return 0
+	}
+	n := len(x.Points)
+	var b AABB2D
+	var size Vector2D
+	r := &refinement2D{x: &x.IncrementalDelaunay2D, maxArea: maxArea, ends: map[int][2]int{}}
+	r.minLength = b.FromPoints(x.Points...).Size(&size).Magnitude() * 1e-9
+	if minAngle > 0 {
+		r.sinMinAngle = math.Sin(minAngle)
+	}
+	for t := range x.tri {
+		r.added(t)
+	}
+	for len(r.segments) > 0 || len(r.triangles) > 0 {
+		// encroached segments are split before any triangle is improved
+		if len(r.segments) > 0 {
+			e := r.segments[len(r.segments)-1]
+			r.segments = r.segments[:len(r.segments)-1]
+			if t, k, ok := x.findEdge(e[0], e[1]); ok && x.fixed[t][k] && r.encroached(t, k) {
+				r.split(t, k)
+			}
+			continue
+		}
+		t := r.triangles[0]
+		r.triangles = r.triangles[1:]
+		if r.poor(t) {
+			r.improve(t)
+		}
+	}
+	return len(x.Points) - n
+}
+
+// A refinement2D holds the state of ConstrainedDelaunay2D.Refine.
+type refinement2D struct {
+	x           *IncrementalDelaunay2D
+	sinMinAngle float64
+	maxArea     float64
+	minLength   float64        // shortest edge that is created
+	ends        map[int][2]int // input segment each split point is on
+	segments    [][2]int       // constraints to check for encroachment
+	triangles   []int          // triangles to check for quality
+}
+
+// added queues triangle t and its constraints to be checked.
+func (r *refinement2D) added(t int) {
+	x := r.x
+	if x.hole[t] || x.isGhost(t) {
+		return
+	}
+	r.triangles = append(r.triangles, t)
+	for k, f := range x.fixed[t] {
+		if f {
+			r.segments = append(r.segments, [2]int{x.tri[t][(k+1)%3], x.tri[t][(k+2)%3]})
+		}
+	}
+}
+
+// encroached returns true if the constraint of triangle t opposite vertex k
+// has a vertex of either neighboring triangle inside its diametral circle or
+// false otherwise. A vertex within rounding of the constraint is on it rather
+// than encroaching.
+func (r *refinement2D) encroached(t, k int) bool {
+	x := r.x
+	i, j := x.tri[t][(k+1)%3], x.tri[t][(k+2)%3]
+	a, b := &x.Points[i], &x.Points[j]
+	n := x.adj[t][k]
+	if p := &x.Points[x.tri[t][k]]; !x.hole[t] && !x.isGhost(t) && encroaches(a, b, p) && !onLine(a, b, p) {
+		return true
+	}
+	if x.hole[n] || x.isGhost(n) {
+		return false
+	}
+	p := &x.Points[x.tri[n][x.opposite(n, j, i)]]
+	return encroaches(a, b, p) && !onLine(a, b, p)
+}
+
+// encroaches returns true if p is strictly inside the circle with diameter ab
+// or false otherwise.
+func encroaches(a, b, p *Vector2D) bool {
+	return (a.X-p.X)*(b.X-p.X)+(a.Y-p.Y)*(b.Y-p.Y) < 0
+}
+
+// poor returns true if triangle t is in the mesh and has an angle or area
+// outside the limits, and no edge shorter than the minimum length, or false
+// otherwise. A triangle with every vertex on one segment or the hull, which
+// rounding can leave where it is split, is never poor: it has no usable
+// circumcenter and splitting the segment only makes more.
+func (r *refinement2D) poor(t int) bool {
+	x := r.x
+	if x.hole[t] || x.isGhost(t) {
+		return false
+	}
+	v := &x.tri[t]
+	a, b, c := &x.Points[v[0]], &x.Points[v[1]], &x.Points[v[2]]
+	for k, f := range x.fixed[t] {
+		if f && onLine(&x.Points[v[(k+1)%3]], &x.Points[v[(k+2)%3]], &x.Points[v[k]]) {
+			return false
+		}
+	}
+	area := ((b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)) / 2
+	la := math.Hypot(b.X-c.X, b.Y-c.Y)
+	lb := math.Hypot(c.X-a.X, c.Y-a.Y)
+	lc := math.Hypot(a.X-b.X, a.Y-b.Y)
+	shortest := math.Min(la, math.Min(lb, lc))
+	if shortest < r.minLength {
+		return false
+	}
+	if r.maxArea > 0 && area > r.maxArea {
+		return true
+	}
+	k := 0
+	if lb == shortest {
+		k = 1
+	} else if lc == shortest {
+		k = 2
+	}
+	if r.seditious(v[(k+1)%3], v[(k+2)%3]) {
+		return false
+	}
+	// the smallest angle is opposite the shortest edge and its sine is the
+	// edge length over the circumcircle diameter, la*lb*lc/(2*area)
+	return 2*area*shortest < r.sinMinAngle*la*lb*lc
+}
+
+// improve adds a point at the circumcenter of triangle t, or splits the
+// segments it would encroach on instead. A triangle too flat for its
+// circumcenter to be represented gets a point at the midpoint of its longest
+// edge.
+func (r *refinement2D) improve(t int) {
+	x := r.x
+	v := &x.tri[t]
+	p := circumcenter2D(&x.Points[v[0]], &x.Points[v[1]], &x.Points[v[2]])
+	if math.IsInf(p.X, 0) || math.IsInf(p.Y, 0) || math.IsNaN(p.X) || math.IsNaN(p.Y) {
+		k := 0
+		for i := 1; i < 3; i++ {
+			if r.edgeLength(t, i) > r.edgeLength(t, k) {
+				k = i
+			}
+		}
+		a, b := &x.Points[v[(k+1)%3]], &x.Points[v[(k+2)%3]]
+		p = Vector2D{(a.X + b.X) / 2, (a.Y + b.Y) / 2}
+	}
+	s, k := x.walkFrom(t, &p, true)
+	if k >= 0 {
+		// a segment hides the circumcenter from t
+		if r.split(s, k) {
+			r.triangles = append(r.triangles, t)
+		}
+		return
+	}
+	if x.isGhost(s) {
+		return
+	}
+	for _, i := range x.tri[s] {
+		if x.Points[i] == p {
+			return
+		}
+	}
+	cav := x.cavity(&p, s)
+	var encroached [][2]int
+	for _, e := range cav.boundary {
+		if e.fixed && e.a != ghost && e.b != ghost && encroaches(&x.Points[e.a], &x.Points[e.b], &p) {
+			encroached = append(encroached, [2]int{e.a, e.b})
+		}
+	}
+	if len(encroached) == 0 {
+		i := len(x.Points)
+		x.Points = append(x.Points, p)
+		for _, s := range x.commit(i, cav) {
+			r.added(s)
+		}
+		return
+	}
+	x.release(cav)
+	retry := false
+	for _, e := range encroached {
+		if s, k, ok := x.findEdge(e[0], e[1]); ok && r.split(s, k) {
+			retry = true
+		}
+	}
+	if retry {
+		r.triangles = append(r.triangles, t)
+	}
+}
+
+// circumcenter2D returns the center of the circle through a, b and c. It is
+// found relative to a, which keeps rounding small for the thin triangles
+// refinement improves.
+func circumcenter2D(a, b, c *Vector2D) Vector2D {
+	bx, by := b.X-a.X, b.Y-a.Y
+	cx, cy := c.X-a.X, c.Y-a.Y
+	d := 2 * (bx*cy - by*cx)
+	b2, c2 := bx*bx+by*by, cx*cx+cy*cy
+	return Vector2D{a.X + (cy*b2-by*c2)/d, a.Y + (bx*c2-cx*b2)/d}
+}
+
+// edgeLength returns the length of the edge of triangle t opposite vertex k.
+func (r *refinement2D) edgeLength(t, k int) float64 {
+	a, b := &r.x.Points[r.x.tri[t][(k+1)%3]], &r.x.Points[r.x.tri[t][(k+2)%3]]
+	return math.Hypot(b.X-a.X, b.Y-a.Y)
+}
+
+// onLine returns true if p is within rounding of the line through a and b, as
+// the points splitting a segment are, or false otherwise.
+func onLine(a, b, p *Vector2D) bool {
+	tol := 0x1p-50 * math.Max(math.Max(math.Abs(a.X), math.Abs(a.Y)), math.Max(math.Abs(b.X), math.Abs(b.Y)))
+	cross := (b.X-a.X)*(p.Y-a.Y) - (b.Y-a.Y)*(p.X-a.X)
+	return math.Abs(cross) <= tol*math.Hypot(b.X-a.X, b.Y-a.Y)
+}
+
+// seditious returns true if split points i and j are on different input
+// segments and equally far from a vertex the segments share, or false
+// otherwise.
+func (r *refinement2D) seditious(i, j int) bool {
+	si, iok := r.ends[i]
+	sj, jok := r.ends[j]
+	if !iok || !jok || si == sj {
+		return false
+	}
+	for _, c := range si {
+		if c == sj[0] || c == sj[1] {
+			di := Distance2DPointPoint(&r.x.Points[c], &r.x.Points[i])
+			dj := Distance2DPointPoint(&r.x.Points[c], &r.x.Points[j])
+			return di < 1.001*dj && dj < 1.001*di
+		}
+	}
+	return false
+}
+
+// acute returns true if a constraint at vertex i other than the one to vertex
+// j makes an angle of less than 60 degrees with it, or false otherwise.
+func (r *refinement2D) acute(i, j int) bool {
+	x := r.x
+	p := &x.Points[i]
+	dx, dy := x.Points[j].X-p.X, x.Points[j].Y-p.Y
+	t, k := x.around(i)
+	for start := t; ; {
+		if u := x.tri[t][(k+1)%3]; u != ghost && u != j && x.fixed[t][(k+2)%3] {
+			ex, ey := x.Points[u].X-p.X, x.Points[u].Y-p.Y
+			if d := dx*ex + dy*ey; d > 0 && 4*d*d > (dx*dx+dy*dy)*(ex*ex+ey*ey) {
+				return true
+			}
+		}
+		t = x.adj[t][(k+1)%3]
+		k = x.indexOf(t, i)
+		if t == start {
+			return false
+		}
+	}
+}
+
+// split adds a point on the constraint of triangle t opposite vertex k then
+// returns true, or returns false if the pieces would be shorter than the
+// minimum length. The point is at the midpoint, or on a circle about the end
+// of the constraint that is acute.
+func (r *refinement2D) split(t, k int) bool {
+	x := r.x
+	i, j := x.tri[t][(k+1)%3], x.tri[t][(k+2)%3]
+	l := Distance2DPointPoint(&x.Points[i], &x.Points[j])
+	if l < 2*r.minLength {
+		return false
+	}
+	a, b := &x.Points[i], &x.Points[j]
+	p := Vector2D{(a.X + b.X) / 2, (a.Y + b.Y) / 2}
+	if ai, bj := r.acute(i, j), r.acute(j, i); ai != bj {
+		if bj {
+			a, b = b, a
+		}
+		s := math.Exp2(math.Round(math.Log2(l/2))) / l
+		p = Vector2D{a.X + s*(b.X-a.X), a.Y + s*(b.Y-a.Y)}
+	}
+	n := len(x.Points)
+	x.Points = append(x.Points, p)
+	changed, ok := x.split(n, t, k)
+	if !ok {
+		x.Points = x.Points[:n]
+		return false
+	}
+	e, ok := r.ends[i]
+	if !ok {
+		if e, ok = r.ends[j]; !ok {
+			e = [2]int{i, j}
+			if j < i {
+				e = [2]int{j, i}
+			}
+		}
+	}
+	r.ends[n] = e
+	for _, s := range changed {
+		r.added(s)
+	}
+	return true
+}
+
+// vertex returns the index of the nearest vertex with coordinates within tol
+// of those of p, adding p if there is none.
+func (x *IncrementalDelaunay2D) vertex(p Vector2D, tol float64) int {
+	candidates := x.pending
+	if len(x.tri) > 0 {
+		// just outside the hull p is in a ghost triangle with the hull edge
+		candidates = x.tri[x.walk(&p)][:]
+	}
+	z, d := -1, math.Inf(1)
+	for _, v := range candidates {
+		if v == ghost {
+			continue
+		}
+		q := &x.Points[v]
+		if near(q, &p, tol) {
+			if e := Distance2DPointPointSquared(q, &p); e < d {
+				z, d = v, e
+			}
+		}
+	}
+	if z >= 0 {
+		return z
+	}
+	x.Add(p)
+	return len(x.Points) - 1
+}
+
+// near returns true if p and q differ by at most tol in each coordinate or
+// false otherwise.
+func near(p, q *Vector2D, tol float64) bool {
+	return math.Abs(p.X-q.X) <= tol && math.Abs(p.Y-q.Y) <= tol
+}
+
+// around returns a triangle with vertex a and the index of a in it.
+func (x *IncrementalDelaunay2D) around(a int) (int, int) {
+	t := x.walk(&x.Points[a])
+	for k, v := range x.tri[t] {
+		if v == a {
+			return t, k
+		}
+	}
+	// a hull bent slightly inwards by a split can end the walk beside a
+	for t, v := range x.tri {
+		for k := range v {
+			if v[k] == a {
+				return t, k
+			}
+		}
+	}
+	panic("geometry: vertex not in triangulation")
+}
+
+// findEdge returns a triangle with the edge from a to b, the index of the
+// vertex opposite it, and true, or false if there is no such edge.
+func (x *IncrementalDelaunay2D) findEdge(a, b int) (int, int, bool) {
+	t, k := x.around(a)
+	for start := t; ; {
+		if x.tri[t][(k+1)%3] == b {
+			return t, (k + 2) % 3, true
+		}
+		t = x.adj[t][(k+1)%3]
+		k = x.indexOf(t, a)
+		if t == start {
+			return 0, 0, false
+		}
+	}
+}
+
+// fixEdge makes the edge of triangle t opposite vertex k a constraint.
+func (x *IncrementalDelaunay2D) fixEdge(t, k int) {
+	x.fixed[t][k] = true
+	n := x.adj[t][k]
+	x.fixed[n][x.opposite(n, x.tri[t][(k+2)%3], x.tri[t][(k+1)%3])] = true
+}
+
+// removeHole removes every triangle that can be reached from the one
+// containing p without crossing a constraint.
+func (x *IncrementalDelaunay2D) removeHole(p *Vector2D) {
+	t := x.walk(p)
+	if x.hole[t] {
+		return
+	}
+	x.hole[t] = true
+	x.index = nil
+	for stack := []int{t}; len(stack) > 0; {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for k, n := range x.adj[c] {
+			if !x.fixed[c][k] && !x.hole[n] {
+				x.hole[n] = true
+				stack = append(stack, n)
+			}
+		}
+	}
+}
+
+// sameDirection returns true if collinear points b and c are on the same side
+// of a or false otherwise.
+func sameDirection(a, b, c *Vector2D) bool {
+	if a.X != b.X {
+		return (b.X > a.X) == (c.X > a.X)
+	}
+	return (b.Y > a.Y) == (c.Y > a.Y)
+}
+
+// insertSegment makes the segment from point a to point b a constraint,
+// splitting it at points on it and where it crosses other constraints.
+func (x *IncrementalDelaunay2D) insertSegment(a, b int) {
+segment:
+	for a != b {
+		pa, pb := x.Points[a], x.Points[b]
+		// turn around a to the triangle the segment leaves through
+		t, k := x.around(a)
+		for {
+			u, w := x.tri[t][(k+1)%3], x.tri[t][(k+2)%3]
+			if u != ghost {
+				pu := &x.Points[u]
+				o := Orient2D(&pa, pu, &pb)
+				if o == 0 && sameDirection(&pa, pu, &pb) {
+					x.fixEdge(t, (k+2)%3)
+					a = u
+					continue segment
+				}
+				if o > 0 && w != ghost && Orient2D(&pa, &x.Points[w], &pb) < 0 {
+					break
+				}
+			}
+			t = x.adj[t][(k+1)%3]
+			k = x.indexOf(t, a)
+		}
+
+		// walk along the segment collecting the edges it crosses until it
+		// reaches b or a point on it
+		var crossing [][2]int
+		u, w := x.tri[t][(k+1)%3], x.tri[t][(k+2)%3]
+		c := b
+		for {
+			if x.fixed[t][k] {
+				// split both segments where they cross
+				pu, pw := &x.Points[u], &x.Points[w]
+				dx, dy := pb.X-pa.X, pb.Y-pa.Y
+				ex, ey := pw.X-pu.X, pw.Y-pu.Y
+				s := ((pu.X-pa.X)*ey - (pu.Y-pa.Y)*ex) / (dx*ey - dy*ex)
+				p := Vector2D{pa.X + s*dx, pa.Y + s*dy}
+				// should the crossing be within rounding of an end of this
+				// constraint, onto an end of the segment, or beyond another
+				// constraint, go through the nearest end of this one instead
+				i := u
+				if Distance2DPointPointSquared(&p, pw) < Distance2DPointPointSquared(&p, pu) {
+					i = w
+				}
+				tol := 0x1p-50 * (math.Max(math.Abs(pu.X), math.Abs(pu.Y)) + math.Max(math.Abs(pw.X), math.Abs(pw.Y)))
+				if p != pa && p != pb && !near(&p, &x.Points[i], tol) {
+					n := len(x.Points)
+					x.Points = append(x.Points, p)
+					if _, ok := x.split(n, t, k); ok {
+						i = n
+					} else {
+						x.Points = x.Points[:n]
+					}
+				}
+				x.insertSegment(a, i)
+				a = i
+				continue segment
+			}
+			crossing = append(crossing, [2]int{u, w})
+			n := x.adj[t][k]
+			m := x.opposite(n, w, u)
+			q := x.tri[n][m]
+			if q == b {
+				break
+			}
+			o := Orient2D(&pa, &pb, &x.Points[q])
+			if o == 0 {
+				c = q
+				break
+			}
+			t = n
+			if o > 0 {
+				k, w = (m+1)%3, q
+			} else {
+				k, u = (m+2)%3, q
+			}
+		}
+
+		created := x.removeCrossings(crossing, &pa, &x.Points[c])
+		t, k, _ = x.findEdge(a, c)
+		x.fixEdge(t, k)
+		x.legalize(created)
+		a = c
+	}
+}
+
+// removeCrossings flips the edges in crossing, which cross the segment from
+// a to b, until none do then returns the edges created.
+func (x *IncrementalDelaunay2D) removeCrossings(crossing [][2]int, a, b *Vector2D) [][2]int {
+	var created [][2]int
+	for len(crossing) > 0 {
+		e := crossing[0]
+		crossing = crossing[1:]
+		t, k, _ := x.findEdge(e[0], e[1])
+		n := x.adj[t][k]
+		v0, o := x.tri[t][k], x.tri[n][x.opposite(n, e[1], e[0])]
+		p0, po := &x.Points[v0], &x.Points[o]
+		// only the diagonal of a convex quadrilateral can be flipped
+		if Orient2D(p0, po, &x.Points[e[0]]) >= 0 || Orient2D(p0, po, &x.Points[e[1]]) <= 0 {
+			crossing = append(crossing, e)
+			continue
+		}
+		x.flip(t, k)
+		if Orient2D(a, b, p0)*Orient2D(a, b, po) < 0 {
+			crossing = append(crossing, [2]int{v0, o})
+		} else {
+			created = append(created, [2]int{v0, o})
+		}
+	}
+	return created
+}
+
+// legalize flips edges that are not constraints until every edge is locally
+// Delaunay, given that any that are not are in edges, then returns the
+// triangles changed.
+func (x *IncrementalDelaunay2D) legalize(edges [][2]int) []int {
+	var changed []int
+	for len(edges) > 0 {
+		e := edges[len(edges)-1]
+		edges = edges[:len(edges)-1]
+		t, k, ok := x.findEdge(e[0], e[1])
+		if !ok || x.fixed[t][k] || x.isGhost(t) || x.isGhost(x.adj[t][k]) {
+			continue
+		}
+		n := x.adj[t][k]
+		v := x.tri[t]
+		o := x.tri[n][x.opposite(n, e[1], e[0])]
+		if InCircle(&x.Points[v[0]], &x.Points[v[1]], &x.Points[v[2]], &x.Points[o]) > 0 {
+			x.flip(t, k)
+			v0, v1, v2 := v[k], v[(k+1)%3], v[(k+2)%3]
+			edges = append(edges, [2]int{v1, o}, [2]int{o, v2}, [2]int{v2, v0}, [2]int{v0, v1})
+			changed = append(changed, t, n)
+		}
+	}
+	return changed
+}
+
+// split adds point i, which must be on the edge of triangle t opposite vertex
+// k apart from rounding, splitting the edge and the two triangles sharing it,
+// then returns the triangles changed and true. If rounding would leave a
+// triangle inverted nothing is changed and false is returned.
+func (x *IncrementalDelaunay2D) split(i, t, k int) ([]int, bool) {
+	n := x.adj[t][k]
+	a, b, c := x.tri[t][(k+1)%3], x.tri[t][(k+2)%3], x.tri[t][k]
+	m := x.opposite(n, b, a)
+	d := x.tri[n][m]
+	for _, v := range [][3]int{{c, a, i}, {c, i, b}, {d, b, i}, {d, i, a}} {
+		if v[0] != ghost && v[1] != ghost && Orient2D(&x.Points[v[0]], &x.Points[v[1]], &x.Points[v[2]]) <= 0 {
+			return nil, false
+		}
+	}
+
+	// t becomes (c, a, i), n becomes (d, b, i), and the new triangles t2 and
+	// n2 are (c, i, b) and (d, i, a)
+	f, ft, fn := x.fixed[t][k], x.fixed[t], x.fixed[n]
+	tca, tbc := x.adj[t][(k+2)%3], x.adj[t][(k+1)%3]
+	ndb, nad := x.adj[n][(m+2)%3], x.adj[n][(m+1)%3]
+	t2, n2 := len(x.tri), len(x.tri)+1
+	x.tri = append(x.tri, [3]int{c, i, b}, [3]int{d, i, a})
+	x.adj = append(x.adj, [3]int{n, tbc, t}, [3]int{t, nad, n})
+	x.fixed = append(x.fixed, [3]bool{f, ft[(k+1)%3], false}, [3]bool{f, fn[(m+1)%3], false})
+	x.hole = append(x.hole, x.hole[t], x.hole[n])
+	x.dead = append(x.dead, false, false)
+	x.tri[t] = [3]int{c, a, i}
+	x.adj[t] = [3]int{n2, t2, tca}
+	x.fixed[t] = [3]bool{f, false, ft[(k+2)%3]}
+	x.tri[n] = [3]int{d, b, i}
+	x.adj[n] = [3]int{t2, n2, ndb}
+	x.fixed[n] = [3]bool{f, false, fn[(m+2)%3]}
+	x.adj[tbc][x.opposite(tbc, c, b)] = t2
+	x.adj[nad][x.opposite(nad, d, a)] = n2
+	x.index = nil
+	changed := []int{t, t2, n, n2}
+	for _, s := range changed {
+		if !x.isGhost(s) {
+			x.last = s
+		}
+	}
+	var edges [][2]int
+	if c != ghost {
+		edges = append(edges, [2]int{c, a}, [2]int{b, c})
+	}
+	if d != ghost {
+		edges = append(edges, [2]int{d, b}, [2]int{a, d})
+	}
+	return append(changed, x.legalize(edges)...), true
+}
+
+// flip replaces the edge of triangle t opposite vertex k, which must be the
+// diagonal of a convex quadrilateral, with the other diagonal.
+func (x *IncrementalDelaunay2D) flip(t, k int) {
+	v0, v1, v2 := x.tri[t][k], x.tri[t][(k+1)%3], x.tri[t][(k+2)%3]
+	n := x.adj[t][k]
+	m := x.opposite(n, v2, v1)
+	o := x.tri[n][m]
+	// t becomes (v0, v1, o) and n becomes (v0, o, v2)
+	na, fa := x.adj[n][(m+1)%3], x.fixed[n][(m+1)%3]
+	nb, fb := x.adj[n][(m+2)%3], x.fixed[n][(m+2)%3]
+	ta, tfa := x.adj[t][(k+2)%3], x.fixed[t][(k+2)%3]
+	tb, tfb := x.adj[t][(k+1)%3], x.fixed[t][(k+1)%3]
+	x.tri[t] = [3]int{v0, v1, o}
+	x.adj[t] = [3]int{na, n, ta}
+	x.fixed[t] = [3]bool{fa, false, tfa}
+	x.tri[n] = [3]int{v0, o, v2}
+	x.adj[n] = [3]int{nb, tb, t}
+	x.fixed[n] = [3]bool{fb, tfb, false}
+	x.adj[na][x.opposite(na, o, v1)] = t
+	x.adj[tb][x.opposite(tb, v0, v2)] = n
+	x.last = t
+	x.index = nil
+}
+
+// indexOf returns the index of vertex a in triangle t.
+func (x *IncrementalDelaunay2D) indexOf(t, a int) int {
+	for k, v := range x.tri[t] {
+		if v == a {
+			return k
+		}
+	}
+	panic("geometry: vertex not in triangle")
+}
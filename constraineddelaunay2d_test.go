@@ -0,0 +1,305 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// polygonSegments returns the segments joining the points in a closed loop.
+func polygonSegments(points ...Vector2D) []Line2D {
+	z := make([]Line2D, len(points))
+	for i := range points {
+		j := (i + 1) % len(points)
+		z[i] = Line2D{points[i], Vector2D{points[j].X - points[i].X, points[j].Y - points[i].Y}}
+	}
+	return z
+}
+
+// onLineSegment2D returns true if p is on segment s, allowing for the
+// rounding of points added on it, or false otherwise.
+func onLineSegment2D(s *Line2D, p *Vector2D) bool {
+	return Distance2DLineSegmentPoint(s, p) < 1e-12
+}
+
+// checkConstrainedDelaunay2D returns a description of the first way x fails
+// to be a constrained Delaunay triangulation of segments with the given area
+// and holes, or "" if it doesn't.
+func checkConstrainedDelaunay2D(x *ConstrainedDelaunay2D, segments []Line2D, holes []Vector2D, area float64) string {
+	triangles, adjacency := x.Triangles()
+	points := x.Points
+	sum := 0.0
+	for i, v := range triangles {
+		a, b, c := &points[v[0]], &points[v[1]], &points[v[2]]
+		if Orient2D(a, b, c) <= 0 {
+			return "triangle not counterclockwise"
+		}
+		sum += (&Triangle2D{*a, *b, *c}).Area()
+		for j := range v {
+			p, q := &points[v[(j+1)%3]], &points[v[(j+2)%3]]
+			constraint := false
+			for k := range segments {
+				s := &segments[k]
+				var e Vector2D
+				e.Add(&s.P, &s.V)
+				if onLineSegment2D(s, p) && onLineSegment2D(s, q) {
+					constraint = true
+					continue
+				}
+				if onLineSegment2D(s, p) || onLineSegment2D(s, q) {
+					continue
+				}
+				// no edge crosses a segment
+				if Orient2D(p, q, &s.P)*Orient2D(p, q, &e) < 0 && Orient2D(&s.P, &e, p)*Orient2D(&s.P, &e, q) < 0 {
+					return "edge crosses segment"
+				}
+			}
+			n := adjacency[i][j]
+			if n < 0 {
+				continue
+			}
+			m := -1
+			for k, w := range triangles[n] {
+				if w == v[(j+2)%3] && triangles[n][(k+1)%3] == v[(j+1)%3] {
+					m = (k + 2) % 3
+				}
+			}
+			if m < 0 || adjacency[n][m] != i {
+				return "adjacency not symmetric"
+			}
+			if !constraint && InCircle(a, b, c, &points[triangles[n][m]]) > 0 {
+				return "not constrained Delaunay"
+			}
+		}
+	}
+	for i := range holes {
+		if x.Locate(&holes[i]) >= 0 {
+			return "triangle in hole"
+		}
+	}
+	if !FuzzyEqual(sum/area, 1) {
+		return "wrong area"
+	}
+	return ""
+}
+
+type constrainedDelaunay2DData struct {
+	points   []Vector2D
+	segments []Line2D
+	holes    []Vector2D
+	area     float64
+}
+
+var constrainedDelaunay2DValues = []constrainedDelaunay2DData{
+	// square with a square hole
+	{nil,
+		append(polygonSegments(Vector2D{0, 0}, Vector2D{4, 0}, Vector2D{4, 4}, Vector2D{0, 4}),
+			polygonSegments(Vector2D{1, 1}, Vector2D{3, 1}, Vector2D{3, 3}, Vector2D{1, 3})...),
+		[]Vector2D{{2, 2}}, 12},
+	// segments crossing a grid and each other, one through a grid point
+	{[]Vector2D{{0, 0}, {1, 0}, {2, 0}, {3, 0}, {0, 1}, {1, 1}, {2, 1}, {3, 1}, {0, 2}, {1, 2}, {2, 2}, {3, 2}},
+		[]Line2D{{Vector2D{0, 0.1}, Vector2D{2, 0.9}}, {Vector2D{0, 2}, Vector2D{2, -2}}},
+		nil, 6},
+	// crossing segments
+	{[]Vector2D{{0, 0}, {2, 0}, {2, 2}, {0, 2}},
+		[]Line2D{{Vector2D{0, 0}, Vector2D{2, 2}}, {Vector2D{0, 2}, Vector2D{2, -2}}},
+		nil, 4},
+	// an L shape trimmed from its hull by a hole outside it
+	{[]Vector2D{{1, 1}, {3, 3}, {0.5, 3}, {2.5, 0.5}},
+		polygonSegments(Vector2D{0, 0}, Vector2D{3, 0}, Vector2D{3, 1}, Vector2D{1, 1}, Vector2D{1, 4}, Vector2D{0, 4}),
+		[]Vector2D{{-1, -1}}, 6},
+	// interior segments only, hull kept
+	{[]Vector2D{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {1, 2}, {3, 2}},
+		[]Line2D{{Vector2D{0.5, 0.5}, Vector2D{3, 3}}},
+		nil, 16},
+}
+
+func TestConstrainedDelaunay2D(t *testing.T) {
+	for i, v := range constrainedDelaunay2DValues {
+		x := NewConstrainedDelaunay2D(v.points, v.segments, v.holes)
+		if err := checkConstrainedDelaunay2D(x, v.segments, v.holes, v.area); err != "" {
+			t.Error("ConstrainedDelaunay2D", i, err)
+		}
+	}
+	// the crossing segments meet at a new point
+	x := NewConstrainedDelaunay2D(constrainedDelaunay2DValues[2].points, constrainedDelaunay2DValues[2].segments, nil)
+	if len(x.Points) != 5 || x.Points[4] != (Vector2D{1, 1}) {
+		t.Error("ConstrainedDelaunay2D crossing got", x.Points)
+	}
+	if x := NewConstrainedDelaunay2D([]Vector2D{{0, 0}}, []Line2D{{Vector2D{1, 1}, Vector2D{1, 1}}}, nil); len(x.Points) != 3 {
+		t.Error("ConstrainedDelaunay2D collinear got", x.Points)
+	}
+}
+
+func TestConstrainedDelaunay2DRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		points := make([]Vector2D, 40)
+		for j := range points {
+			points[j] = Vector2D{float64(r.Intn(16)) / 4, float64(r.Intn(16)) / 4}
+		}
+		segments := polygonSegments(Vector2D{0, 0}, Vector2D{4, 0}, Vector2D{4, 4}, Vector2D{0, 4})
+		// segments that cross are covered above, and may be bent by rounding
+	random:
+		for len(segments) < 8 {
+			p, q := points[r.Intn(len(points))], points[r.Intn(len(points))]
+			for k := range segments {
+				var e Vector2D
+				s := &segments[k]
+				e.Add(&s.P, &s.V)
+				if Orient2D(&p, &q, &s.P)*Orient2D(&p, &q, &e) < 0 && Orient2D(&s.P, &e, &p)*Orient2D(&s.P, &e, &q) < 0 {
+					continue random
+				}
+			}
+			segments = append(segments, Line2D{p, Vector2D{q.X - p.X, q.Y - p.Y}})
+		}
+		x := NewConstrainedDelaunay2D(points, segments, nil)
+		if err := checkConstrainedDelaunay2D(x, segments, nil, 16); err != "" {
+			t.Fatal("ConstrainedDelaunay2D", points, segments, err)
+		}
+	}
+}
+
+func TestConstrainedDelaunay2DRounding(t *testing.T) {
+	// segments whose ends round to an ulp from points, one ending on the
+	// other's start
+	points := []Vector2D{{0.8049295058741669, 0.8159709225166477}, {0.5684681407433906, 0.6748762607640776},
+		{0.11406899684874491, 0.7847971419668659}, {0.9188070704038833, 0.47808763795944037},
+		{0.30644196951984287, 0.07010440771054477}}
+	segments := []Line2D{{points[4], Vector2D{0.498487536354324, 0.745866514806103}},
+		{points[1], Vector2D{-0.2620261712235477, -0.6047718530535329}}}
+	var hull Polygon2D
+	x := NewConstrainedDelaunay2D(points, segments, nil)
+	if len(x.Points) != len(points) {
+		t.Error("ConstrainedDelaunay2D rounded ends got", x.Points)
+	}
+	if err := checkConstrainedDelaunay2D(x, segments, nil, hull.ConvexHull(points).Area()); err != "" {
+		t.Error("ConstrainedDelaunay2D rounded ends", err)
+	}
+
+	// segments ending a few ulps from points, too far to be moved onto them,
+	// so crossings round onto and around vertices
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		points := make([]Vector2D, 3+r.Intn(8))
+		for j := range points {
+			points[j] = Vector2D{r.Float64(), r.Float64()}
+		}
+		var segments []Line2D
+		for j := r.Intn(5); j >= 0; j-- {
+			p, q := points[r.Intn(len(points))], points[r.Intn(len(points))]
+			for k := r.Intn(8); k > 0; k-- {
+				q.X = math.Nextafter(q.X, r.Float64())
+				q.Y = math.Nextafter(q.Y, r.Float64())
+			}
+			segments = append(segments, Line2D{p, Vector2D{q.X - p.X, q.Y - p.Y}})
+		}
+		x := NewConstrainedDelaunay2D(points, segments, nil)
+		triangles, _ := x.Triangles()
+		for _, v := range triangles {
+			if Orient2D(&x.Points[v[0]], &x.Points[v[1]], &x.Points[v[2]]) <= 0 {
+				t.Fatal("ConstrainedDelaunay2D", points, segments, "triangle not counterclockwise")
+			}
+		}
+	}
+}
+
+func TestConstrainedDelaunay2DRefine(t *testing.T) {
+	minAngle := 20 * math.Pi / 180
+	for i, v := range constrainedDelaunay2DValues {
+		x := NewConstrainedDelaunay2D(v.points, v.segments, v.holes)
+		n := len(x.Points)
+		added := x.Refine(minAngle, 0.1)
+		if added != len(x.Points)-n || added == 0 {
+			t.Error("ConstrainedDelaunay2D.Refine", i, "added", added, len(x.Points)-n)
+		}
+		if err := checkConstrainedDelaunay2D(x, v.segments, v.holes, v.area); err != "" {
+			t.Error("ConstrainedDelaunay2D.Refine", i, err)
+		}
+		triangles, _ := x.Triangles()
+		var q MeshQuality2D
+		q.FromTriangles(x.Points, triangles)
+		if q.MinAngle < minAngle || q.MaxArea > 0.1 {
+			t.Error("ConstrainedDelaunay2D.Refine", i, "got", q)
+		}
+	}
+}
+
+func TestConstrainedDelaunay2DRefineSmallAngle(t *testing.T) {
+	// segments meeting at about 1.4 degrees cannot be refined fully, but
+	// refinement still finishes with a valid mesh
+	points := []Vector2D{{0, 0}, {3, 0}, {3, 2}, {0, 2}}
+	segments := []Line2D{{Vector2D{0, 0.1}, Vector2D{2, 0.9}}, {Vector2D{0, 0}, Vector2D{2, 1}}}
+	x := NewConstrainedDelaunay2D(points, segments, nil)
+	x.Refine(20*math.Pi/180, 0)
+	if err := checkConstrainedDelaunay2D(x, segments, nil, 6); err != "" {
+		t.Error("ConstrainedDelaunay2D.Refine small angle", err)
+	}
+}
+
+func TestConstrainedDelaunay2DRefineSlanted(t *testing.T) {
+	// points splitting a slanted edge are off it by rounding, which must not
+	// leave flat triangles or refine them without end
+	var grid []Vector2D
+	for i := 0; i < 18; i++ {
+		grid = append(grid, Vector2D{float64(i % 6), float64(i / 6)})
+	}
+	var gridSegments []Line2D
+	for i := 0; i < 9; i++ {
+		p, q := grid[i], grid[(5*i+7)%18]
+		gridSegments = append(gridSegments, Line2D{p, Vector2D{q.X - p.X, q.Y - p.Y}})
+	}
+	minAngle := 20 * math.Pi / 180
+	for i, v := range []struct {
+		points   []Vector2D
+		segments []Line2D
+	}{
+		{[]Vector2D{{0, 0}, {3, 1}, {0, 3}}, nil},
+		{[]Vector2D{{0, 2}, {2, 1}, {4, 0}, {4, 4}}, nil},
+		{grid, gridSegments},
+	} {
+		x := NewConstrainedDelaunay2D(v.points, v.segments, nil)
+		if added := x.Refine(0, 0); added != 0 {
+			t.Error("ConstrainedDelaunay2D.Refine", i, "without limits added", added)
+		}
+		if added := x.Refine(minAngle, 0.05); added > 1000 {
+			t.Error("ConstrainedDelaunay2D.Refine", i, "added", added)
+		}
+		triangles, _ := x.Triangles()
+		var q MeshQuality2D
+		q.FromTriangles(x.Points, triangles)
+		if q.MinAngle <= 0 || q.MinArea <= 0 || q.MaxArea > 0.05 {
+			t.Error("ConstrainedDelaunay2D.Refine", i, "got", q)
+		}
+	}
+}
+
+func TestConstrainedDelaunay2DRefineRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		points := make([]Vector2D, 10)
+		for j := range points {
+			points[j] = Vector2D{r.Float64(), r.Float64()}
+		}
+		// segments between the points, which may cross or meet at any angle
+		var segments []Line2D
+		for j := r.Intn(5) - 1; j >= 0; j-- {
+			p, q := points[r.Intn(len(points))], points[r.Intn(len(points))]
+			segments = append(segments, Line2D{p, Vector2D{q.X - p.X, q.Y - p.Y}})
+		}
+		var hull Polygon2D
+		x := NewConstrainedDelaunay2D(points, segments, nil)
+		x.Refine(0.3, 0)
+		if err := checkConstrainedDelaunay2D(x, segments, nil, hull.ConvexHull(points).Area()); err != "" {
+			t.Fatal("ConstrainedDelaunay2D.Refine", points, segments, err)
+		}
+	}
+}
+
+func Benchmark_ConstrainedDelaunay2DRefine(b *testing.B) {
+	segments := append(polygonSegments(Vector2D{0, 0}, Vector2D{4, 0}, Vector2D{4, 4}, Vector2D{0, 4}),
+		polygonSegments(Vector2D{1, 1}, Vector2D{3, 1}, Vector2D{3, 3}, Vector2D{1, 3})...)
+	for i := 0; i < b.N; i++ {
+		NewConstrainedDelaunay2D(nil, segments, []Vector2D{{2, 2}}).Refine(20*math.Pi/180, 0.01)
+	}
+}
@@ -0,0 +1,177 @@
+package geometry
+
+import (
+	"math"
+	"sort"
+)
+
+// ConvexHull2D returns the indices of the points on the convex hull of points
+// in counterclockwise order, starting from the point with the lowest X (then
+// lowest Y). Points that lie on a hull edge are only included if collinear is
+// true. Of duplicate points only the one with the lowest index is used.
+//
+// If all points are collinear the two extreme points are returned, or all of
+// the points in order from one extreme to the other if collinear is true. A
+// single distinct point returns one index and no points returns nil.
+func ConvexHull2D(points []Vector2D, collinear bool) []int {
+	idx := make([]int, len(points))
+	for i := range idx {
+		idx[i] = i
+	}
+	return convexHull2D(points, idx, collinear)
+}
+
+// ConvexHull sets z to the convex hull of points, as returned by ConvexHull2D
+// without collinear points, then returns z.
+func (z *Polygon2D) ConvexHull(points []Vector2D) *Polygon2D {
+	z.Outer = z.Outer[:0]
+	for _, i := range ConvexHull2D(points, false) {
+		z.Outer = append(z.Outer, points[i])
+	}
+	z.Holes = nil
+	return z
+}
+
+// An IncrementalConvexHull2D maintains the convex hull of a set of points as
+// points are added one at a time. The zero value is an empty hull that
+// excludes collinear points.
+type IncrementalConvexHull2D struct {
+	Points    []Vector2D // every point added so far
+	Collinear bool       // keep points that lie on a hull edge
+	hull      []int
+}
+
+// Add adds point p to the set then returns true if the hull changed or false
+// otherwise.
+func (x *IncrementalConvexHull2D) Add(p Vector2D) bool {
+	x.Points = append(x.Points, p)
+	if x.strictlyInside(&p) {
+		return false
+	}
+	// hull(S + p) is hull(hull(S) + p), so only the current hull is rebuilt
+	n := len(x.hull)
+	idx := append(append(make([]int, 0, n+1), x.hull...), len(x.Points)-1)
+	x.hull = convexHull2D(x.Points, idx, x.Collinear)
+	if len(x.hull) != n {
+		return true
+	}
+	for _, i := range x.hull {
+		if i == len(x.Points)-1 {
+			return true
+		}
+	}
+	return false
+}
+
+// Indices returns the indices into Points of the current hull in the same
+// order ConvexHull2D would return them. The slice must not be modified.
+func (x *IncrementalConvexHull2D) Indices() []int {
+	return x.hull
+}
+
+// strictlyInside returns true if p is strictly inside the current hull or
+// false otherwise.
+func (x *IncrementalConvexHull2D) strictlyInside(p *Vector2D) bool {
+	n := len(x.hull)
+	if n < 3 {
+		return false
+	}
+	for i := range x.hull {
+		if Orient2D(&x.Points[x.hull[i]], &x.Points[x.hull[(i+1)%n]], p) <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// supportPoints2D returns the point in points furthest in direction d.
+func supportPoints2D(points []Vector2D, d *Vector2D) Vector2D {
+	best, bd := 0, math.Inf(-1)
+	for i := range points {
+		if v := d.DotProduct(&points[i]); v > bd {
+			best, bd = i, v
+		}
+	}
+	return points[best]
+}
+
+// convexHull2D returns the convex hull of the points at the given indices
+// using Andrew's monotone chain algorithm. The indices are reordered.
+func convexHull2D(points []Vector2D, idx []int, collinear bool) []int {
+	sort.Slice(idx, func(i, j int) bool {
+		a, b := &points[idx[i]], &points[idx[j]]
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		return idx[i] < idx[j]
+	})
+	// drop duplicates, keeping the lowest index which sorts first
+	n := 0
+	for i, v := range idx {
+		if i > 0 && points[v] == points[idx[n-1]] {
+			continue
+		}
+		idx[n] = v
+		n++
+	}
+	idx = idx[:n]
+	if n < 3 {
+		if n == 0 {
+			return nil
+		}
+		return append([]int(nil), idx...)
+	}
+
+	first, last := &points[idx[0]], &points[idx[n-1]]
+	degenerate := true
+	for _, v := range idx[1 : n-1] {
+		if Orient2D(first, last, &points[v]) != 0 {
+			degenerate = false
+			break
+		}
+	}
+	if degenerate {
+		if collinear {
+			return append([]int(nil), idx...)
+		}
+		return []int{idx[0], idx[n-1]}
+	}
+
+	// a point is removed from the chain if it makes a clockwise turn, or no
+	// turn unless collinear points are kept
+	limit := 0
+	if collinear {
+		limit = -1
+	}
+	hull := make([]int, 0, n+1)
+	for _, v := range idx {
+		for len(hull) >= 2 && Orient2D(&points[hull[len(hull)-2]], &points[hull[len(hull)-1]], &points[v]) <= limit {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, v)
+	}
+	lower := len(hull) + 1
+	for i := n - 2; i >= 0; i-- {
+		v := idx[i]
+		for len(hull) >= lower && Orient2D(&points[hull[len(hull)-2]], &points[hull[len(hull)-1]], &points[v]) <= limit {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, v)
+	}
+	// the last point is the first point again
+	return hull[:len(hull)-1]
+}
+
+// sign returns 1 if x is positive, -1 if it is negative, or 0 otherwise.
+func sign(x float64) int {
+	if x > 0 {
+		return 1
+	}
+	if x < 0 {
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package geometry
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+type convexHull2DData struct {
+	points    []Vector2D
+	collinear bool
+	hull      []int
+}
+
+var convexHull2DValues = []convexHull2DData{
+	{nil, false, nil},
+	{[]Vector2D{{1, 1}}, false, []int{0}},
+	{[]Vector2D{{1, 1}, {1, 1}}, false, []int{0}},
+	{[]Vector2D{{2, 2}, {1, 1}}, false, []int{1, 0}},
+	// square with an interior point, duplicates, and edge midpoints
+	{[]Vector2D{{1, 1}, {0, 0}, {2, 0}, {2, 2}, {0, 2}, {1, 0}, {2, 2}, {0, 1}}, false, []int{1, 2, 3, 4}},
+	{[]Vector2D{{1, 1}, {0, 0}, {2, 0}, {2, 2}, {0, 2}, {1, 0}, {2, 2}, {0, 1}}, true, []int{1, 5, 2, 3, 4, 7}},
+	// collinear
+	{[]Vector2D{{1, 1}, {3, 3}, {0, 0}, {2, 2}, {3, 3}}, false, []int{2, 1}},
+	{[]Vector2D{{1, 1}, {3, 3}, {0, 0}, {2, 2}, {3, 3}}, true, []int{2, 0, 3, 1}},
+	{[]Vector2D{{0, 2}, {0, 1}, {0, 3}}, false, []int{1, 2}},
+	// nearly collinear points that naive arithmetic gets wrong
+	{[]Vector2D{{0.5, 0.5}, {12, 12}, {24, 24}, {0.50000000000000011, 0.5}}, false, []int{0, 3, 2}},
+}
+
+func TestConvexHull2D(t *testing.T) {
+	for _, v := range convexHull2DValues {
+		if got := ConvexHull2D(v.points, v.collinear); !reflect.DeepEqual(got, v.hull) {
+			t.Error("ConvexHull2D", v.points, v.collinear, "want", v.hull, "got", got)
+		}
+	}
+}
+
+func Benchmark_ConvexHull2D(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	points := make([]Vector2D, 1000)
+	for i := range points {
+		points[i] = Vector2D{r.Float64(), r.Float64()}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ConvexHull2D(points, false)
+	}
+}
+
+func TestPolygon2DConvexHull(t *testing.T) {
+	var p Polygon2D
+	points := []Vector2D{{1, 1}, {0, 0}, {2, 0}, {2, 2}, {0, 2}}
+	want := &Polygon2D{Outer: []Vector2D{{0, 0}, {2, 0}, {2, 2}, {0, 2}}}
+	if !p.ConvexHull(points).Equal(want) {
+		t.Error("Polygon2D.ConvexHull", "want", want, "got", p)
+	}
+	if !p.IsConvex() || p.Orientation() != 1 {
+		t.Error("Polygon2D.ConvexHull not convex and counterclockwise", p)
+	}
+}
+
+func TestIncrementalConvexHull2D(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, collinear := range []bool{false, true} {
+		h := IncrementalConvexHull2D{Collinear: collinear}
+		for i := 0; i < 200; i++ {
+			// a coarse grid to get plenty of duplicate and collinear points
+			p := Vector2D{float64(r.Intn(10)), float64(r.Intn(10))}
+			changed := h.Add(p)
+			want := ConvexHull2D(h.Points, collinear)
+			if !reflect.DeepEqual(h.Indices(), want) {
+				t.Fatal("IncrementalConvexHull2D", i, p, "want", want, "got", h.Indices())
+			}
+			if changed != (len(want) > 0 && intsContain(want, i)) {
+				t.Fatal("IncrementalConvexHull2D.Add", i, p, "changed", changed)
+			}
+		}
+	}
+}
+
+func intsContain(s []int, v int) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
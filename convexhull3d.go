@@ -0,0 +1,293 @@
+package geometry
+
+import (
+	"math"
+)
+
+// A Hull3D is a closed convex triangle mesh. Each face is a triple of indices
+// into Points ordered counterclockwise when seen from outside, so the right
+// hand rule gives an outward normal.
+type Hull3D struct {
+	Points []Vector3D
+	Faces  [][3]int
+}
+
+// ConvexHull3D sets z to the convex hull of points, using the quickhull
+// algorithm, then returns the dimension of the space the points span. z.Points
+// is set to points, which is not copied. Points within a small tolerance,
+// relative to the extent of the input, of a hull face are treated as inside.
+//
+// Possible return values are:
+// 0 if there are no points or they are all the same, z.Faces is set to nil.
+// 1 if the points are collinear, z.Faces is set to nil.
+// 2 if the points are coplanar, z.Faces is set to nil.
+// 3 otherwise, z.Faces is set to the hull faces.
+func ConvexHull3D(points []Vector3D, z *Hull3D) int {
+	z.Points = points
+	z.Faces = nil
+	if len(points) == 0 {
+		return 0
+	}
+
+	// tolerance as used by qhull and others
+	var mx, my, mz float64
+	for i := range points {
+		mx = math.Max(mx, math.Abs(points[i].X))
+		my = math.Max(my, math.Abs(points[i].Y))
+		mz = math.Max(mz, math.Abs(points[i].Z))
+	}
+	eps := 3 * 2.220446049250313e-16 * (mx + my + mz)
+
+	// initial simplex from the extreme points
+	var min, max [3]int
+	for i := range points {
+		p := &points[i]
+		for axis, v := range [3]float64{p.X, p.Y, p.Z} {
+			if v < vector3DAxis(&points[min[axis]], axis) {
+				min[axis] = i
+			}
+			if v > vector3DAxis(&points[max[axis]], axis) {
+				max[axis] = i
+			}
+		}
+	}
+	v0, v1, best := 0, 0, 0.0
+	for axis := 0; axis < 3; axis++ {
+		if d := Distance3DPointPointSquared(&points[min[axis]], &points[max[axis]]); d > best {
+			v0, v1, best = min[axis], max[axis], d
+		}
+	}
+	if math.Sqrt(best) <= eps {
+		return 0
+	}
+	line := Line3D{points[v0], Vector3D{}}
+	line.V.Subtract(&points[v1], &points[v0])
+	v2, best := 0, 0.0
+	for i := range points {
+		if d := Distance3DLinePointSquared(&line, &points[i]); d > best {
+			v2, best = i, d
+		}
+	}
+	if math.Sqrt(best) <= eps {
+		return 1
+	}
+	var plane Plane
+	plane.FromPoints(&points[v0], &points[v1], &points[v2]).Normalize(&plane)
+	v3, best := 0, 0.0
+	for i := range points {
+		if d := math.Abs(Distance3DPlaneNormalizedPoint(&plane, &points[i])); d > best {
+			v3, best = i, d
+		}
+	}
+	if best <= eps {
+		return 2
+	}
+
+	h := quickHull3D{points: points, eps: eps, edges: make(map[[2]int]int)}
+	if Distance3DPlaneNormalizedPoint(&plane, &points[v3]) > 0 {
+		v1, v2 = v2, v1
+	}
+	for _, f := range [4][3]int{{v0, v1, v2}, {v0, v3, v1}, {v1, v3, v2}, {v2, v3, v0}} {
+		h.addFace(f)
+	}
+	for i := range points {
+		if i != v0 && i != v1 && i != v2 && i != v3 {
+			h.assign(i, h.faces[:4])
+		}
+	}
+
+	for {
+		// pick any face that still has points outside it
+		fi := -1
+		for i := range h.faces {
+			if h.faces[i].alive && len(h.faces[i].outside) > 0 {
+				fi = i
+				break
+			}
+		}
+		if fi < 0 {
+			break
+		}
+		h.addPoint(fi)
+	}
+
+	for i := range h.faces {
+		if h.faces[i].alive {
+			z.Faces = append(z.Faces, h.faces[i].v)
+		}
+	}
+	return 3
+}
+
+// Normal sets z to the outward unit normal of face i of x then returns z.
+func (x *Hull3D) Normal(i int, z *Vector3D) *Vector3D {
+	f := &x.Faces[i]
+	var u, v Vector3D
+	u.Subtract(&x.Points[f[1]], &x.Points[f[0]])
+	v.Subtract(&x.Points[f[2]], &x.Points[f[0]])
+	return z.CrossProduct(&u, &v).Normalize()
+}
+
+// Support returns the point of x furthest in direction d.
+func (x *Hull3D) Support(d Vector3D) Vector3D {
+	return supportPoints3D(x.Points, &d)
+}
+
+// SurfaceArea returns the total area of the faces of x.
+func (x *Hull3D) SurfaceArea() float64 {
+	var u, v, n Vector3D
+	a := 0.0
+	for _, f := range x.Faces {
+		u.Subtract(&x.Points[f[1]], &x.Points[f[0]])
+		v.Subtract(&x.Points[f[2]], &x.Points[f[0]])
+		a += n.CrossProduct(&u, &v).Magnitude()
+	}
+	return a / 2
+}
+
+// Volume returns the volume enclosed by x.
+func (x *Hull3D) Volume() float64 {
+	if len(x.Faces) == 0 {
+		return 0
+	}
+	// sum of the signed volumes of the tetrahedrons formed by each face and a
+	// point on the hull
+	o := &x.Points[x.Faces[0][0]]
+	var a, b, c, n Vector3D
+	v := 0.0
+	for _, f := range x.Faces {
+		a.Subtract(&x.Points[f[0]], o)
+		b.Subtract(&x.Points[f[1]], o)
+		c.Subtract(&x.Points[f[2]], o)
+		v += a.DotProduct(n.CrossProduct(&b, &c))
+	}
+	return v / 6
+}
+
+// quickHull3D is the working state of ConvexHull3D.
+type quickHull3D struct {
+	points []Vector3D
+	eps    float64
+	faces  []quickHullFace
+	edges  map[[2]int]int // directed edge to the face it belongs to
+}
+
+type quickHullFace struct {
+	v       [3]int
+	plane   Plane // normalized with the normal pointing out
+	outside []int // points above the face that no earlier face claimed
+	alive   bool
+}
+
+// addFace adds a face with the given vertices counterclockwise from outside.
+func (h *quickHull3D) addFace(v [3]int) int {
+	f := quickHullFace{v: v, alive: true}
+	f.plane.FromPoints(&h.points[v[0]], &h.points[v[1]], &h.points[v[2]]).Normalize(&f.plane)
+	h.faces = append(h.faces, f)
+	i := len(h.faces) - 1
+	for j := 0; j < 3; j++ {
+		h.edges[[2]int{v[j], v[(j+1)%3]}] = i
+	}
+	return i
+}
+
+// addPoint adds the farthest point outside face fi to the hull.
+func (h *quickHull3D) addPoint(fi int) {
+	f := &h.faces[fi]
+	eye, best := -1, 0.0
+	for _, i := range f.outside {
+		if d := Distance3DPlaneNormalizedPoint(&f.plane, &h.points[i]); d > best || eye < 0 {
+			eye, best = i, d
+		}
+	}
+	p := &h.points[eye]
+
+	// find the faces the eye point can see, which are connected
+	visible := []int{fi}
+	seen := map[int]bool{fi: true}
+	for k := 0; k < len(visible); k++ {
+		v := h.faces[visible[k]].v
+		for j := 0; j < 3; j++ {
+			n := h.edges[[2]int{v[(j+1)%3], v[j]}]
+			if !seen[n] && h.above(&h.faces[n], p) {
+				seen[n] = true
+				visible = append(visible, n)
+			}
+		}
+	}
+
+	// the horizon edges are those of visible faces whose twin is not visible,
+	// each forms a new face with the eye point
+	var orphans []int
+	for _, vi := range visible {
+		h.faces[vi].alive = false
+		for _, i := range h.faces[vi].outside {
+			if i != eye {
+				orphans = append(orphans, i)
+			}
+		}
+		h.faces[vi].outside = nil
+	}
+	first := len(h.faces)
+	for _, vi := range visible {
+		v := h.faces[vi].v
+		for j := 0; j < 3; j++ {
+			a, b := v[j], v[(j+1)%3]
+			if !seen[h.edges[[2]int{b, a}]] {
+				h.addFace([3]int{a, b, eye})
+			}
+		}
+	}
+	for _, vi := range visible {
+		v := h.faces[vi].v
+		for j := 0; j < 3; j++ {
+			e := [2]int{v[j], v[(j+1)%3]}
+			if h.edges[e] == vi {
+				delete(h.edges, e)
+			}
+		}
+	}
+	for _, i := range orphans {
+		h.assign(i, h.faces[first:])
+	}
+}
+
+// above returns true if p is strictly above face f or false otherwise. The
+// plane of f is rounded, which could put a point coplanar with it above it
+// and, once added, fold the new faces over their coplanar neighbors, so this
+// is found exactly.
+func (h *quickHull3D) above(f *quickHullFace, p *Vector3D) bool {
+	return Orient3D(&h.points[f.v[0]], &h.points[f.v[1]], &h.points[f.v[2]], p) < 0
+}
+
+// assign adds point i to the outside set of the first face it is above.
+func (h *quickHull3D) assign(i int, faces []quickHullFace) {
+	for j := range faces {
+		if p := &h.points[i]; Distance3DPlaneNormalizedPoint(&faces[j].plane, p) > h.eps && h.above(&faces[j], p) {
+			faces[j].outside = append(faces[j].outside, i)
+			return
+		}
+	}
+}
+
+// supportPoints3D returns the point in points furthest in direction d.
+func supportPoints3D(points []Vector3D, d *Vector3D) Vector3D {
+	best, bd := 0, math.Inf(-1)
+	for i := range points {
+		if v := d.DotProduct(&points[i]); v > bd {
+			best, bd = i, v
+		}
+	}
+	return points[best]
+}
+
+// vector3DAxis returns the component of v along axis 0, 1, or 2.
+func vector3DAxis(v *Vector3D, axis int) float64 {
+	switch axis {
+	case 0:
+		return v.X
+	case 1:
+		return v.Y
+	}
+	return v.Z
+}
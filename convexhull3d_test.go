@@ -0,0 +1,124 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func testHull3DConvex(h *Hull3D, t *testing.T) {
+	var n Vector3D
+	edges := make(map[[2]int]bool)
+	for i, f := range h.Faces {
+		h.Normal(i, &n)
+		d := n.DotProduct(&h.Points[f[0]])
+		for j := range h.Points {
+			if n.DotProduct(&h.Points[j])-d > 1e-9 {
+				t.Fatal("ConvexHull3D point", h.Points[j], "outside face", f)
+			}
+		}
+		for k := 0; k < 3; k++ {
+			edges[[2]int{f[k], f[(k+1)%3]}] = true
+		}
+	}
+	// closed and consistently oriented, every edge has its twin
+	for e := range edges {
+		if !edges[[2]int{e[1], e[0]}] {
+			t.Fatal("ConvexHull3D edge", e, "has no twin")
+		}
+	}
+}
+
+func TestConvexHull3DCube(t *testing.T) {
+	points := []Vector3D{{0.5, 0.5, 0.5}}
+	for i := 0; i < 8; i++ {
+		points = append(points, Vector3D{float64(i & 1), float64(i >> 1 & 1), float64(i >> 2 & 1)})
+	}
+	points = append(points, Vector3D{0.5, 0, 0.5}, Vector3D{0.2, 0.7, 0.1})
+	var h Hull3D
+	if n := ConvexHull3D(points, &h); n != 3 {
+		t.Fatal("ConvexHull3D", "want", 3, "got", n)
+	}
+	if len(h.Faces) != 12 {
+		t.Error("ConvexHull3D", "want", 12, "faces got", len(h.Faces))
+	}
+	for _, f := range h.Faces {
+		for _, v := range f {
+			if v == 0 || v > 8 {
+				t.Error("ConvexHull3D non-corner vertex", points[v])
+			}
+		}
+	}
+	if v := h.Volume(); !FuzzyEqual(v, 1) {
+		t.Error("Hull3D.Volume", "want", 1, "got", v)
+	}
+	if a := h.SurfaceArea(); !FuzzyEqual(a, 6) {
+		t.Error("Hull3D.SurfaceArea", "want", 6, "got", a)
+	}
+	testHull3DConvex(&h, t)
+}
+
+func TestConvexHull3DSphere(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	points := make([]Vector3D, 2000)
+	for i := range points {
+		points[i] = Vector3D{r.NormFloat64(), r.NormFloat64(), r.NormFloat64()}
+		points[i].Normalize()
+		points[i].Scale(&points[i], math.Cbrt(r.Float64()))
+	}
+	var h Hull3D
+	if n := ConvexHull3D(points, &h); n != 3 {
+		t.Fatal("ConvexHull3D", "want", 3, "got", n)
+	}
+	testHull3DConvex(&h, t)
+	if v := h.Volume(); v < 3.5 || v > 4*math.Pi/3 {
+		t.Error("Hull3D.Volume", "got", v)
+	}
+}
+
+func TestConvexHull3DCoplanar(t *testing.T) {
+	// points on a cylinder, many on each of a few planes, some of which are
+	// added to the hull after faces in the same plane
+	r := rand.New(rand.NewSource(1))
+	points := make([]Vector3D, 1000)
+	for i := range points {
+		a := r.Float64() * 2 * math.Pi
+		points[i] = Vector3D{math.Cos(a), math.Sin(a), float64(r.Intn(4))}
+	}
+	var h Hull3D
+	if n := ConvexHull3D(points, &h); n != 3 {
+		t.Fatal("ConvexHull3D", "want", 3, "got", n)
+	}
+	testHull3DConvex(&h, t)
+}
+
+func TestConvexHull3DDegenerate(t *testing.T) {
+	for _, v := range []struct {
+		points []Vector3D
+		n      int
+	}{
+		{nil, 0},
+		{[]Vector3D{{1, 2, 3}, {1, 2, 3}}, 0},
+		{[]Vector3D{{1, 2, 3}, {2, 4, 6}, {3, 6, 9}, {0, 0, 0}}, 1},
+		{[]Vector3D{{0, 0, 1}, {1, 0, 1}, {0, 1, 1}, {1, 1, 1}, {0.5, 0.5, 1}}, 2},
+		{[]Vector3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1e-20}}, 2},
+	} {
+		var h Hull3D
+		if n := ConvexHull3D(v.points, &h); n != v.n || h.Faces != nil {
+			t.Error("ConvexHull3D", v.points, "want", v.n, "got", n, h.Faces)
+		}
+	}
+}
+
+func Benchmark_ConvexHull3D(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	points := make([]Vector3D, 1000)
+	for i := range points {
+		points[i] = Vector3D{r.Float64(), r.Float64(), r.Float64()}
+	}
+	var h Hull3D
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ConvexHull3D(points, &h)
+	}
+}
@@ -0,0 +1,374 @@
+package geometry
+
+import (
+	"sort"
+)
+
+// ghost is the vertex index of the point at infinity. Every convex hull edge
+// has a ghost triangle joining it to the ghost vertex, so every triangle has
+// three neighbors and points outside the hull can be inserted like any other.
+const ghost = -1
+
+// Delaunay2D returns the Delaunay triangulation of points as triangles of
+// point indices in counterclockwise order, and for each triangle the indices of
+// its neighbors. adjacency[i][j] is the triangle sharing the edge of triangle
+// i opposite vertex j, or -1 if that edge is on the convex hull.
+//
+// Of duplicate points only the one with the lowest index is used. Where four
+// or more points are cocircular the triangulation is not unique and the one
+// returned depends only on the distinct points, not on their order. If all
+// points are collinear there are no triangles and nil is returned.
+func Delaunay2D(points []Vector2D) (triangles, adjacency [][3]int) {
+	return NewIncrementalDelaunay2D(points).Triangles()
+}
+
+// An IncrementalDelaunay2D maintains the Delaunay triangulation of a set of
+// points as points are added one at a time. The zero value is an empty
+// triangulation.
+type IncrementalDelaunay2D struct {
+	Points  []Vector2D // every point added so far
+	tri     [][3]int   // vertices, including ghost triangles
+	adj     [][3]int   // adj[t][i] is the triangle opposite tri[t][i]
+	fixed   [][3]bool  // fixed[t][i] is true if that edge is a constraint
+	hole    []bool     // triangles removed from the mesh
+	dead    []bool     // triangles in the current cavity
+	last    int        // a recently created triangle to start walks from
+	pending []int      // distinct points before the first triangle
+	index   []int      // tri index to Triangles index, nil if stale
+}
+
+// NewIncrementalDelaunay2D returns the triangulation of points. The points
+// are inserted in sorted order, which is faster and makes the result
+// independent of their order. The slice is used as Points.
+func NewIncrementalDelaunay2D(points []Vector2D) *IncrementalDelaunay2D {
+	x := &IncrementalDelaunay2D{Points: points}
+	idx := make([]int, len(points))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool {
+		a, b := &points[idx[i]], &points[idx[j]]
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		return idx[i] < idx[j]
+	})
+	for _, i := range idx {
+		x.insert(i)
+	}
+	return x
+}
+
+// Add adds point p to the set then returns true if it was inserted, or false
+// if it duplicates an earlier point and was ignored.
+func (x *IncrementalDelaunay2D) Add(p Vector2D) bool {
+	x.Points = append(x.Points, p)
+	return x.insert(len(x.Points) - 1)
+}
+
+// Circumcircle sets z to the circumcircle of triangle i, as numbered by
+// Triangles, then returns z.
+func (x *IncrementalDelaunay2D) Circumcircle(i int, z *Circle) *Circle {
+	t := &x.tri[x.slot(i)]
+	return z.FromThreePoints(&x.Points[t[0]], &x.Points[t[1]], &x.Points[t[2]])
+}
+
+// Locate returns the index, as numbered by Triangles, of a triangle
+// containing p, or -1 if p is outside the triangulation. A point on an edge or
+// vertex is in every triangle sharing it and any one of them is returned.
+func (x *IncrementalDelaunay2D) Locate(p *Vector2D) int {
+	if len(x.tri) == 0 {
+		return -1
+	}
+	return x.compact()[x.walk(p)]
+}
+
+// Triangles returns the current triangulation as Delaunay2D would. The
+// slices are new on every call.
+func (x *IncrementalDelaunay2D) Triangles() (triangles, adjacency [][3]int) {
+	index := x.compact()
+	for t, v := range x.tri {
+		if index[t] < 0 {
+			continue
+		}
+		var a [3]int
+		for i, n := range x.adj[t] {
+			a[i] = index[n]
+		}
+		triangles = append(triangles, v)
+		adjacency = append(adjacency, a)
+	}
+	return triangles, adjacency
+}
+
+// compact returns the mapping from tri indices to Triangles indices, with -1
+// for ghost triangles and holes.
+func (x *IncrementalDelaunay2D) compact() []int {
+	if x.index != nil {
+		return x.index
+	}
+	x.index = make([]int, len(x.tri))
+	n := 0
+	for t := range x.tri {
+		if x.hole[t] || x.isGhost(t) {
+			x.index[t] = -1
+			continue
+		}
+		x.index[t] = n
+		n++
+	}
+	return x.index
+}
+
+// slot returns the tri index of triangle i as numbered by Triangles.
+func (x *IncrementalDelaunay2D) slot(i int) int {
+	for t, n := range x.compact() {
+		if n == i {
+			return t
+		}
+	}
+	panic("geometry: triangle index out of range")
+}
+
+// isGhost returns true if triangle t has the ghost vertex or false otherwise.
+func (x *IncrementalDelaunay2D) isGhost(t int) bool {
+	v := &x.tri[t]
+	return v[0] == ghost || v[1] == ghost || v[2] == ghost
+}
+
+// insert adds point i to the triangulation then returns true, or false if it
+// duplicates a point already in it.
+func (x *IncrementalDelaunay2D) insert(i int) bool {
+	p := &x.Points[i]
+	if len(x.tri) == 0 {
+		return x.insertPending(i)
+	}
+	t := x.walk(p)
+	if !x.isGhost(t) {
+		for _, v := range x.tri[t] {
+			if x.Points[v] == *p {
+				return false
+			}
+		}
+	}
+	x.commit(i, x.cavity(p, t))
+	return true
+}
+
+// A cavityEdge is an edge on the boundary of a cavity, from a to b
+// counterclockwise around it, with out the triangle outside it.
+type cavityEdge struct {
+	a, b, out int
+	fixed     bool
+	hole      bool
+}
+
+// A cavity2D is the set of triangles replaced when a point is inserted.
+type cavity2D struct {
+	triangles []int
+	boundary  []cavityEdge
+}
+
+// cavity returns the triangles whose circumcircles contain p that can be
+// reached from triangle t without crossing a constraint, marking them dead.
+// Should rounding put p just outside the cavity it is grown until p sees all
+// of its boundary, where constraints allow.
+func (x *IncrementalDelaunay2D) cavity(p *Vector2D, t int) *cavity2D {
+	// Bowyer-Watson: remove every triangle whose circumcircle contains p and
+	// join p to the edges of the cavity left behind
+	z := &cavity2D{triangles: []int{t}}
+	x.dead[t] = true
+	for j := 0; j < len(z.triangles); j++ {
+		c := z.triangles[j]
+		for k, n := range x.adj[c] {
+			if x.dead[n] {
+				continue
+			}
+			if !x.fixed[c][k] && (x.conflict(n, p) || !x.sees(c, k, p)) {
+				x.dead[n] = true
+				z.triangles = append(z.triangles, n)
+				continue
+			}
+			z.boundary = append(z.boundary, cavityEdge{x.tri[c][(k+1)%3], x.tri[c][(k+2)%3], n, x.fixed[c][k], x.hole[c]})
+		}
+	}
+	return z
+}
+
+// sees returns true if p is strictly inside the edge of triangle t opposite
+// vertex k, or the edge has the ghost vertex, or false otherwise.
+func (x *IncrementalDelaunay2D) sees(t, k int, p *Vector2D) bool {
+	a, b := x.tri[t][(k+1)%3], x.tri[t][(k+2)%3]
+	return a == ghost || b == ghost || Orient2D(&x.Points[a], &x.Points[b], p) > 0
+}
+
+// release unmarks the triangles of cavity c without changing them.
+func (x *IncrementalDelaunay2D) release(c *cavity2D) {
+	for _, t := range c.triangles {
+		x.dead[t] = false
+	}
+}
+
+// commit replaces the triangles of cavity c with triangles joining point i to
+// its boundary then returns the new triangles.
+func (x *IncrementalDelaunay2D) commit(i int, c *cavity2D) []int {
+	x.index = nil
+	// the cavity boundary is a cycle so each vertex starts exactly one edge,
+	// and there are always two more new triangles than old ones
+	created := make([]int, len(c.boundary))
+	start := make(map[int]int, len(c.boundary))
+	for j, e := range c.boundary {
+		var s int
+		if j < len(c.triangles) {
+			s = c.triangles[j]
+			x.dead[s] = false
+		} else {
+			s = len(x.tri)
+			x.tri = append(x.tri, [3]int{})
+			x.adj = append(x.adj, [3]int{})
+			x.fixed = append(x.fixed, [3]bool{})
+			x.hole = append(x.hole, false)
+			x.dead = append(x.dead, false)
+		}
+		x.tri[s] = [3]int{e.a, e.b, i}
+		x.adj[s][2] = e.out
+		x.fixed[s] = [3]bool{2: e.fixed}
+		x.hole[s] = e.hole
+		x.adj[e.out][x.opposite(e.out, e.b, e.a)] = s
+		start[e.a] = s
+		created[j] = s
+	}
+	for _, s := range created {
+		v := &x.tri[s]
+		x.adj[s][0] = start[v[1]]
+		x.adj[start[v[1]]][1] = s
+		if !x.isGhost(s) {
+			x.last = s
+		}
+	}
+	return created
+}
+
+// insertPending adds point i while every point so far is collinear, creating
+// the first triangle once a point is not, then returns true, or false if it
+// duplicates an earlier point.
+func (x *IncrementalDelaunay2D) insertPending(i int) bool {
+	p := &x.Points[i]
+	for _, v := range x.pending {
+		if x.Points[v] == *p {
+			return false
+		}
+	}
+	n := len(x.pending)
+	if n < 2 || Orient2D(&x.Points[x.pending[0]], &x.Points[x.pending[1]], p) == 0 {
+		x.pending = append(x.pending, i)
+		return true
+	}
+
+	// sorting collinear points orders them along their line
+	sort.Slice(x.pending, func(i, j int) bool {
+		a, b := &x.Points[x.pending[i]], &x.Points[x.pending[j]]
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		return a.Y < b.Y
+	})
+	a, b := x.pending[0], x.pending[1]
+	if Orient2D(&x.Points[a], &x.Points[b], p) < 0 {
+		a, b = b, a
+	}
+	x.tri = [][3]int{{a, b, i}, {b, a, ghost}, {i, b, ghost}, {a, i, ghost}}
+	x.adj = [][3]int{{2, 3, 1}, {3, 2, 0}, {1, 3, 0}, {2, 1, 0}}
+	x.fixed = make([][3]bool, 4)
+	x.hole = make([]bool, 4)
+	x.dead = make([]bool, 4)
+	x.last = 0
+	x.index = nil
+	rest := x.pending[2:]
+	x.pending = nil
+	for _, v := range rest {
+		x.insert(v)
+	}
+	return true
+}
+
+// opposite returns the index in triangle t of the vertex opposite the
+// directed edge from a to b.
+func (x *IncrementalDelaunay2D) opposite(t, a, b int) int {
+	v := &x.tri[t]
+	for i := range v {
+		if v[(i+1)%3] == a && v[(i+2)%3] == b {
+			return i
+		}
+	}
+	panic("geometry: triangulation edge not found")
+}
+
+// conflict returns true if p is inside the circumcircle of triangle t or
+// false otherwise. The circumcircle of a ghost triangle is the open half
+// plane outside its hull edge plus the open hull edge itself.
+func (x *IncrementalDelaunay2D) conflict(t int, p *Vector2D) bool {
+	v := &x.tri[t]
+	for i := range v {
+		if v[i] != ghost {
+			continue
+		}
+		a, b := &x.Points[v[(i+1)%3]], &x.Points[v[(i+2)%3]]
+		switch Orient2D(a, b, p) {
+		case 1:
+			return true
+		case 0:
+			// collinear, so a single coordinate orders the three points
+			if a.X != b.X {
+				return (a.X < p.X && p.X < b.X) || (b.X < p.X && p.X < a.X)
+			}
+			return (a.Y < p.Y && p.Y < b.Y) || (b.Y < p.Y && p.Y < a.Y)
+		}
+		return false
+	}
+	return InCircle(&x.Points[v[0]], &x.Points[v[1]], &x.Points[v[2]], p) > 0
+}
+
+// walk returns a triangle containing p, or a ghost triangle whose hull edge
+// has p strictly outside it, by walking from the last triangle towards p.
+func (x *IncrementalDelaunay2D) walk(p *Vector2D) int {
+	t, _ := x.walkFrom(x.last, p, false)
+	return t
+}
+
+// walkFrom is like walk but starts from triangle t. If constrained is true
+// and the walk would cross a constraint it stops and returns the triangle and
+// the index of the vertex opposite the constraint, otherwise the index is -1.
+func (x *IncrementalDelaunay2D) walkFrom(t int, p *Vector2D, constrained bool) (int, int) {
+	// always trying the edges in the same order can cycle in a triangulation
+	// that is not Delaunay, so the first edge tried varies
+	r := uint32(t)
+next:
+	for {
+		v := &x.tri[t]
+		for i := range v {
+			if v[i] == ghost {
+				if Orient2D(&x.Points[v[(i+1)%3]], &x.Points[v[(i+2)%3]], p) > 0 {
+					return t, -1
+				}
+				t = x.adj[t][i]
+				continue next
+			}
+		}
+		r = r*1664525 + 1013904223
+		for j := range v {
+			i := (j + int(r>>16)) % 3
+			if Orient2D(&x.Points[v[(i+1)%3]], &x.Points[v[(i+2)%3]], p) < 0 {
+				if constrained && x.fixed[t][i] {
+					return t, i
+				}
+				t = x.adj[t][i]
+				continue next
+			}
+		}
+		return t, -1
+	}
+}
@@ -0,0 +1,247 @@
+package geometry
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// checkDelaunay2D returns a description of the first way triangles and
+// adjacency fail to be the Delaunay triangulation of points, or "" if they
+// don't.
+func checkDelaunay2D(points []Vector2D, triangles, adjacency [][3]int) string {
+	if len(triangles) != len(adjacency) {
+		return "length mismatch"
+	}
+	used := make(map[int]bool)
+	for i, v := range triangles {
+		a, b, c := &points[v[0]], &points[v[1]], &points[v[2]]
+		if Orient2D(a, b, c) <= 0 {
+			return "triangle not counterclockwise"
+		}
+		for j := range v {
+			used[v[j]] = true
+			n := adjacency[i][j]
+			if n < 0 {
+				continue
+			}
+			// the neighbor has the same edge the other way around
+			e0, e1 := v[(j+1)%3], v[(j+2)%3]
+			k := -1
+			for m, w := range triangles[n] {
+				if w == e0 && triangles[n][(m+2)%3] == e1 {
+					k = (m + 1) % 3
+				}
+			}
+			if k < 0 || adjacency[n][k] != i {
+				return "adjacency not symmetric"
+			}
+			if InCircle(a, b, c, &points[triangles[n][k]]) > 0 {
+				return "not Delaunay"
+			}
+		}
+	}
+	// every distinct point is used and Euler's formula holds
+	if len(ConvexHull2D(points, false)) < 3 {
+		if triangles != nil {
+			return "triangles from collinear points"
+		}
+		return ""
+	}
+	n := 0
+	for i := range points {
+		first := true
+		for j := 0; j < i; j++ {
+			if points[j] == points[i] {
+				first = false
+				break
+			}
+		}
+		if first {
+			n++
+			if !used[i] {
+				return "point unused"
+			}
+		} else if used[i] {
+			return "duplicate point used"
+		}
+	}
+	if len(triangles) != 2*n-len(ConvexHull2D(points, true))-2 {
+		return "wrong number of triangles"
+	}
+	return ""
+}
+
+var delaunay2DValues = [][]Vector2D{
+	nil,
+	{{0, 0}, {1, 1}},
+	{{0, 0}, {1, 1}, {2, 2}, {1, 1}},
+	{{0, 0}, {1, 0}, {0, 1}},
+	// collinear points then one that is not
+	{{2, 0}, {0, 0}, {3, 0}, {1, 0}, {1, 1}},
+	{{1, 1}, {0, 0}, {2, 2}, {3, 3}, {2, 2}, {0, 3}},
+	// cocircular square with duplicates and a center point
+	{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {1, 1}, {0, 0}},
+	{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0.5, 0.5}},
+	// points on hull edges
+	{{0, 0}, {4, 0}, {0, 4}, {2, 0}, {2, 2}, {0, 2}, {1, 0}},
+	// nearly collinear points that naive arithmetic gets wrong
+	{{0.5, 0.5}, {12, 12}, {24, 24}, {0.50000000000000011, 0.5}},
+}
+
+func TestDelaunay2D(t *testing.T) {
+	for _, v := range delaunay2DValues {
+		triangles, adjacency := Delaunay2D(v)
+		if err := checkDelaunay2D(v, triangles, adjacency); err != "" {
+			t.Error("Delaunay2D", v, err, triangles, adjacency)
+		}
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		// a coarse grid to get plenty of duplicate and cocircular points
+		points := make([]Vector2D, 100)
+		for j := range points {
+			points[j] = Vector2D{float64(r.Intn(8)), float64(r.Intn(8))}
+		}
+		triangles, adjacency := Delaunay2D(points)
+		if err := checkDelaunay2D(points, triangles, adjacency); err != "" {
+			t.Fatal("Delaunay2D", points, err)
+		}
+	}
+}
+
+// vector2DLess returns true if a sorts before b by X then Y or false
+// otherwise.
+func vector2DLess(a, b Vector2D) bool {
+	return a.X < b.X || (a.X == b.X && a.Y < b.Y)
+}
+
+// delaunay2DCoordinates returns the triangles as sorted vertex coordinates.
+func delaunay2DCoordinates(points []Vector2D, triangles [][3]int) [][3]Vector2D {
+	var z [][3]Vector2D
+	for _, v := range triangles {
+		t := [3]Vector2D{points[v[0]], points[v[1]], points[v[2]]}
+		// rotate the lowest vertex first
+		for vector2DLess(t[1], t[0]) || vector2DLess(t[2], t[0]) {
+			t[0], t[1], t[2] = t[1], t[2], t[0]
+		}
+		z = append(z, t)
+	}
+	sort.Slice(z, func(i, j int) bool {
+		for k := 0; k < 3; k++ {
+			if z[i][k] != z[j][k] {
+				return vector2DLess(z[i][k], z[j][k])
+			}
+		}
+		return false
+	})
+	return z
+}
+
+func TestDelaunay2DDeterministic(t *testing.T) {
+	// a grid is entirely cocircular squares, so every order must agree
+	var points []Vector2D
+	for x := 0; x < 6; x++ {
+		for y := 0; y < 6; y++ {
+			points = append(points, Vector2D{float64(x), float64(y)})
+		}
+	}
+	triangles, _ := Delaunay2D(points)
+	want := delaunay2DCoordinates(points, triangles)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		r.Shuffle(len(points), func(i, j int) { points[i], points[j] = points[j], points[i] })
+		triangles, _ := Delaunay2D(points)
+		got := delaunay2DCoordinates(points, triangles)
+		if len(got) != len(want) {
+			t.Fatal("Delaunay2D shuffled", len(want), "triangles", "got", len(got))
+		}
+		for j := range got {
+			if got[j] != want[j] {
+				t.Fatal("Delaunay2D shuffled", i, "want", want[j], "got", got[j])
+			}
+		}
+	}
+}
+
+func TestIncrementalDelaunay2D(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var d IncrementalDelaunay2D
+	for i := 0; i < 200; i++ {
+		p := Vector2D{float64(r.Intn(10)), float64(r.Intn(10))}
+		if i%2 == 0 {
+			p = Vector2D{r.Float64() * 9, r.Float64() * 9}
+		}
+		duplicate := false
+		for _, q := range d.Points {
+			duplicate = duplicate || p == q
+		}
+		if d.Add(p) == duplicate {
+			t.Fatal("IncrementalDelaunay2D.Add", i, p, "duplicate", duplicate)
+		}
+		triangles, adjacency := d.Triangles()
+		if err := checkDelaunay2D(d.Points, triangles, adjacency); err != "" {
+			t.Fatal("IncrementalDelaunay2D", i, p, err)
+		}
+	}
+}
+
+func TestIncrementalDelaunay2DLocate(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	points := make([]Vector2D, 50)
+	for i := range points {
+		points[i] = Vector2D{r.Float64(), r.Float64()}
+	}
+	d := NewIncrementalDelaunay2D(points)
+	triangles, _ := d.Triangles()
+	var hull Polygon2D
+	hull.ConvexHull(points)
+	for i := 0; i < 200; i++ {
+		p := Vector2D{r.Float64()*1.2 - 0.1, r.Float64()*1.2 - 0.1}
+		if i < len(points) {
+			p = points[i]
+		}
+		got := d.Locate(&p)
+		if got < 0 {
+			if hull.Contains(&p) == 1 {
+				t.Error("IncrementalDelaunay2D.Locate", p, "inside hull got", got)
+			}
+			continue
+		}
+		v := triangles[got]
+		for j := range v {
+			if Orient2D(&points[v[j]], &points[v[(j+1)%3]], &p) < 0 {
+				t.Error("IncrementalDelaunay2D.Locate", p, "not in triangle", got)
+			}
+		}
+	}
+	if got := (&IncrementalDelaunay2D{}).Locate(&Vector2D{}); got != -1 {
+		t.Error("IncrementalDelaunay2D.Locate empty want -1 got", got)
+	}
+}
+
+func TestIncrementalDelaunay2DCircumcircle(t *testing.T) {
+	d := NewIncrementalDelaunay2D([]Vector2D{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {1, 3}})
+	triangles, _ := d.Triangles()
+	var c Circle
+	for i, v := range triangles {
+		d.Circumcircle(i, &c)
+		for _, j := range v {
+			if !FuzzyEqual(Distance2DPointPoint(&d.Points[j], &c.C), c.R) {
+				t.Error("IncrementalDelaunay2D.Circumcircle", i, "got", c)
+			}
+		}
+	}
+}
+
+func Benchmark_Delaunay2D(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	points := make([]Vector2D, 1000)
+	for i := range points {
+		points[i] = Vector2D{r.Float64(), r.Float64()}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Delaunay2D(points)
+	}
+}
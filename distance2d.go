@@ -4,6 +4,20 @@ import (
 	"math"
 )
 
+// Distance2DAABBPoint returns the distance between box a and point b, which is
+// zero if b is inside a.
+func Distance2DAABBPoint(a *AABB2D, b *Vector2D) float64 {
+	return math.Sqrt(Distance2DAABBPointSquared(a, b))
+}
+
+// Distance2DAABBPointSquared returns the squared distance between box a and
+// point b, which is zero if b is inside a.
+func Distance2DAABBPointSquared(a *AABB2D, b *Vector2D) float64 {
+	dx := math.Max(0, math.Max(a.Min.X-b.X, b.X-a.Max.X))
+	dy := math.Max(0, math.Max(a.Min.Y-b.Y, b.Y-a.Max.Y))
+	return dx*dx + dy*dy
+}
+
 // Distance2DLinePointAngular returns the angle the line segment a would have
 // to rotate about its midpoint to pass through point b.
 func Distance2DLinePointAngular(a *Line2D, b *Vector2D) float64 {
@@ -93,6 +107,43 @@ func Distance2DPointPointSquared(a, b *Vector2D) float64 {
 	return dx*dx + dy*dy
 }
 
+// Distance2DPolygonPoint returns the distance between polygon a and point b,
+// which is zero if b is inside a.
+func Distance2DPolygonPoint(a *Polygon2D, b *Vector2D) float64 {
+	return math.Sqrt(Distance2DPolygonPointSquared(a, b))
+}
+
+// Distance2DPolygonPointSquared returns the squared distance between polygon a
+// and point b, which is zero if b is inside a.
+func Distance2DPolygonPointSquared(a *Polygon2D, b *Vector2D) float64 {
+	if a.Contains(b) >= 0 {
+		return 0
+	}
+	d := ringDistanceSquared(a.Outer, b)
+	for _, r := range a.Holes {
+		d = math.Min(d, ringDistanceSquared(r, b))
+	}
+	return d
+}
+
+// Distance2DTrianglePoint returns the distance between triangle a and point b,
+// which is zero if b is inside a.
+func Distance2DTrianglePoint(a *Triangle2D, b *Vector2D) float64 {
+	var c Vector2D
+	a.ClosestPoint(b, &c)
+	dx, dy := b.X-c.X, b.Y-c.Y
+	return math.Sqrt(dx*dx + dy*dy)
+}
+
+// Distance2DTrianglePointSquared returns the squared distance between triangle
+// a and point b, which is zero if b is inside a.
+func Distance2DTrianglePointSquared(a *Triangle2D, b *Vector2D) float64 {
+	var c Vector2D
+	a.ClosestPoint(b, &c)
+	dx, dy := b.X-c.X, b.Y-c.Y
+	return dx*dx + dy*dy
+}
+
 // Distance2DVectorVectorAngular returns the angle between a and b.
 func Distance2DVectorVectorAngular(a, b *Vector2D) float64 {
 	return math.Acos((a.X*b.X + a.Y*b.Y) /
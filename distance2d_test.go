@@ -149,6 +149,33 @@ func Benchmark_Distance2D_PointPointSquared(b *testing.B) {
 	}
 }
 
+func TestDistance2DPolygonPoint(t *testing.T) {
+	for _, v := range []struct {
+		p Vector2D
+		d float64
+	}{
+		{Vector2D{0.5, 0.5}, 0},
+		{Vector2D{2, 2}, 1},
+		{Vector2D{2, 2.5}, 0.5},
+		{Vector2D{7, 8}, 5},
+		{Vector2D{-1, 2}, 1},
+	} {
+		if got := Distance2DPolygonPoint(&polygon2DSquareWithHole, &v.p); !FuzzyEqual(got, v.d) {
+			t.Error("Distance2D.PolygonPoint", v.p, "want", v.d, "got", got)
+		}
+		if got := Distance2DPolygonPointSquared(&polygon2DSquareWithHole, &v.p); !FuzzyEqual(got, v.d*v.d) {
+			t.Error("Distance2D.PolygonPointSquared", v.p, "want", v.d*v.d, "got", got)
+		}
+	}
+}
+
+func Benchmark_Distance2D_PolygonPoint(b *testing.B) {
+	p := &Vector2D{7, 8}
+	for i := 0; i < b.N; i++ {
+		Distance2DPolygonPoint(&polygon2DSquareWithHole, p)
+	}
+}
+
 func TestDistance2DVectorVectorAngular(t *testing.T) {
 	v1, v2 := &Vector2D{1, 0}, &Vector2D{0, 1}
 	if Distance2DVectorVectorAngular(v1, v2) != math.Pi/2 {
@@ -177,3 +204,16 @@ func Benchmark_Distance2D_VectorVectorAngularCosSquared(b *testing.B) {
 		Distance2DVectorVectorAngularCosSquared(v1, v2)
 	}
 }
+
+func TestDistance2DAABBPoint(t *testing.T) {
+	b := &AABB2D{Vector2D{1, 1}, Vector2D{3, 3}}
+	if d := Distance2DAABBPoint(b, &Vector2D{6, 7}); d != 5 {
+		t.Error("Distance2D.AABBPoint", b, "want", 5, "got", d)
+	}
+	if d := Distance2DAABBPointSquared(b, &Vector2D{2, 0}); d != 1 {
+		t.Error("Distance2D.AABBPointSquared", b, "want", 1, "got", d)
+	}
+	if d := Distance2DAABBPoint(b, &Vector2D{2, 2}); d != 0 {
+		t.Error("Distance2D.AABBPoint", b, "want", 0, "got", d)
+	}
+}
@@ -4,6 +4,21 @@ import (
 	"math"
 )
 
+// Distance3DAABBPoint returns the distance between box a and point b, which is
+// zero if b is inside a.
+func Distance3DAABBPoint(a *AABB3D, b *Vector3D) float64 {
+	return math.Sqrt(Distance3DAABBPointSquared(a, b))
+}
+
+// Distance3DAABBPointSquared returns the squared distance between box a and
+// point b, which is zero if b is inside a.
+func Distance3DAABBPointSquared(a *AABB3D, b *Vector3D) float64 {
+	dx := math.Max(0, math.Max(a.Min.X-b.X, b.X-a.Max.X))
+	dy := math.Max(0, math.Max(a.Min.Y-b.Y, b.Y-a.Max.Y))
+	dz := math.Max(0, math.Max(a.Min.Z-b.Z, b.Z-a.Max.Z))
+	return dx*dx + dy*dy + dz*dz
+}
+
 // Distance3DLinePointAngular returns the angle the line segment a would have
 // to rotate about its midpoint to pass through point b.
 func Distance3DLinePointAngular(a *Line3D, b *Vector3D) float64 {
@@ -107,6 +122,23 @@ func Distance3DPointPointSquared(a, b *Vector3D) float64 {
 	return dx*dx + dy*dy + dz*dz
 }
 
+// Distance3DTrianglePoint returns the distance between triangle a and point b.
+func Distance3DTrianglePoint(a *Triangle3D, b *Vector3D) float64 {
+	var c Vector3D
+	a.ClosestPoint(b, &c)
+	dx, dy, dz := b.X-c.X, b.Y-c.Y, b.Z-c.Z
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
+
+// Distance3DTrianglePointSquared returns the squared distance between
+// triangle a and point b.
+func Distance3DTrianglePointSquared(a *Triangle3D, b *Vector3D) float64 {
+	var c Vector3D
+	a.ClosestPoint(b, &c)
+	dx, dy, dz := b.X-c.X, b.Y-c.Y, b.Z-c.Z
+	return dx*dx + dy*dy + dz*dz
+}
+
 // Distance3DVectorVectorAngular returns the angle between a and b.
 func Distance3DVectorVectorAngular(a, b *Vector3D) float64 {
 	return math.Acos((a.X*b.X + a.Y*b.Y + a.Z*b.Z) /
@@ -230,3 +230,13 @@ func Benchmark_Distance3D_VectorVectorAngularCosSquared(b *testing.B) {
 		Distance3DVectorVectorAngularCosSquared(v1, v2)
 	}
 }
+
+func TestDistance3DAABBPoint(t *testing.T) {
+	b := &AABB3D{Vector3D{1, 1, 1}, Vector3D{3, 3, 3}}
+	if d := Distance3DAABBPoint(b, &Vector3D{5, 0, 5}); d != 3 {
+		t.Error("Distance3D.AABBPoint", b, "want", 3, "got", d)
+	}
+	if d := Distance3DAABBPointSquared(b, &Vector3D{2, 2, 2}); d != 0 {
+		t.Error("Distance3D.AABBPointSquared", b, "want", 0, "got", d)
+	}
+}
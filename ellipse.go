@@ -0,0 +1,77 @@
+package geometry
+
+import (
+	"math"
+)
+
+// An Ellipse represents all points whose distances from two foci sum to a
+// constant, described by its center C, the lengths of its two semi-axes A and
+// B, and the counterclockwise angle of the A semi-axis from the x axis.
+type Ellipse struct {
+	C     Vector2D
+	A, B  float64
+	Angle float64
+}
+
+// Area returns the area of the ellipse.
+func (x *Ellipse) Area() float64 {
+	return math.Pi * x.A * x.B
+}
+
+// Copy sets z to x then returns z.
+func (z *Ellipse) Copy(x *Ellipse) *Ellipse {
+	*z = *x
+	return z
+}
+
+// Equal returns true if the two ellipses are exactly equal or false otherwise.
+func (a *Ellipse) Equal(b *Ellipse) bool {
+	return *a == *b
+}
+
+// FromCircle sets z to the ellipse equivalent to circle x then returns z.
+func (z *Ellipse) FromCircle(x *Circle) *Ellipse {
+	z.C = x.C
+	z.A = x.R
+	z.B = x.R
+	z.Angle = 0
+	return z
+}
+
+// FuzzyEqual returns true if the two ellipses are very close or false
+// otherwise.
+func (a *Ellipse) FuzzyEqual(b *Ellipse) bool {
+	return DefaultTolerance.EllipseEqual(a, b)
+}
+
+// EllipseEqual is like Ellipse.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) EllipseEqual(a, b *Ellipse) bool {
+	return t.Equal(a.C.X, b.C.X) && t.Equal(a.C.Y, b.C.Y) && t.Equal(a.A, b.A) &&
+		t.Equal(a.B, b.B) && t.Equal(a.Angle, b.Angle)
+}
+
+// Transform sets z to ellipse x transformed by m then returns z. The A
+// semi-axis of z is always the major one.
+func (z *Ellipse) Transform(m *Affine2D, x *Ellipse) *Ellipse {
+	// the ellipse is the unit circle mapped by the linear part l, which is
+	// split by a 2x2 singular value decomposition to recover the new axes
+	s, c := math.Sincos(x.Angle)
+	l00, l01 := m[0][0]*c+m[0][1]*s, m[0][1]*c-m[0][0]*s
+	l10, l11 := m[1][0]*c+m[1][1]*s, m[1][1]*c-m[1][0]*s
+	l00, l01, l10, l11 = l00*x.A, l01*x.B, l10*x.A, l11*x.B
+	e, f := (l00+l11)/2, (l00-l11)/2
+	g, h := (l10+l01)/2, (l10-l01)/2
+	q, r := math.Sqrt(e*e+h*h), math.Sqrt(f*f+g*g)
+	z.Angle = (math.Atan2(h, e) + math.Atan2(g, f)) / 2
+	z.A = q + r
+	z.B = math.Abs(q - r)
+	z.C.TransformPoint(m, &x.C)
+	return z
+}
+
+// TransformCircle sets z to circle x transformed by m then returns z. Unlike
+// Circle.Transform any affine transform may be used.
+func (z *Ellipse) TransformCircle(m *Affine2D, x *Circle) *Ellipse {
+	var e Ellipse
+	return z.Transform(m, e.FromCircle(x))
+}
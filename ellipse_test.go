@@ -0,0 +1,48 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEllipseArea(t *testing.T) {
+	e := Ellipse{Vector2D{}, 2, 3, 1}
+	if got := e.Area(); got != 6*math.Pi {
+		t.Error("Ellipse.Area", e, "got", got)
+	}
+}
+
+type ellipseTransformData struct {
+	m    Affine2D
+	e, r Ellipse
+}
+
+var ellipseTransformValues = []ellipseTransformData{
+	{Affine2D{{2, 0, 1}, {0, 1, 2}}, Ellipse{Vector2D{1, 1}, 1, 1, 0}, Ellipse{Vector2D{3, 3}, 2, 1, 0}},
+	{Affine2D{{1, 0, 0}, {0, 3, 0}}, Ellipse{Vector2D{}, 2, 2, 0}, Ellipse{Vector2D{}, 6, 2, math.Pi / 2}},
+	{Affine2D{{0, -1, 0}, {1, 0, 0}}, Ellipse{Vector2D{1, 0}, 3, 1, 0}, Ellipse{Vector2D{0, 1}, 3, 1, math.Pi / 2}},
+	{Affine2D{{2, 0, 0}, {0, 1, 0}}, Ellipse{Vector2D{}, 3, 1, math.Pi / 2}, Ellipse{Vector2D{}, 3, 2, math.Pi / 2}},
+}
+
+func testEllipseTransform(d ellipseTransformData, t *testing.T) {
+	var e Ellipse
+	if !e.Transform(&d.m, &d.e).FuzzyEqual(&d.r) {
+		t.Error("Ellipse.Transform", d.m, d.e, "want", d.r, "got", e)
+	}
+}
+
+func TestEllipseTransform(t *testing.T) {
+	for _, v := range ellipseTransformValues {
+		testEllipseTransform(v, t)
+	}
+}
+
+func TestEllipseTransformCircle(t *testing.T) {
+	var m Affine2D
+	var e Ellipse
+	m.Compose(&Vector2D{1, 2}, &Vector2D{2, 0.5}, 0.3, 0)
+	want := Ellipse{Vector2D{1, 2}, 4, 1, 0.3}
+	if !e.TransformCircle(&m, &Circle{Vector2D{}, 2}).FuzzyEqual(&want) {
+		t.Error("Ellipse.TransformCircle", "want", want, "got", e)
+	}
+}
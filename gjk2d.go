@@ -0,0 +1,276 @@
+package geometry
+
+import (
+	"math"
+)
+
+// A Convex2D is a convex shape described by its support function, which is
+// all the GJK and EPA based functions need to know about it. Circle, AABB2D,
+// Polygon2D, and Triangle2D implement it.
+type Convex2D interface {
+	// Support returns the point of the shape furthest in direction d, which
+	// need not be normalized.
+	Support(d Vector2D) Vector2D
+}
+
+// Distance2DConvexConvex returns the distance between convex shapes a and b
+// and sets y and z to the closest points on a and b respectively. If the
+// shapes overlap 0 is returned and y and z are untouched.
+func Distance2DConvexConvex(a, b Convex2D, y, z *Vector2D) float64 {
+	var s gjkSimplex2D
+	v, overlap := s.run(a, b, false)
+	if overlap {
+		return 0
+	}
+	*y, *z = Vector2D{}, Vector2D{}
+	for i := 0; i < s.n; i++ {
+		l := s.l[i]
+		y.X += l * s.a[i].X
+		y.Y += l * s.a[i].Y
+		z.X += l * s.b[i].X
+		z.Y += l * s.b[i].Y
+	}
+	return v.Magnitude()
+}
+
+// Intersection2DConvexConvex returns true if convex shapes a and b overlap or
+// touch or false otherwise.
+func Intersection2DConvexConvex(a, b Convex2D) bool {
+	var s gjkSimplex2D
+	_, overlap := s.run(a, b, true)
+	return overlap
+}
+
+// Penetration2DConvexConvex returns how far convex shapes a and b overlap and
+// sets z to the unit contact normal, pointing from a towards b, such that
+// moving b by the returned depth along z leaves the shapes touching. If the
+// shapes do not overlap 0 is returned and z is untouched.
+func Penetration2DConvexConvex(a, b Convex2D, z *Vector2D) float64 {
+	var s gjkSimplex2D
+	if _, overlap := s.run(a, b, true); !overlap {
+		return 0
+	}
+	if !s.fill(a, b) {
+		// the Minkowski difference is flat so the shapes only touch
+		return 0
+	}
+	// the polytope is a counterclockwise polygon
+	p := []Vector2D{s.w[0], s.w[1], s.w[2]}
+	if cross2D(&p[0], &p[1], &p[2]) < 0 {
+		p[1], p[2] = p[2], p[1]
+	}
+	var n Vector2D
+	d := 0.0
+	for iter := 0; iter < gjkMaxIterations; iter++ {
+		// find the edge closest to the origin
+		best := -1
+		d = math.Inf(1)
+		for i := range p {
+			j := (i + 1) % len(p)
+			e := Vector2D{p[j].Y - p[i].Y, p[i].X - p[j].X}
+			m := e.Magnitude()
+			if m == 0 {
+				continue
+			}
+			e.X, e.Y = e.X/m, e.Y/m
+			if ed := e.DotProduct(&p[i]); ed < d {
+				best, d, n = i, ed, e
+			}
+		}
+		w := minkowskiSupport2D(a, b, &n)
+		if wd := n.DotProduct(&w.w); wd-d <= gjkTolerance*math.Max(wd, 1e-150) {
+			break
+		}
+		p = append(p, Vector2D{})
+		copy(p[best+2:], p[best+1:])
+		p[best+1] = w.w
+	}
+	*z = n
+	return d
+}
+
+// gjkVertex2D is a point of the Minkowski difference a-b and the support
+// points of a and b it came from.
+type gjkVertex2D struct {
+	w, a, b Vector2D
+}
+
+// minkowskiSupport2D returns the point of the Minkowski difference a-b
+// furthest in direction d.
+func minkowskiSupport2D(a, b Convex2D, d *Vector2D) gjkVertex2D {
+	var v gjkVertex2D
+	v.a = a.Support(*d)
+	v.b = b.Support(Vector2D{-d.X, -d.Y})
+	v.w.Subtract(&v.a, &v.b)
+	return v
+}
+
+// gjkSimplex2D is the simplex GJK maintains, with the barycentric coordinates
+// l of the point on it closest to the origin.
+type gjkSimplex2D struct {
+	n       int
+	w, a, b [3]Vector2D
+	l       [3]float64
+}
+
+// run runs GJK on shapes a and b, then returns the point of the Minkowski
+// difference closest to the origin and true if the shapes overlap or false
+// otherwise. If early is true it stops as soon as the shapes are known to be
+// apart, so the returned point is not the closest.
+func (s *gjkSimplex2D) run(a, b Convex2D, early bool) (Vector2D, bool) {
+	v := minkowskiSupport2D(a, b, &Vector2D{1, 0})
+	s.n = 1
+	s.w[0], s.a[0], s.b[0], s.l[0] = v.w, v.a, v.b, 1
+	c := v.w
+	scale := c.MagnitudeSquared()
+	for iter := 0; iter < gjkMaxIterations; iter++ {
+		cc := c.MagnitudeSquared()
+		if cc <= gjkTolerance*gjkTolerance*scale {
+			return c, true
+		}
+		v = minkowskiSupport2D(a, b, &Vector2D{-c.X, -c.Y})
+		cw := c.DotProduct(&v.w)
+		if early && cw > 0 {
+			return c, false
+		}
+		if cc-cw <= gjkTolerance*cc {
+			return c, false
+		}
+		for i := 0; i < s.n; i++ {
+			if s.w[i] == v.w {
+				return c, false
+			}
+		}
+		scale = math.Max(scale, v.w.MagnitudeSquared())
+		s.w[s.n], s.a[s.n], s.b[s.n] = v.w, v.a, v.b
+		s.n++
+		c = s.closest()
+		if s.n == 3 {
+			return c, true
+		}
+	}
+	return c, false
+}
+
+// closest reduces s to the smallest sub-simplex containing the point of s
+// closest to the origin, sets the barycentric coordinates of the point, then
+// returns it. If the origin is inside a triangle s is left unchanged.
+func (s *gjkSimplex2D) closest() Vector2D {
+	if s.n == 2 {
+		return s.closestSegment(0, 1)
+	}
+	// Ericson, Real-Time Collision Detection, 5.1.5
+	a, b, c := &s.w[0], &s.w[1], &s.w[2]
+	var ab, ac Vector2D
+	ab.Subtract(b, a)
+	ac.Subtract(c, a)
+	d1, d2 := -ab.DotProduct(a), -ac.DotProduct(a)
+	if d1 <= 0 && d2 <= 0 {
+		return s.keep(0)
+	}
+	d3, d4 := -ab.DotProduct(b), -ac.DotProduct(b)
+	if d3 >= 0 && d4 <= d3 {
+		return s.keep(1)
+	}
+	vc := d1*d4 - d3*d2
+	if vc <= 0 && d1 >= 0 && d3 <= 0 {
+		t := d1 / (d1 - d3)
+		return s.keep2(0, 1, 1-t, t)
+	}
+	d5, d6 := -ab.DotProduct(c), -ac.DotProduct(c)
+	if d6 >= 0 && d5 <= d6 {
+		return s.keep(2)
+	}
+	vb := d5*d2 - d1*d6
+	if vb <= 0 && d2 >= 0 && d6 <= 0 {
+		t := d2 / (d2 - d6)
+		return s.keep2(0, 2, 1-t, t)
+	}
+	va := d3*d6 - d5*d4
+	if va <= 0 && d4-d3 >= 0 && d5-d6 >= 0 {
+		t := (d4 - d3) / ((d4 - d3) + (d5 - d6))
+		return s.keep2(1, 2, 1-t, t)
+	}
+	// the origin is inside the triangle
+	d := 1 / (va + vb + vc)
+	v, w := vb*d, vc*d
+	s.l = [3]float64{1 - v - w, v, w}
+	return Vector2D{}
+}
+
+// closestSegment reduces s to the vertices i and j, or one of them, nearest
+// the origin then returns the closest point.
+func (s *gjkSimplex2D) closestSegment(i, j int) Vector2D {
+	var ab Vector2D
+	ab.Subtract(&s.w[j], &s.w[i])
+	t := -s.w[i].DotProduct(&ab) / ab.MagnitudeSquared()
+	if !(t > 0) {
+		return s.keep(i)
+	}
+	if t >= 1 {
+		return s.keep(j)
+	}
+	return s.keep2(i, j, 1-t, t)
+}
+
+// fill adds points of the Minkowski difference of a and b to s until it is a
+// triangle then returns true, or returns false if the difference is flat.
+func (s *gjkSimplex2D) fill(a, b Convex2D) bool {
+	for s.n < 3 {
+		var try []Vector2D
+		if s.n == 1 {
+			try = []Vector2D{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+		} else {
+			var l Vector2D
+			l.Subtract(&s.w[1], &s.w[0])
+			try = []Vector2D{{-l.Y, l.X}, {l.Y, -l.X}}
+		}
+		added := false
+		for i := range try {
+			v := minkowskiSupport2D(a, b, &try[i])
+			if s.affinelyIndependent(&v.w) {
+				s.w[s.n], s.a[s.n], s.b[s.n] = v.w, v.a, v.b
+				s.n++
+				added = true
+				break
+			}
+		}
+		if !added {
+			return false
+		}
+	}
+	return true
+}
+
+// affinelyIndependent returns true if adding p to s would not make it
+// degenerate or false otherwise.
+func (s *gjkSimplex2D) affinelyIndependent(p *Vector2D) bool {
+	var d Vector2D
+	d.Subtract(p, &s.w[0])
+	scale := math.Max(p.MagnitudeSquared(), s.w[0].MagnitudeSquared())
+	if s.n == 1 {
+		return d.MagnitudeSquared() > gjkTolerance*scale
+	}
+	c := cross2D(&s.w[0], &s.w[1], p)
+	return c*c > gjkTolerance*scale*scale
+}
+
+// keep reduces s to vertex i then returns it.
+func (s *gjkSimplex2D) keep(i int) Vector2D {
+	s.w[0], s.a[0], s.b[0] = s.w[i], s.a[i], s.b[i]
+	s.l = [3]float64{1}
+	s.n = 1
+	return s.w[0]
+}
+
+// keep2 reduces s to vertices i and j with barycentric coordinates u and v
+// then returns the point.
+func (s *gjkSimplex2D) keep2(i, j int, u, v float64) Vector2D {
+	w0, a0, b0 := s.w[i], s.a[i], s.b[i]
+	w1, a1, b1 := s.w[j], s.a[j], s.b[j]
+	s.w[0], s.a[0], s.b[0] = w0, a0, b0
+	s.w[1], s.a[1], s.b[1] = w1, a1, b1
+	s.l = [3]float64{u, v}
+	s.n = 2
+	return Vector2D{u*w0.X + v*w1.X, u*w0.Y + v*w1.Y}
+}
@@ -0,0 +1,66 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+type gjk2DData struct {
+	a, b  Convex2D
+	dist  float64
+	depth float64
+	n     Vector2D
+}
+
+var gjk2DValues = []gjk2DData{
+	{&Circle{Vector2D{0, 0}, 1}, &Circle{Vector2D{0, 3}, 1}, 1, 0, Vector2D{}},
+	{&Circle{Vector2D{0, 0}, 1}, &Circle{Vector2D{0, 1.5}, 1}, 0, 0.5, Vector2D{0, 1}},
+	{&AABB2D{Vector2D{0, 0}, Vector2D{2, 2}}, &Circle{Vector2D{4, 4}, 1}, 2*math.Sqrt2 - 1, 0, Vector2D{}},
+	{&AABB2D{Vector2D{0, 0}, Vector2D{2, 2}}, &AABB2D{Vector2D{1.5, 0.5}, Vector2D{3, 1.5}}, 0, 0.5, Vector2D{1, 0}},
+	{NewPolygon2D([]Vector2D{{0, 0}, {4, 0}, {2, 2}}), &Circle{Vector2D{2, -2}, 1}, 1, 0, Vector2D{}},
+	{NewPolygon2D([]Vector2D{{0, 0}, {4, 0}, {2, 2}}), &Circle{Vector2D{2, -0.5}, 1}, 0, 0.5, Vector2D{0, -1}},
+	{NewTriangle2D(0, 0, 2, 0, 0, 2), NewTriangle2D(3, 3, 5, 3, 3, 5), math.Sqrt2 * 2, 0, Vector2D{}},
+}
+
+func TestDistance2DConvexConvex(t *testing.T) {
+	for _, v := range gjk2DValues {
+		var y, z Vector2D
+		d := Distance2DConvexConvex(v.a, v.b, &y, &z)
+		if math.Abs(d-v.dist) > 1e-6 {
+			t.Error("Distance2D.ConvexConvex", v.a, v.b, "want", v.dist, "got", d)
+			continue
+		}
+		if d > 0 && math.Abs(Distance2DPointPoint(&y, &z)-d) > 1e-6 {
+			t.Error("Distance2D.ConvexConvex", v.a, v.b, "closest points", y, z, "are not", d, "apart")
+		}
+		if Intersection2DConvexConvex(v.a, v.b) != (v.dist == 0) {
+			t.Error("Intersection2D.ConvexConvex", v.a, v.b, "want", v.dist == 0)
+		}
+	}
+	a := &AABB2D{Vector2D{0, 0}, Vector2D{1, 1}}
+	b := &Circle{Vector2D{3, 0.5}, 1}
+	var y, z Vector2D
+	if d := Distance2DConvexConvex(a, b, &y, &z); math.Abs(d-1) > 1e-6 ||
+		Distance2DPointPoint(&y, &Vector2D{1, 0.5}) > 1e-6 || Distance2DPointPoint(&z, &Vector2D{2, 0.5}) > 1e-6 {
+		t.Error("Distance2D.ConvexConvex", a, b, "got", d, y, z)
+	}
+}
+
+func TestPenetration2DConvexConvex(t *testing.T) {
+	for _, v := range gjk2DValues {
+		var n Vector2D
+		d := Penetration2DConvexConvex(v.a, v.b, &n)
+		if math.Abs(d-v.depth) > 1e-6 || (d > 0 && Distance2DPointPoint(&n, &v.n) > 1e-4) {
+			t.Error("Penetration2D.ConvexConvex", v.a, v.b, "want", v.depth, v.n, "got", d, n)
+		}
+	}
+}
+
+func Benchmark_Distance2D_ConvexConvex(b *testing.B) {
+	x := NewPolygon2D([]Vector2D{{0, 0}, {4, 0}, {2, 2}})
+	y := &Circle{Vector2D{2, -2}, 1}
+	var p, q Vector2D
+	for i := 0; i < b.N; i++ {
+		Distance2DConvexConvex(x, y, &p, &q)
+	}
+}
@@ -0,0 +1,471 @@
+package geometry
+
+import (
+	"math"
+)
+
+// A Convex3D is a convex shape described by its support function, which is
+// all the GJK and EPA based functions need to know about it. Sphere, AABB3D,
+// OBB3D, Capsule3D, Hull3D, and Triangle3D implement it.
+type Convex3D interface {
+	// Support returns the point of the shape furthest in direction d, which
+	// need not be normalized.
+	Support(d Vector3D) Vector3D
+}
+
+// Distance3DConvexConvex returns the distance between convex shapes a and b
+// and sets y and z to the closest points on a and b respectively. If the
+// shapes overlap 0 is returned and y and z are untouched.
+func Distance3DConvexConvex(a, b Convex3D, y, z *Vector3D) float64 {
+	var s gjkSimplex3D
+	v, overlap := s.run(a, b, false)
+	if overlap {
+		return 0
+	}
+	*y, *z = Vector3D{}, Vector3D{}
+	for i := 0; i < s.n; i++ {
+		l := s.l[i]
+		y.X += l * s.a[i].X
+		y.Y += l * s.a[i].Y
+		y.Z += l * s.a[i].Z
+		z.X += l * s.b[i].X
+		z.Y += l * s.b[i].Y
+		z.Z += l * s.b[i].Z
+	}
+	return v.Magnitude()
+}
+
+// Intersection3DConvexConvex returns true if convex shapes a and b overlap or
+// touch or false otherwise.
+func Intersection3DConvexConvex(a, b Convex3D) bool {
+	var s gjkSimplex3D
+	_, overlap := s.run(a, b, true)
+	return overlap
+}
+
+// Penetration3DConvexConvex returns how far convex shapes a and b overlap and
+// sets z to the unit contact normal, pointing from a towards b, such that
+// moving b by the returned depth along z leaves the shapes touching. If the
+// shapes do not overlap 0 is returned and z is untouched.
+func Penetration3DConvexConvex(a, b Convex3D, z *Vector3D) float64 {
+	var s gjkSimplex3D
+	if _, overlap := s.run(a, b, true); !overlap {
+		return 0
+	}
+	// Van den Bergen, "Proximity Queries and Penetration Depth Computation on
+	// 3D Game Objects", GDC 2001, expanding the simplex GJK finishes with
+	// into a polytope until its face closest to the origin is on the boundary
+	// of the Minkowski difference
+	if !s.fill(a, b) {
+		// the Minkowski difference is flat so the shapes only touch
+		return 0
+	}
+	p := epaPolytope3D{edges: make(map[[2]int]int)}
+	for i := 0; i < 4; i++ {
+		p.points = append(p.points, s.w[i])
+	}
+	var n Vector3D
+	n.Subtract(&s.w[1], &s.w[0])
+	var e2, e3 Vector3D
+	e2.Subtract(&s.w[2], &s.w[0])
+	e3.Subtract(&s.w[3], &s.w[0])
+	v0, v1, v2, v3 := 0, 1, 2, 3
+	if n.CrossProduct(&n, &e2).DotProduct(&e3) > 0 {
+		v1, v2 = v2, v1
+	}
+	for _, f := range [4][3]int{{v0, v1, v2}, {v0, v3, v1}, {v1, v3, v2}, {v2, v3, v0}} {
+		p.addFace(f)
+	}
+
+	var best *epaFace3D
+	for iter := 0; iter < gjkMaxIterations; iter++ {
+		best = nil
+		for i := range p.faces {
+			if f := &p.faces[i]; f.alive && (best == nil || f.d < best.d) {
+				best = f
+			}
+		}
+		w := minkowskiSupport3D(a, b, &best.n)
+		if d := best.n.DotProduct(&w.w); d-best.d <= gjkTolerance*math.Max(d, 1e-150) {
+			break
+		}
+		if !p.expand(w.w) {
+			break
+		}
+	}
+	*z = best.n
+	return best.d
+}
+
+// gjkMaxIterations bounds the iterations of GJK and EPA, which converge slowly
+// on curved shapes.
+const gjkMaxIterations = 128
+
+// gjkTolerance is the relative tolerance at which GJK and EPA stop.
+const gjkTolerance = 1e-12
+
+// gjkVertex3D is a point of the Minkowski difference a-b and the support
+// points of a and b it came from.
+type gjkVertex3D struct {
+	w, a, b Vector3D
+}
+
+// minkowskiSupport3D returns the point of the Minkowski difference a-b
+// furthest in direction d.
+func minkowskiSupport3D(a, b Convex3D, d *Vector3D) gjkVertex3D {
+	var v gjkVertex3D
+	v.a = a.Support(*d)
+	v.b = b.Support(Vector3D{-d.X, -d.Y, -d.Z})
+	v.w.Subtract(&v.a, &v.b)
+	return v
+}
+
+// gjkSimplex3D is the simplex GJK maintains, with the barycentric coordinates
+// l of the point on it closest to the origin.
+type gjkSimplex3D struct {
+	n       int
+	w, a, b [4]Vector3D
+	l       [4]float64
+}
+
+// run runs GJK on shapes a and b, then returns the point of the Minkowski
+// difference closest to the origin and true if the shapes overlap or false
+// otherwise. If early is true it stops as soon as the shapes are known to be
+// apart, so the returned point is not the closest.
+func (s *gjkSimplex3D) run(a, b Convex3D, early bool) (Vector3D, bool) {
+	// Gilbert, Johnson, and Keerthi, "A Fast Procedure for Computing the
+	// Distance Between Complex Objects in Three-Dimensional Space", 1988
+	v := minkowskiSupport3D(a, b, &Vector3D{1, 0, 0})
+	s.n = 1
+	s.w[0], s.a[0], s.b[0], s.l[0] = v.w, v.a, v.b, 1
+	c := v.w
+	scale := c.MagnitudeSquared()
+	for iter := 0; iter < gjkMaxIterations; iter++ {
+		cc := c.MagnitudeSquared()
+		if cc <= gjkTolerance*gjkTolerance*scale {
+			return c, true
+		}
+		v = minkowskiSupport3D(a, b, &Vector3D{-c.X, -c.Y, -c.Z})
+		cw := c.DotProduct(&v.w)
+		if early && cw > 0 {
+			return c, false
+		}
+		if cc-cw <= gjkTolerance*cc {
+			return c, false
+		}
+		for i := 0; i < s.n; i++ {
+			if s.w[i] == v.w {
+				return c, false
+			}
+		}
+		prev := *s
+		scale = math.Max(scale, v.w.MagnitudeSquared())
+		s.w[s.n], s.a[s.n], s.b[s.n] = v.w, v.a, v.b
+		s.n++
+		next := s.closest()
+		if s.n == 4 {
+			if s.flat() {
+				// rounding put the support point in the plane of the
+				// triangle, so the origin is not known to be inside
+				*s = prev
+				return c, false
+			}
+			return next, true
+		}
+		if next.MagnitudeSquared() >= cc {
+			// no progress, so c is as close as rounding allows
+			*s = prev
+			return c, false
+		}
+		c = next
+	}
+	return c, false
+}
+
+// closest reduces s to the smallest sub-simplex containing the point of s
+// closest to the origin, sets the barycentric coordinates of the point, then
+// returns it. If the origin is inside a tetrahedron s is left unchanged.
+func (s *gjkSimplex3D) closest() Vector3D {
+	switch s.n {
+	case 2:
+		return s.closestSegment(0, 1)
+	case 3:
+		return s.closestTriangle(0, 1, 2)
+	}
+	// a tetrahedron, the origin is inside unless it is outside one of the faces
+	faces := [4][4]int{{0, 1, 2, 3}, {0, 2, 3, 1}, {0, 3, 1, 2}, {1, 3, 2, 0}}
+	var best gjkSimplex3D
+	var c Vector3D
+	bd := math.Inf(1)
+	inside := true
+	for _, f := range faces {
+		var ab, ac, n, ad Vector3D
+		ab.Subtract(&s.w[f[1]], &s.w[f[0]])
+		ac.Subtract(&s.w[f[2]], &s.w[f[0]])
+		n.CrossProduct(&ab, &ac)
+		ad.Subtract(&s.w[f[3]], &s.w[f[0]])
+		do, dd := -n.DotProduct(&s.w[f[0]]), n.DotProduct(&ad)
+		if !(do*dd < 0 || dd == 0) {
+			continue
+		}
+		inside = false
+		t := *s
+		fc := t.closestTriangle(f[0], f[1], f[2])
+		if d := fc.MagnitudeSquared(); d < bd {
+			best, c, bd = t, fc, d
+		}
+	}
+	if inside {
+		for i := range s.l {
+			s.l[i] = 0
+		}
+		return Vector3D{}
+	}
+	*s = best
+	return c
+}
+
+// flat returns true if the tetrahedron s has a volume that is zero apart from
+// rounding or false otherwise.
+func (s *gjkSimplex3D) flat() bool {
+	var e1, e2, e3, n Vector3D
+	e1.Subtract(&s.w[1], &s.w[0])
+	e2.Subtract(&s.w[2], &s.w[0])
+	e3.Subtract(&s.w[3], &s.w[0])
+	v := n.CrossProduct(&e1, &e2).DotProduct(&e3)
+	return math.Abs(v) <= gjkTolerance*e1.Magnitude()*e2.Magnitude()*e3.Magnitude()
+}
+
+// closestSegment reduces s to the vertices i and j, or one of them, nearest
+// the origin then returns the closest point.
+func (s *gjkSimplex3D) closestSegment(i, j int) Vector3D {
+	var ab Vector3D
+	ab.Subtract(&s.w[j], &s.w[i])
+	t := -s.w[i].DotProduct(&ab) / ab.MagnitudeSquared()
+	if !(t > 0) {
+		return s.keep(i)
+	}
+	if t >= 1 {
+		return s.keep(j)
+	}
+	return s.keep2(i, j, 1-t, t)
+}
+
+// closestTriangle reduces s to the vertices i, j, and k, or some of them,
+// nearest the origin then returns the closest point.
+func (s *gjkSimplex3D) closestTriangle(i, j, k int) Vector3D {
+	// Ericson, Real-Time Collision Detection, 5.1.5
+	a, b, c := &s.w[i], &s.w[j], &s.w[k]
+	var ab, ac Vector3D
+	ab.Subtract(b, a)
+	ac.Subtract(c, a)
+	d1, d2 := -ab.DotProduct(a), -ac.DotProduct(a)
+	if d1 <= 0 && d2 <= 0 {
+		return s.keep(i)
+	}
+	d3, d4 := -ab.DotProduct(b), -ac.DotProduct(b)
+	if d3 >= 0 && d4 <= d3 {
+		return s.keep(j)
+	}
+	vc := d1*d4 - d3*d2
+	if vc <= 0 && d1 >= 0 && d3 <= 0 {
+		t := d1 / (d1 - d3)
+		return s.keep2(i, j, 1-t, t)
+	}
+	d5, d6 := -ab.DotProduct(c), -ac.DotProduct(c)
+	if d6 >= 0 && d5 <= d6 {
+		return s.keep(k)
+	}
+	vb := d5*d2 - d1*d6
+	if vb <= 0 && d2 >= 0 && d6 <= 0 {
+		t := d2 / (d2 - d6)
+		return s.keep2(i, k, 1-t, t)
+	}
+	va := d3*d6 - d5*d4
+	if va <= 0 && d4-d3 >= 0 && d5-d6 >= 0 {
+		t := (d4 - d3) / ((d4 - d3) + (d5 - d6))
+		return s.keep2(j, k, 1-t, t)
+	}
+	d := 1 / (va + vb + vc)
+	v, w := vb*d, vc*d
+	t := gjkSimplex3D{n: 3}
+	for n, x := range [3]int{i, j, k} {
+		t.w[n], t.a[n], t.b[n] = s.w[x], s.a[x], s.b[x]
+	}
+	t.l = [4]float64{1 - v - w, v, w}
+	*s = t
+	return s.point()
+}
+
+// fill adds points of the Minkowski difference of a and b to s until it is a
+// tetrahedron then returns true, or returns false if the difference is flat.
+func (s *gjkSimplex3D) fill(a, b Convex3D) bool {
+	dirs := []Vector3D{{1, 0, 0}, {-1, 0, 0}, {0, 1, 0}, {0, -1, 0}, {0, 0, 1}, {0, 0, -1}}
+	for s.n < 4 {
+		// directions off the current simplex
+		var try []Vector3D
+		switch s.n {
+		case 1:
+			try = dirs
+		case 2:
+			var l Vector3D
+			l.Subtract(&s.w[1], &s.w[0])
+			for i := range dirs {
+				var d Vector3D
+				if d.CrossProduct(&l, &dirs[i]).MagnitudeSquared() > 0 {
+					try = append(try, d)
+				}
+			}
+		case 3:
+			var ab, ac, n Vector3D
+			ab.Subtract(&s.w[1], &s.w[0])
+			ac.Subtract(&s.w[2], &s.w[0])
+			n.CrossProduct(&ab, &ac)
+			try = []Vector3D{n, {-n.X, -n.Y, -n.Z}}
+		}
+		added := false
+		for i := range try {
+			v := minkowskiSupport3D(a, b, &try[i])
+			if s.affinelyIndependent(&v.w) {
+				s.w[s.n], s.a[s.n], s.b[s.n] = v.w, v.a, v.b
+				s.n++
+				added = true
+				break
+			}
+		}
+		if !added {
+			return false
+		}
+	}
+	return true
+}
+
+// affinelyIndependent returns true if adding p to s would not make it
+// degenerate or false otherwise.
+func (s *gjkSimplex3D) affinelyIndependent(p *Vector3D) bool {
+	var d, e, f Vector3D
+	d.Subtract(p, &s.w[0])
+	scale := math.Max(p.MagnitudeSquared(), s.w[0].MagnitudeSquared())
+	switch s.n {
+	case 1:
+		return d.MagnitudeSquared() > gjkTolerance*scale
+	case 2:
+		e.Subtract(&s.w[1], &s.w[0])
+		return f.CrossProduct(&d, &e).MagnitudeSquared() > gjkTolerance*scale*scale
+	}
+	e.Subtract(&s.w[1], &s.w[0])
+	f.Subtract(&s.w[2], &s.w[0])
+	var n Vector3D
+	v := n.CrossProduct(&e, &f).DotProduct(&d)
+	return v*v > gjkTolerance*scale*scale*scale
+}
+
+// keep reduces s to vertex i then returns it.
+func (s *gjkSimplex3D) keep(i int) Vector3D {
+	s.w[0], s.a[0], s.b[0] = s.w[i], s.a[i], s.b[i]
+	s.l = [4]float64{1}
+	s.n = 1
+	return s.w[0]
+}
+
+// keep2 reduces s to vertices i and j with barycentric coordinates u and v
+// then returns the point.
+func (s *gjkSimplex3D) keep2(i, j int, u, v float64) Vector3D {
+	w0, a0, b0 := s.w[i], s.a[i], s.b[i]
+	w1, a1, b1 := s.w[j], s.a[j], s.b[j]
+	s.w[0], s.a[0], s.b[0] = w0, a0, b0
+	s.w[1], s.a[1], s.b[1] = w1, a1, b1
+	s.l = [4]float64{u, v}
+	s.n = 2
+	return s.point()
+}
+
+// point returns the point of s with barycentric coordinates l.
+func (s *gjkSimplex3D) point() Vector3D {
+	var p Vector3D
+	for i := 0; i < s.n; i++ {
+		p.X += s.l[i] * s.w[i].X
+		p.Y += s.l[i] * s.w[i].Y
+		p.Z += s.l[i] * s.w[i].Z
+	}
+	return p
+}
+
+// epaPolytope3D is the convex polytope EPA expands inside the Minkowski
+// difference.
+type epaPolytope3D struct {
+	points []Vector3D
+	faces  []epaFace3D
+	edges  map[[2]int]int // directed edge to the face it belongs to
+}
+
+type epaFace3D struct {
+	v     [3]int
+	n     Vector3D // outward unit normal
+	d     float64  // distance from the origin
+	alive bool
+}
+
+// addFace adds a face with the given vertices counterclockwise from outside.
+func (p *epaPolytope3D) addFace(v [3]int) {
+	f := epaFace3D{v: v, alive: true}
+	var ab, ac Vector3D
+	ab.Subtract(&p.points[v[1]], &p.points[v[0]])
+	ac.Subtract(&p.points[v[2]], &p.points[v[0]])
+	f.n.CrossProduct(&ab, &ac)
+	if m := f.n.Magnitude(); m > 0 {
+		f.n.Scale(&f.n, 1/m)
+		f.d = f.n.DotProduct(&p.points[v[0]])
+	} else {
+		// a sliver with no normal is never the closest face
+		f.d = math.Inf(1)
+	}
+	p.faces = append(p.faces, f)
+	for j := 0; j < 3; j++ {
+		p.edges[[2]int{v[j], v[(j+1)%3]}] = len(p.faces) - 1
+	}
+}
+
+// expand adds point w, which is outside the polytope, replacing the faces it
+// can see, then returns true or returns false if it can see no faces.
+func (p *epaPolytope3D) expand(w Vector3D) bool {
+	var visible []int
+	seen := make(map[int]bool)
+	for i := range p.faces {
+		f := &p.faces[i]
+		var d Vector3D
+		if f.alive && f.n.DotProduct(d.Subtract(&w, &p.points[f.v[0]])) > 0 {
+			visible = append(visible, i)
+			seen[i] = true
+		}
+	}
+	if len(visible) == 0 {
+		return false
+	}
+	p.points = append(p.points, w)
+	eye := len(p.points) - 1
+	var horizon [][2]int
+	for _, i := range visible {
+		p.faces[i].alive = false
+		v := p.faces[i].v
+		for j := 0; j < 3; j++ {
+			a, b := v[j], v[(j+1)%3]
+			if !seen[p.edges[[2]int{b, a}]] {
+				horizon = append(horizon, [2]int{a, b})
+			}
+		}
+	}
+	for _, i := range visible {
+		v := p.faces[i].v
+		for j := 0; j < 3; j++ {
+			e := [2]int{v[j], v[(j+1)%3]}
+			if p.edges[e] == i {
+				delete(p.edges, e)
+			}
+		}
+	}
+	for _, e := range horizon {
+		p.addFace([3]int{e[0], e[1], eye})
+	}
+	return true
+}
@@ -0,0 +1,144 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// gjk3DBox returns an OBB3D with center c and half extents e rotated by angle
+// about axis.
+func gjk3DBox(c Vector3D, e [3]float64, axis Vector3D, angle float64) *OBB3D {
+	return obb3DRotated(c, e, axis, angle)
+}
+
+type gjk3DData struct {
+	a, b  Convex3D
+	dist  float64 // distance if apart
+	depth float64 // penetration depth if overlapping
+	n     Vector3D
+}
+
+var gjk3DValues = []gjk3DData{
+	{&Sphere{Vector3D{0, 0, 0}, 1}, &Sphere{Vector3D{3, 0, 0}, 1}, 1, 0, Vector3D{}},
+	{&Sphere{Vector3D{0, 0, 0}, 1}, &Sphere{Vector3D{1.5, 0, 0}, 1}, 0, 0.5, Vector3D{1, 0, 0}},
+	{&AABB3D{Vector3D{0, 0, 0}, Vector3D{1, 1, 1}}, &AABB3D{Vector3D{2, 0.5, 0.5}, Vector3D{3, 2, 2}}, 1, 0, Vector3D{}},
+	{&AABB3D{Vector3D{0, 0, 0}, Vector3D{2, 2, 2}}, &AABB3D{Vector3D{1.75, 0.5, 0.5}, Vector3D{3, 1.5, 1.5}}, 0, 0.25,
+		Vector3D{1, 0, 0}},
+	{&AABB3D{Vector3D{0, 0, 0}, Vector3D{2, 2, 2}}, &Sphere{Vector3D{1, 1, 3.5}, 1}, 0.5, 0, Vector3D{}},
+	{&AABB3D{Vector3D{0, 0, 0}, Vector3D{2, 2, 2}}, &Sphere{Vector3D{1, 1, 2.5}, 1}, 0, 0.5, Vector3D{0, 0, 1}},
+	{&Capsule3D{Line3D{Vector3D{0, 0, 0}, Vector3D{0, 0, 4}}, 1}, &Sphere{Vector3D{3, 0, 2}, 1}, 1, 0, Vector3D{}},
+	{&Capsule3D{Line3D{Vector3D{0, 0, 0}, Vector3D{0, 0, 4}}, 1}, &Sphere{Vector3D{0, -1.5, 2}, 1}, 0, 0.5,
+		Vector3D{0, -1, 0}},
+	{gjk3DBox(Vector3D{}, [3]float64{1, 1, 1}, Vector3D{0, 0, 1}, math.Pi/4),
+		&Sphere{Vector3D{math.Sqrt2 + 2, 0, 0}, 1}, 1, 0, Vector3D{}},
+	{&Triangle3D{Vector3D{0, 0, 0}, Vector3D{2, 0, 0}, Vector3D{0, 2, 0}}, &Sphere{Vector3D{0.5, 0.5, 3}, 1}, 2, 0,
+		Vector3D{}},
+}
+
+func TestDistance3DConvexConvex(t *testing.T) {
+	for _, v := range gjk3DValues {
+		var y, z Vector3D
+		d := Distance3DConvexConvex(v.a, v.b, &y, &z)
+		if math.Abs(d-v.dist) > 1e-6 {
+			t.Error("Distance3D.ConvexConvex", v.a, v.b, "want", v.dist, "got", d)
+			continue
+		}
+		if d > 0 && math.Abs(Distance3DPointPoint(&y, &z)-d) > 1e-6 {
+			t.Error("Distance3D.ConvexConvex", v.a, v.b, "closest points", y, z, "are not", d, "apart")
+		}
+		if Intersection3DConvexConvex(v.a, v.b) != (v.dist == 0) {
+			t.Error("Intersection3D.ConvexConvex", v.a, v.b, "want", v.dist == 0)
+		}
+	}
+}
+
+func TestDistance3DConvexConvexClosestPoints(t *testing.T) {
+	a := &AABB3D{Vector3D{0, 0, 0}, Vector3D{1, 1, 1}}
+	b := &Sphere{Vector3D{3, 0.5, 0.5}, 1}
+	var y, z Vector3D
+	if d := Distance3DConvexConvex(a, b, &y, &z); math.Abs(d-1) > 1e-6 ||
+		Distance3DPointPoint(&y, &Vector3D{1, 0.5, 0.5}) > 1e-6 || Distance3DPointPoint(&z, &Vector3D{2, 0.5, 0.5}) > 1e-6 {
+		t.Error("Distance3D.ConvexConvex", a, b, "got", d, y, z)
+	}
+	h := &Hull3D{Points: []Vector3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}}}
+	c := &Sphere{Vector3D{2, 2, 2}, 1}
+	want := math.Sqrt(3)*2 - 1/math.Sqrt(3) - 1
+	if d := Distance3DConvexConvex(h, c, &y, &z); math.Abs(d-want) > 1e-6 {
+		t.Error("Distance3D.ConvexConvex", h, c, "want", want, "got", d)
+	}
+}
+
+func TestDistance3DConvexConvexRandom(t *testing.T) {
+	// the distance from a sphere to a box is that from its center less its
+	// radius
+	r := rand.New(rand.NewSource(1))
+	random := func() Vector3D {
+		return Vector3D{r.Float64()*6 - 3, r.Float64()*6 - 3, r.Float64()*6 - 3}
+	}
+	for i := 0; i < 20000; i++ {
+		e := [3]float64{0.1 + r.Float64(), 0.1 + r.Float64(), 0.1 + r.Float64()}
+		var a Convex3D = obb3DRotated(random(), e, random(), r.Float64()*2*math.Pi)
+		if i%2 == 1 {
+			c := random()
+			a = &AABB3D{Vector3D{c.X - e[0], c.Y - e[1], c.Z - e[2]}, Vector3D{c.X + e[0], c.Y + e[1], c.Z + e[2]}}
+		}
+		b := &Sphere{random(), 0.1 + 1.5*r.Float64()}
+		var p Vector3D
+		switch a := a.(type) {
+		case *OBB3D:
+			a.ClosestPoint(&b.C, &p)
+		case *AABB3D:
+			a.ClosestPoint(&b.C, &p)
+		}
+		want := math.Max(0, Distance3DPointPoint(&p, &b.C)-b.R)
+		if math.Abs(want) < 1e-6 {
+			continue
+		}
+		var y, z Vector3D
+		if d := Distance3DConvexConvex(a, b, &y, &z); math.Abs(d-want) > 1e-6 {
+			t.Error("Distance3D.ConvexConvex", a, b, "want", want, "got", d)
+		}
+		if Intersection3DConvexConvex(a, b) != (want == 0) {
+			t.Error("Intersection3D.ConvexConvex", a, b, "want", want == 0)
+		}
+	}
+}
+
+func TestPenetration3DConvexConvex(t *testing.T) {
+	for _, v := range gjk3DValues {
+		var n Vector3D
+		d := Penetration3DConvexConvex(v.a, v.b, &n)
+		if math.Abs(d-v.depth) > 1e-6 || (d > 0 && Distance3DPointPoint(&n, &v.n) > 1e-4) {
+			t.Error("Penetration3D.ConvexConvex", v.a, v.b, "want", v.depth, v.n, "got", d, n)
+		}
+	}
+	// touching boxes share a face
+	a := &AABB3D{Vector3D{0, 0, 0}, Vector3D{1, 1, 1}}
+	b := &AABB3D{Vector3D{1, 0, 0}, Vector3D{2, 1, 1}}
+	var n Vector3D
+	if !Intersection3DConvexConvex(a, b) {
+		t.Error("Intersection3D.ConvexConvex", a, b, "want", true)
+	}
+	if d := Penetration3DConvexConvex(a, b, &n); d > 1e-12 {
+		t.Error("Penetration3D.ConvexConvex", a, b, "want", 0, "got", d)
+	}
+}
+
+func Benchmark_Distance3D_ConvexConvex(b *testing.B) {
+	x := gjk3DBox(Vector3D{}, [3]float64{1, 1, 1}, Vector3D{0, 0, 1}, math.Pi/4)
+	y := &Capsule3D{Line3D{Vector3D{3, 0, 0}, Vector3D{0, 0, 4}}, 1}
+	var p, q Vector3D
+	for i := 0; i < b.N; i++ {
+		Distance3DConvexConvex(x, y, &p, &q)
+	}
+}
+
+func Benchmark_Penetration3D_ConvexConvex(b *testing.B) {
+	x := &AABB3D{Vector3D{0, 0, 0}, Vector3D{2, 2, 2}}
+	y := gjk3DBox(Vector3D{2.5, 1, 1}, [3]float64{1, 1, 1}, Vector3D{0, 0, 1}, math.Pi/4)
+	var n Vector3D
+	for i := 0; i < b.N; i++ {
+		Penetration3DConvexConvex(x, y, &n)
+	}
+}
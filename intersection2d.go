@@ -1,5 +1,115 @@
 package geometry
 
+import (
+	"math"
+)
+
+// Intersection2DCircleCircle sets y and z to the possible intersections of
+// circles a and b and returns the number of intersections.
+//
+// Possible return values are:
+// -1 if the circles are coincident, y and z are untouched.
+// 0 if the circles do not intersect, including one inside the other, y and z
+// are untouched.
+// 1 if the circles touch, y is set and z is untouched.
+// 2 if the circles intersect, y and z are set to the two intersection points
+// with y on the left of the line from a.C to b.C.
+func Intersection2DCircleCircle(a, b *Circle, y, z *Vector2D) int {
+	return Intersect2DCircleCircle(a, b, y, z).code()
+}
+
+// Intersect2DCircleCircle is like Intersection2DCircleCircle but returns the
+// kind of intersection.
+//
+// Possible return values are:
+// IntersectionCoincident if the circles are coincident, y and z are untouched.
+// IntersectionNone if the circles do not intersect, including one inside the
+// other, y and z are untouched.
+// IntersectionPoint if the circles touch, y is set and z is untouched.
+// IntersectionTwoPoints if the circles intersect, y and z are set to the two
+// intersection points with y on the left of the line from a.C to b.C.
+func Intersect2DCircleCircle(a, b *Circle, y, z *Vector2D) IntersectionKind {
+	return intersection2DCircleCircle(a, b, nil, y, z)
+}
+
+// Intersection2DFuzzyCircleCircle is like Intersection2DCircleCircle but
+// circles very close to touching are treated as touching and circles very
+// close to each other are treated as coincident.
+//
+// Possible return values are:
+// -1 if the circles are coincident, y and z are untouched.
+// 0 if the circles do not intersect, including one inside the other, y and z
+// are untouched.
+// 1 if the circles touch, y is set and z is untouched.
+// 2 if the circles intersect, y and z are set to the two intersection points
+// with y on the left of the line from a.C to b.C.
+func Intersection2DFuzzyCircleCircle(a, b *Circle, y, z *Vector2D) int {
+	return Intersect2DFuzzyCircleCircle(a, b, y, z).code()
+}
+
+// Intersect2DFuzzyCircleCircle is like Intersection2DFuzzyCircleCircle but
+// returns the kind of intersection.
+//
+// Possible return values are:
+// IntersectionCoincident if the circles are coincident, y and z are untouched.
+// IntersectionNone if the circles do not intersect, including one inside the
+// other, y and z are untouched.
+// IntersectionPoint if the circles touch, y is set and z is untouched.
+// IntersectionTwoPoints if the circles intersect, y and z are set to the two
+// intersection points with y on the left of the line from a.C to b.C.
+func Intersect2DFuzzyCircleCircle(a, b *Circle, y, z *Vector2D) IntersectionKind {
+	return DefaultTolerance.Intersect2DCircleCircle(a, b, y, z)
+}
+
+// Intersection2DCircleCircle is like Intersection2DFuzzyCircleCircle but
+// compares with tolerance t.
+func (t *Tolerance) Intersection2DCircleCircle(a, b *Circle, y, z *Vector2D) int {
+	return t.Intersect2DCircleCircle(a, b, y, z).code()
+}
+
+// Intersect2DCircleCircle is like Tolerance.Intersection2DCircleCircle but
+// returns the kind of intersection.
+func (t *Tolerance) Intersect2DCircleCircle(a, b *Circle, y, z *Vector2D) IntersectionKind {
+	return intersection2DCircleCircle(a, b, t, y, z)
+}
+
+// Intersection2DFuzzyLineCircle is like Intersection2DLineCircle but a line
+// very close to being a tangent of the circle is treated as a tangent.
+//
+// Possible return values are:
+// 0 for no intersections, y and z are untouched.
+// 1 if the line is a tangent of the circle, y is set and z is untouched.
+// 2 if the line intersects the circle, y and z are set to the two
+// intersection points in order along a.
+func Intersection2DFuzzyLineCircle(a *Line2D, b *Circle, y, z *Vector2D) int {
+	return Intersect2DFuzzyLineCircle(a, b, y, z).code()
+}
+
+// Intersect2DFuzzyLineCircle is like Intersection2DFuzzyLineCircle but returns
+// the kind of intersection.
+//
+// Possible return values are:
+// IntersectionNone for no intersections, y and z are untouched.
+// IntersectionPoint if the line is a tangent of the circle, y is set and z is
+// untouched.
+// IntersectionTwoPoints if the line intersects the circle, y and z are set to
+// the two intersection points in order along a.
+func Intersect2DFuzzyLineCircle(a *Line2D, b *Circle, y, z *Vector2D) IntersectionKind {
+	return DefaultTolerance.Intersect2DLineCircle(a, b, y, z)
+}
+
+// Intersection2DLineCircle is like Intersection2DFuzzyLineCircle but compares
+// with tolerance t.
+func (t *Tolerance) Intersection2DLineCircle(a *Line2D, b *Circle, y, z *Vector2D) int {
+	return t.Intersect2DLineCircle(a, b, y, z).code()
+}
+
+// Intersect2DLineCircle is like Tolerance.Intersection2DLineCircle but returns
+// the kind of intersection.
+func (t *Tolerance) Intersect2DLineCircle(a *Line2D, b *Circle, y, z *Vector2D) IntersectionKind {
+	return intersection2DLineCircle(a, b, math.Inf(-1), math.Inf(1), t, y, z)
+}
+
 // Intersection2DFuzzyLineLine sets point z to the intersection of a and b then
 // returns the number of intersections.
 //
@@ -8,19 +118,83 @@ package geometry
 // 0 if the lines are parallel, z is untouched.
 // 1 otherwise, z is set to the intersection of the two lines.
 func Intersection2DFuzzyLineLine(a, b *Line2D, z *Vector2D) int {
+	return Intersect2DFuzzyLineLine(a, b, z).code()
+}
+
+// Intersect2DFuzzyLineLine is like Intersection2DFuzzyLineLine but returns the
+// kind of intersection.
+//
+// Possible return values are:
+// IntersectionCoincident if the lines are coincident, z is untouched.
+// IntersectionParallel if the lines are parallel, z is untouched.
+// IntersectionPoint otherwise, z is set to the intersection of the two lines.
+func Intersect2DFuzzyLineLine(a, b *Line2D, z *Vector2D) IntersectionKind {
+	return DefaultTolerance.Intersect2DLineLine(a, b, z)
+}
+
+// Intersection2DLineLine is like Intersection2DFuzzyLineLine but compares with
+// tolerance t.
+func (t *Tolerance) Intersection2DLineLine(a, b *Line2D, z *Vector2D) int {
+	return t.Intersect2DLineLine(a, b, z).code()
+}
+
+// Intersect2DLineLine is like Tolerance.Intersection2DLineLine but returns the
+// kind of intersection.
+func (t *Tolerance) Intersect2DLineLine(a, b *Line2D, z *Vector2D) IntersectionKind {
 	// http://local.wasp.uwa.edu.au/~pbourke/geometry/lineline2d/
 	d := b.V.Y*a.V.X - b.V.X*a.V.Y
-	if FuzzyEqual(d, 0) {
+	if t.Equal(d, 0) {
 		am, bm := a.V.Y/a.V.X, b.V.Y/b.V.X
-		if FuzzyEqual(a.P.Y-am*a.P.X, b.P.Y-bm*b.P.X) {
-			return -1
+		if t.Equal(a.P.Y-am*a.P.X, b.P.Y-bm*b.P.X) {
+			return IntersectionCoincident
 		}
-		return 0
+		return IntersectionParallel
 	}
 	ua := (b.V.X*(a.P.Y-b.P.Y) - b.V.Y*(a.P.X-b.P.X)) / d
 	z.X = a.P.X + ua*a.V.X
 	z.Y = a.P.Y + ua*a.V.Y
-	return 1
+	return IntersectionPoint
+}
+
+// Intersection2DFuzzyLineSegmentCircle is like
+// Intersection2DLineSegmentCircle but intersections very close to the ends of
+// the line segment are counted and a line segment very close to being a
+// tangent of the circle is treated as a tangent.
+//
+// Possible return values are:
+// 0 for no intersections, y and z are untouched.
+// 1 if the line segment touches or crosses the circle once, y is set and z is
+// untouched.
+// 2 if the line segment crosses the circle twice, y and z are set to the two
+// intersection points in order along a.
+func Intersection2DFuzzyLineSegmentCircle(a *Line2D, b *Circle, y, z *Vector2D) int {
+	return Intersect2DFuzzyLineSegmentCircle(a, b, y, z).code()
+}
+
+// Intersect2DFuzzyLineSegmentCircle is like
+// Intersection2DFuzzyLineSegmentCircle but returns the kind of intersection.
+//
+// Possible return values are:
+// IntersectionNone for no intersections, y and z are untouched.
+// IntersectionPoint if the line segment touches or crosses the circle once, y
+// is set and z is untouched.
+// IntersectionTwoPoints if the line segment crosses the circle twice, y and z
+// are set to the two intersection points in order along a.
+func Intersect2DFuzzyLineSegmentCircle(a *Line2D, b *Circle, y, z *Vector2D) IntersectionKind {
+	return DefaultTolerance.Intersect2DLineSegmentCircle(a, b, y, z)
+}
+
+// Intersection2DLineSegmentCircle is like Intersection2DFuzzyLineSegmentCircle
+// but compares with tolerance t.
+func (t *Tolerance) Intersection2DLineSegmentCircle(a *Line2D, b *Circle, y, z *Vector2D) int {
+	return t.Intersect2DLineSegmentCircle(a, b, y, z).code()
+}
+
+// Intersect2DLineSegmentCircle is like
+// Tolerance.Intersection2DLineSegmentCircle but returns the kind of
+// intersection.
+func (t *Tolerance) Intersect2DLineSegmentCircle(a *Line2D, b *Circle, y, z *Vector2D) IntersectionKind {
+	return intersection2DLineCircle(a, b, 0, 1, t, y, z)
 }
 
 // Intersection2DFuzzyLineSegmentLineSegment determines the intersection of two
@@ -32,28 +206,58 @@ func Intersection2DFuzzyLineLine(a, b *Line2D, z *Vector2D) int {
 // 1 if the intersection occures on both line segments, z is set to the
 // intersection.
 func Intersection2DFuzzyLineSegmentLineSegment(a, b *Line2D, z *Vector2D) int {
+	return Intersect2DFuzzyLineSegmentLineSegment(a, b, z).code()
+}
+
+// Intersect2DFuzzyLineSegmentLineSegment is like
+// Intersection2DFuzzyLineSegmentLineSegment but returns the kind of
+// intersection.
+//
+// Possible return values are:
+// IntersectionCoincident if part of the line segments are coincident, z is
+// untouched.
+// IntersectionParallel if the line segments are parallel and apart, z is
+// untouched.
+// IntersectionNone if the intersection does not occure on both line segments,
+// z is untouched.
+// IntersectionPoint if the intersection occures on both line segments, z is
+// set to the intersection.
+func Intersect2DFuzzyLineSegmentLineSegment(a, b *Line2D, z *Vector2D) IntersectionKind {
+	return DefaultTolerance.Intersect2DLineSegmentLineSegment(a, b, z)
+}
+
+// Intersection2DLineSegmentLineSegment is like
+// Intersection2DFuzzyLineSegmentLineSegment but compares with tolerance t.
+func (t *Tolerance) Intersection2DLineSegmentLineSegment(a, b *Line2D, z *Vector2D) int {
+	return t.Intersect2DLineSegmentLineSegment(a, b, z).code()
+}
+
+// Intersect2DLineSegmentLineSegment is like
+// Tolerance.Intersection2DLineSegmentLineSegment but returns the kind of
+// intersection.
+func (t *Tolerance) Intersect2DLineSegmentLineSegment(a, b *Line2D, z *Vector2D) IntersectionKind {
 	d := (b.V.Y*a.V.X - b.V.X*a.V.Y)
-	if FuzzyEqual(d, 0) {
+	if t.Equal(d, 0) {
 		// slopes are the same, parallel or coincident
 		am, bm := a.V.Y/a.V.X, b.V.Y/b.V.X
-		if !FuzzyEqual(a.P.Y-am*a.P.X, b.P.Y-bm*b.P.X) {
+		if !t.Equal(a.P.Y-am*a.P.X, b.P.Y-bm*b.P.X) {
 			// parallel
-			return 0
+			return IntersectionParallel
 		}
 		// check if endpoints are equal
 		bp2x, bp2y := b.P.X+b.V.X, b.P.Y+b.V.Y
-		if (FuzzyEqual(a.P.X, b.P.X) && FuzzyEqual(a.P.Y, b.P.Y)) ||
-			(FuzzyEqual(a.P.X, bp2x) && FuzzyEqual(a.P.Y, bp2y)) {
+		if (t.Equal(a.P.X, b.P.X) && t.Equal(a.P.Y, b.P.Y)) ||
+			(t.Equal(a.P.X, bp2x) && t.Equal(a.P.Y, bp2y)) {
 			z.X = a.P.X
 			z.Y = a.P.Y
-			return 1
+			return IntersectionPoint
 		}
 		ap2x, ap2y := a.P.X+a.V.X, a.P.Y+a.V.Y
-		if (FuzzyEqual(ap2x, b.P.X) && FuzzyEqual(ap2y, b.P.Y)) ||
-			(FuzzyEqual(ap2x, bp2x) && FuzzyEqual(ap2y, bp2y)) {
+		if (t.Equal(ap2x, b.P.X) && t.Equal(ap2y, b.P.Y)) ||
+			(t.Equal(ap2x, bp2x) && t.Equal(ap2y, bp2y)) {
 			z.X = ap2x
 			z.Y = ap2y
-			return 1
+			return IntersectionPoint
 		}
 		// check for overlap
 		var x1, x2 float64
@@ -63,10 +267,10 @@ func Intersection2DFuzzyLineSegmentLineSegment(a, b *Line2D, z *Vector2D) int {
 			x1, x2 = ap2x, a.P.X
 		}
 		if (x1 < b.P.X && b.P.X < x2) || (x1 < bp2x && bp2x < x2) {
-			return -1
+			return IntersectionCoincident
 		}
 		// coincident if they were lines
-		return 0
+		return IntersectionNone
 	}
 	dx, dy := a.P.X-b.P.X, a.P.Y-b.P.Y
 	d = 1 / d
@@ -75,17 +279,394 @@ func Intersection2DFuzzyLineSegmentLineSegment(a, b *Line2D, z *Vector2D) int {
 	if 0 <= ua && ua <= 1 && 0 <= ub && ub <= 1 {
 		z.X = a.P.X + ua*a.V.X
 		z.Y = a.P.Y + ua*a.V.Y
-		return 1
+		return IntersectionPoint
 	}
-	return 0
+	return IntersectionNone
+}
+
+// Intersection2DFuzzyRayCircle is like Intersection2DRayCircle but
+// intersections very close to the origin of the ray are counted and a ray very
+// close to being a tangent of the circle is treated as a tangent.
+//
+// Possible return values are:
+// 0 for no intersections, y and z are untouched.
+// 1 if the ray touches or crosses the circle once, y is set and z is
+// untouched.
+// 2 if the ray crosses the circle twice, y and z are set to the two
+// intersection points in order along a.
+func Intersection2DFuzzyRayCircle(a *Line2D, b *Circle, y, z *Vector2D) int {
+	return Intersect2DFuzzyRayCircle(a, b, y, z).code()
+}
+
+// Intersect2DFuzzyRayCircle is like Intersection2DFuzzyRayCircle but returns
+// the kind of intersection.
+//
+// Possible return values are:
+// IntersectionNone for no intersections, y and z are untouched.
+// IntersectionPoint if the ray touches or crosses the circle once, y is set
+// and z is untouched.
+// IntersectionTwoPoints if the ray crosses the circle twice, y and z are set
+// to the two intersection points in order along a.
+func Intersect2DFuzzyRayCircle(a *Line2D, b *Circle, y, z *Vector2D) IntersectionKind {
+	return DefaultTolerance.Intersect2DRayCircle(a, b, y, z)
+}
+
+// Intersection2DRayCircle is like Intersection2DFuzzyRayCircle but compares
+// with tolerance t.
+func (t *Tolerance) Intersection2DRayCircle(a *Line2D, b *Circle, y, z *Vector2D) int {
+	return t.Intersect2DRayCircle(a, b, y, z).code()
+}
+
+// Intersect2DRayCircle is like Tolerance.Intersection2DRayCircle but returns
+// the kind of intersection.
+func (t *Tolerance) Intersect2DRayCircle(a *Line2D, b *Circle, y, z *Vector2D) IntersectionKind {
+	return intersection2DLineCircle(a, b, 0, math.Inf(1), t, y, z)
+}
+
+// Intersection2DLineCircle sets y and z to the possible intersections of line
+// a with circle b and returns the number of intersections.
+//
+// Possible return values are:
+// 0 for no intersections, y and z are untouched.
+// 1 if the line is a tangent of the circle, y is set and z is untouched.
+// 2 if the line intersects the circle, y and z are set to the two
+// intersection points in order along a.
+func Intersection2DLineCircle(a *Line2D, b *Circle, y, z *Vector2D) int {
+	return Intersect2DLineCircle(a, b, y, z).code()
+}
+
+// Intersect2DLineCircle is like Intersection2DLineCircle but returns the kind
+// of intersection.
+//
+// Possible return values are:
+// IntersectionNone for no intersections, y and z are untouched.
+// IntersectionPoint if the line is a tangent of the circle, y is set and z is
+// untouched.
+// IntersectionTwoPoints if the line intersects the circle, y and z are set to
+// the two intersection points in order along a.
+func Intersect2DLineCircle(a *Line2D, b *Circle, y, z *Vector2D) IntersectionKind {
+	return intersection2DLineCircle(a, b, math.Inf(-1), math.Inf(1), nil, y, z)
 }
 
 // Intersection2DLineLine sets point z to the intersection of a and b and
 // returns 1.
 func Intersection2DLineLine(a, b *Line2D, z *Vector2D) int {
+	return Intersect2DLineLine(a, b, z).code()
+}
+
+// Intersect2DLineLine is like Intersection2DLineLine but returns the kind of
+// intersection, always IntersectionPoint.
+func Intersect2DLineLine(a, b *Line2D, z *Vector2D) IntersectionKind {
 	// http://local.wasp.uwa.edu.au/~pbourke/geometry/lineline2d/
 	ua := (b.V.X*(a.P.Y-b.P.Y) - b.V.Y*(a.P.X-b.P.X)) / (b.V.Y*a.V.X - b.V.X*a.V.Y)
 	z.X = a.P.X + ua*a.V.X
 	z.Y = a.P.Y + ua*a.V.Y
-	return 1
+	return IntersectionPoint
+}
+
+// Intersection2DLineSegmentAABB determines the intersection of line segment a
+// with box b, then returns the number of intersections along with the
+// parameters t0 and t1, both in [0, 1], at which a enters and leaves b. If a
+// starts inside b t0 is 0 and if it ends inside b t1 is 1.
+//
+// Possible return values are:
+// 0 if there is no intersection, t0 and t1 are 0.
+// 1 if there is an intersection.
+func Intersection2DLineSegmentAABB(a *Line2D, b *AABB2D) (n int, t0, t1 float64) {
+	k, t0, t1 := Intersect2DLineSegmentAABB(a, b)
+	return k.code(), t0, t1
+}
+
+// Intersect2DLineSegmentAABB is like Intersection2DLineSegmentAABB but returns
+// the kind of intersection.
+//
+// Possible return values are:
+// IntersectionNone if there is no intersection, t0 and t1 are 0.
+// IntersectionSegment if there is an intersection.
+func Intersect2DLineSegmentAABB(a *Line2D, b *AABB2D) (n IntersectionKind, t0, t1 float64) {
+	t0, t1 = 0, 1
+	if !aabbSlab(a.P.X, a.V.X, b.Min.X, b.Max.X, &t0, &t1) ||
+		!aabbSlab(a.P.Y, a.V.Y, b.Min.Y, b.Max.Y, &t0, &t1) {
+		return IntersectionNone, 0, 0
+	}
+	return IntersectionSegment, t0, t1
+}
+
+// Intersection2DLineSegmentCircle sets y and z to the possible intersections
+// of line segment a with circle b and returns the number of intersections.
+//
+// Possible return values are:
+// 0 for no intersections, y and z are untouched.
+// 1 if the line segment touches or crosses the circle once, y is set and z is
+// untouched.
+// 2 if the line segment crosses the circle twice, y and z are set to the two
+// intersection points in order along a.
+func Intersection2DLineSegmentCircle(a *Line2D, b *Circle, y, z *Vector2D) int {
+	return Intersect2DLineSegmentCircle(a, b, y, z).code()
+}
+
+// Intersect2DLineSegmentCircle is like Intersection2DLineSegmentCircle but
+// returns the kind of intersection.
+//
+// Possible return values are:
+// IntersectionNone for no intersections, y and z are untouched.
+// IntersectionPoint if the line segment touches or crosses the circle once, y
+// is set and z is untouched.
+// IntersectionTwoPoints if the line segment crosses the circle twice, y and z
+// are set to the two intersection points in order along a.
+func Intersect2DLineSegmentCircle(a *Line2D, b *Circle, y, z *Vector2D) IntersectionKind {
+	return intersection2DLineCircle(a, b, 0, 1, nil, y, z)
+}
+
+// Intersection2DRayAABB determines the intersection of ray a with box b, then
+// returns the number of intersections along with the distances t0 and t1, in
+// multiples of a.V, at which the ray enters and leaves b. If the ray starts
+// inside b t0 is 0.
+//
+// Possible return values are:
+// 0 if there is no intersection, t0 and t1 are 0.
+// 1 if there is an intersection.
+func Intersection2DRayAABB(a *Line2D, b *AABB2D) (n int, t0, t1 float64) {
+	k, t0, t1 := Intersect2DRayAABB(a, b)
+	return k.code(), t0, t1
+}
+
+// Intersect2DRayAABB is like Intersection2DRayAABB but returns the kind of
+// intersection.
+//
+// Possible return values are:
+// IntersectionNone if there is no intersection, t0 and t1 are 0.
+// IntersectionSegment if there is an intersection.
+func Intersect2DRayAABB(a *Line2D, b *AABB2D) (n IntersectionKind, t0, t1 float64) {
+	t0, t1 = 0, math.Inf(1)
+	if !aabbSlab(a.P.X, a.V.X, b.Min.X, b.Max.X, &t0, &t1) ||
+		!aabbSlab(a.P.Y, a.V.Y, b.Min.Y, b.Max.Y, &t0, &t1) {
+		return IntersectionNone, 0, 0
+	}
+	return IntersectionSegment, t0, t1
+}
+
+// Intersection2DRayCircle sets y and z to the possible intersections of ray a
+// with circle b and returns the number of intersections.
+//
+// Possible return values are:
+// 0 for no intersections, y and z are untouched.
+// 1 if the ray touches or crosses the circle once, y is set and z is
+// untouched.
+// 2 if the ray crosses the circle twice, y and z are set to the two
+// intersection points in order along a.
+func Intersection2DRayCircle(a *Line2D, b *Circle, y, z *Vector2D) int {
+	return Intersect2DRayCircle(a, b, y, z).code()
+}
+
+// Intersect2DRayCircle is like Intersection2DRayCircle but returns the kind of
+// intersection.
+//
+// Possible return values are:
+// IntersectionNone for no intersections, y and z are untouched.
+// IntersectionPoint if the ray touches or crosses the circle once, y is set
+// and z is untouched.
+// IntersectionTwoPoints if the ray crosses the circle twice, y and z are set
+// to the two intersection points in order along a.
+func Intersect2DRayCircle(a *Line2D, b *Circle, y, z *Vector2D) IntersectionKind {
+	return intersection2DLineCircle(a, b, 0, math.Inf(1), nil, y, z)
+}
+
+// Intersection2DRobustLineSegmentLineSegment is like
+// Intersection2DFuzzyLineSegmentLineSegment but decides how the line segments
+// meet with Orient2D, so the result is always correct for the end points P
+// and P+V as computed in float64. Only the position of a crossing point is
+// subject to rounding.
+//
+// Possible return values are:
+// -1 if part of the line segments are coincident, z is untouched.
+// 0 if the line segments do not meet, z is untouched.
+// 1 if the line segments meet at one point, z is set to the point, exactly
+// when it is an end point.
+func Intersection2DRobustLineSegmentLineSegment(a, b *Line2D, z *Vector2D) int {
+	return Intersect2DRobustLineSegmentLineSegment(a, b, z).code()
+}
+
+// Intersect2DRobustLineSegmentLineSegment is like
+// Intersection2DRobustLineSegmentLineSegment but returns the kind of
+// intersection.
+//
+// Possible return values are:
+// IntersectionCoincident if part of the line segments are coincident, z is
+// untouched.
+// IntersectionNone if the line segments do not meet, z is untouched.
+// IntersectionPoint if the line segments meet at one point, z is set to the
+// point, exactly when it is an end point.
+func Intersect2DRobustLineSegmentLineSegment(a, b *Line2D, z *Vector2D) IntersectionKind {
+	var a1, b1 Vector2D
+	a1.Add(&a.P, &a.V)
+	b1.Add(&b.P, &b.V)
+	return intersection2DLineSegments(&a.P, &a1, &b.P, &b1, z)
+}
+
+// intersection2DCircleCircle implements Intersection2DCircleCircle and
+// Tolerance.Intersection2DCircleCircle.
+func intersection2DCircleCircle(a, b *Circle, tol *Tolerance, y, z *Vector2D) IntersectionKind {
+	// http://paulbourke.net/geometry/circlesphere/
+	dx, dy := b.C.X-a.C.X, b.C.Y-a.C.Y
+	d := math.Sqrt(dx*dx + dy*dy)
+	if tol != nil {
+		if tol.CircleEqual(a, b) {
+			return IntersectionCoincident
+		}
+	} else if d == 0 && a.R == b.R {
+		return IntersectionCoincident
+	}
+	sum, diff := a.R+b.R, math.Abs(a.R-b.R)
+	tangent := d == sum || d == diff
+	if tol != nil {
+		tangent = tol.Equal(d, sum) || (d != 0 && tol.Equal(d, diff))
+	}
+	if !tangent && (d > sum || d < diff || d == 0) {
+		return IntersectionNone
+	}
+	// distance from a.C to the chord through the intersections
+	m := (a.R*a.R - b.R*b.R + d*d) / (2 * d)
+	px, py := a.C.X+m*dx/d, a.C.Y+m*dy/d
+	if tangent {
+		y.X, y.Y = px, py
+		return IntersectionPoint
+	}
+	// nearly tangent circles can round m to more than a.R
+	h := math.Sqrt(math.Max(0, a.R*a.R-m*m)) / d
+	y.X, y.Y = px-h*dy, py+h*dx
+	z.X, z.Y = px+h*dy, py-h*dx
+	return IntersectionTwoPoints
+}
+
+// intersection2DLineCircle sets y and z to the intersections of line a with
+// circle b whose line parameters are between lo and hi, then returns the kind
+// of intersection.
+func intersection2DLineCircle(a *Line2D, b *Circle, lo, hi float64, tol *Tolerance, y, z *Vector2D) IntersectionKind {
+	// solve |P + uV - C|^2 = R^2 about the point on the line closest to C
+	fx, fy := a.P.X-b.C.X, a.P.Y-b.C.Y
+	vv := a.V.X*a.V.X + a.V.Y*a.V.Y
+	u := -(fx*a.V.X + fy*a.V.Y) / vv
+	hx, hy := fx+u*a.V.X, fy+u*a.V.Y
+	h := math.Sqrt(hx*hx + hy*hy)
+	between := func(t float64) bool {
+		if tol != nil {
+			return tol.between(t, lo, hi)
+		}
+		return lo <= t && t <= hi
+	}
+	if h == b.R || (tol != nil && tol.Equal(h, b.R)) {
+		if !between(u) {
+			return IntersectionNone
+		}
+		y.X, y.Y = a.P.X+u*a.V.X, a.P.Y+u*a.V.Y
+		return IntersectionPoint
+	}
+	if h > b.R {
+		return IntersectionNone
+	}
+	s := math.Sqrt(math.Max(0, (b.R-h)*(b.R+h)/vv))
+	k := IntersectionNone
+	for _, t := range [2]float64{u - s, u + s} {
+		if !between(t) {
+			continue
+		}
+		p := y
+		if k == IntersectionPoint {
+			p = z
+		}
+		p.X, p.Y = a.P.X+t*a.V.X, a.P.Y+t*a.V.Y
+		if k == IntersectionNone {
+			k = IntersectionPoint
+		} else {
+			k = IntersectionTwoPoints
+		}
+	}
+	return k
+}
+
+// intersection2DLineSegments intersects line segments a0 a1 and b0 b1 like
+// Intersect2DRobustLineSegmentLineSegment, for callers that have the end
+// points rather than a Line2D.
+func intersection2DLineSegments(a0, a1, b0, b1, z *Vector2D) IntersectionKind {
+	o1, o2 := Orient2D(a0, a1, b0), Orient2D(a0, a1, b1)
+	o3, o4 := Orient2D(b0, b1, a0), Orient2D(b0, b1, a1)
+	if o1 == 0 && o2 == 0 && o3 == 0 && o4 == 0 {
+		return intersection2DCollinearLineSegments(a0, a1, b0, b1, z)
+	}
+	if o1*o2 > 0 || o3*o4 > 0 {
+		return IntersectionNone
+	}
+	switch {
+	case o1 == 0:
+		*z = *b0
+	case o2 == 0:
+		*z = *b1
+	case o3 == 0:
+		*z = *a0
+	case o4 == 0:
+		*z = *a1
+	default:
+		// a proper crossing, solved exactly if the segments are too close to
+		// parallel for the floating point solution
+		ax, ay := a1.X-a0.X, a1.Y-a0.Y
+		bx, by := b1.X-b0.X, b1.Y-b0.Y
+		u := (bx*(a0.Y-b0.Y) - by*(a0.X-b0.X)) / (by*ax - bx*ay)
+		if math.IsNaN(u) || math.IsInf(u, 0) {
+			intersection2DLineSegmentsExact(a0, a1, b0, b1, z)
+			break
+		}
+		u = math.Max(0, math.Min(u, 1))
+		z.X = a0.X + u*ax
+		z.Y = a0.Y + u*ay
+	}
+	return IntersectionPoint
+}
+
+// intersection2DLineSegmentsExact sets z to the crossing of line segments a0
+// a1 and b0 b1, which must not be parallel, found exactly then rounded.
+func intersection2DLineSegmentsExact(a0, a1, b0, b1, z *Vector2D) {
+	ax, ay := ratSub(ratFloat(a1.X), ratFloat(a0.X)), ratSub(ratFloat(a1.Y), ratFloat(a0.Y))
+	bx, by := ratSub(ratFloat(b1.X), ratFloat(b0.X)), ratSub(ratFloat(b1.Y), ratFloat(b0.Y))
+	dx, dy := ratSub(ratFloat(a0.X), ratFloat(b0.X)), ratSub(ratFloat(a0.Y), ratFloat(b0.Y))
+	u := ratSub(ratMul(bx, dy), ratMul(by, dx))
+	u.Quo(u, ratSub(ratMul(by, ax), ratMul(bx, ay)))
+	z.X, _ = ratAdd(ratFloat(a0.X), ratMul(u, ax)).Float64()
+	z.Y, _ = ratAdd(ratFloat(a0.Y), ratMul(u, ay)).Float64()
+}
+
+// intersection2DCollinearLineSegments intersects line segments a0 a1 and b0
+// b1, all on one line, like Intersection2DRobustLineSegmentLineSegment.
+func intersection2DCollinearLineSegments(a0, a1, b0, b1, z *Vector2D) IntersectionKind {
+	if *a0 == *a1 && *b0 == *b1 {
+		// two points are always collinear
+		if *a0 != *b0 {
+			return IntersectionNone
+		}
+		*z = *a0
+		return IntersectionPoint
+	}
+	// compare along the axis the line is closest to
+	p := [4]*Vector2D{a0, a1, b0, b1}
+	var bounds AABB2D
+	bounds.FromPoints(*a0, *a1, *b0, *b1)
+	c := func(v *Vector2D) float64 { return v.X }
+	if bounds.Max.Y-bounds.Min.Y > bounds.Max.X-bounds.Min.X {
+		c = func(v *Vector2D) float64 { return v.Y }
+	}
+	lo := math.Max(math.Min(c(p[0]), c(p[1])), math.Min(c(p[2]), c(p[3])))
+	hi := math.Min(math.Max(c(p[0]), c(p[1])), math.Max(c(p[2]), c(p[3])))
+	if lo > hi {
+		return IntersectionNone
+	}
+	if lo < hi {
+		return IntersectionCoincident
+	}
+	for _, v := range p {
+		if c(v) == lo {
+			*z = *v
+			break
+		}
+	}
+	return IntersectionPoint
 }
@@ -2,6 +2,7 @@ package geometry
 
 import (
 	"math"
+	"math/rand"
 	"testing"
 )
 
@@ -150,6 +151,52 @@ func TestIntersection2DFuzzyLineSegmentLineSegment(t *testing.T) {
 	}
 }
 
+func testIntersection2DRobustLineSegmentLineSegment(d intersection2DFuzzyLineSegmentLineSegmentData, t *testing.T) {
+	var p Vector2D
+	if n := Intersection2DRobustLineSegmentLineSegment(&d.l1, &d.l2, &p); n != d.n ||
+		(d.n == 1 && !p.Equal(&d.p)) {
+		t.Error("Intersection2D.RobustLineSegmentLineSegment", d.l1, d.l2, "want", d.n, d.p, "got", n, p)
+	}
+}
+
+func TestIntersection2DRobustLineSegmentLineSegment(t *testing.T) {
+	values := append([]intersection2DFuzzyLineSegmentLineSegmentData{
+		// touch at an end point in the middle of the other
+		{Line2D{Vector2D{0, 0}, Vector2D{2, 0}}, Line2D{Vector2D{1, 0}, Vector2D{1, 3}}, Vector2D{1, 0}, 1},
+		// collinear and touching end to end on a vertical line
+		{Line2D{Vector2D{1, 0}, Vector2D{0, 2}}, Line2D{Vector2D{1, 2}, Vector2D{0, 2}}, Vector2D{1, 2}, 1},
+		// a point on a line segment, and not
+		{Line2D{Vector2D{1, 1}, Vector2D{0, 0}}, Line2D{Vector2D{0, 0}, Vector2D{2, 2}}, Vector2D{1, 1}, 1},
+		{Line2D{Vector2D{1, 1}, Vector2D{0, 0}}, Line2D{Vector2D{0, 0}, Vector2D{2, 2.5}}, Vector2D{}, 0},
+		// two points
+		{Line2D{Vector2D{1, 1}, Vector2D{0, 0}}, Line2D{Vector2D{1, 1}, Vector2D{0, 0}}, Vector2D{1, 1}, 1},
+		{Line2D{Vector2D{1, 1}, Vector2D{0, 0}}, Line2D{Vector2D{1, 2}, Vector2D{0, 0}}, Vector2D{}, 0},
+		// an end point one ulp off the other line segment
+		{Line2D{Vector2D{0, 0}, Vector2D{3, 3}}, Line2D{Vector2D{0.1, math.Nextafter(0.1, 1)}, Vector2D{-1, 2}},
+			Vector2D{}, 0},
+	}, intersection2DFuzzyLineSegmentLineSegmentValues...)
+	for _, v := range values {
+		testIntersection2DRobustLineSegmentLineSegment(v, t)
+		v.l1, v.l2 = v.l2, v.l1
+		testIntersection2DRobustLineSegmentLineSegment(v, t)
+	}
+}
+
+func TestIntersection2DLineSegmentsNearlyParallel(t *testing.T) {
+	// crossing so close to parallel that the floating point solution
+	// divides zero by zero
+	a0, a1 := Vector2D{1.8684781386993021, 1.8684781386993021}, Vector2D{4, -0.26304372260139564}
+	b0, b1 := Vector2D{0.7369562773986043, 3}, Vector2D{4, -0.2630437226013957}
+	a, b := Line2D{P: a0}, Line2D{P: b0}
+	a.V.Subtract(&a1, &a0)
+	b.V.Subtract(&b1, &b0)
+	var z Vector2D
+	if k := intersection2DLineSegments(&a0, &a1, &b0, &b1, &z); k != IntersectionPoint ||
+		!(Distance2DLineSegmentPoint(&a, &z) < 1e-12) || !(Distance2DLineSegmentPoint(&b, &z) < 1e-12) {
+		t.Error("intersection2DLineSegments", a0, a1, b0, b1, "got", k, z)
+	}
+}
+
 func Benchmark_Intersection2DFuzzy_LineSegmentLineSegment_Endpoint(b *testing.B) {
 	l1 := &Line2D{Vector2D{0, 0}, Vector2D{1, 1}}
 	l2 := &Line2D{Vector2D{-1, -1}, Vector2D{0, 0}}
@@ -236,3 +283,223 @@ func Benchmark_Intersection2D_LineLine(b *testing.B) {
 		Intersection2DLineLine(l1, l2, p)
 	}
 }
+
+type intersection2DRayAABBData struct {
+	r      Line2D
+	n      int
+	t0, t1 float64
+}
+
+var intersection2DRayAABBValues = []intersection2DRayAABBData{
+	{Line2D{Vector2D{0, 2}, Vector2D{1, 0}}, 1, 1, 3},
+	{Line2D{Vector2D{2, 2}, Vector2D{1, 0}}, 1, 0, 1},
+	{Line2D{Vector2D{0, 0}, Vector2D{2, 2}}, 1, 0.5, 1.5},
+	{Line2D{Vector2D{4, 2}, Vector2D{1, 0}}, 0, 0, 0},
+	{Line2D{Vector2D{0, 5}, Vector2D{1, 0}}, 0, 0, 0},
+	// along an edge
+	{Line2D{Vector2D{2, 1}, Vector2D{0, 1}}, 1, 0, 2},
+	{Line2D{Vector2D{2, 0}, Vector2D{-1, 1}}, 1, 1, 1},
+}
+
+func TestIntersection2DRayAABB(t *testing.T) {
+	b := &AABB2D{Vector2D{1, 1}, Vector2D{3, 3}}
+	for _, v := range intersection2DRayAABBValues {
+		if n, t0, t1 := Intersection2DRayAABB(&v.r, b); n != v.n || !FuzzyEqual(t0, v.t0) || !FuzzyEqual(t1, v.t1) {
+			t.Error("Intersection2D.RayAABB", v.r, b, "want", v.n, v.t0, v.t1, "got", n, t0, t1)
+		}
+	}
+}
+
+func TestIntersection2DLineSegmentAABB(t *testing.T) {
+	b := &AABB2D{Vector2D{1, 1}, Vector2D{3, 3}}
+	l := &Line2D{Vector2D{0, 2}, Vector2D{2, 0}}
+	if n, t0, t1 := Intersection2DLineSegmentAABB(l, b); n != 1 || t0 != 0.5 || t1 != 1 {
+		t.Error("Intersection2D.LineSegmentAABB", l, b, "want", 1, 0.5, 1, "got", n, t0, t1)
+	}
+	l.V.X = 0.5
+	if n, _, _ := Intersection2DLineSegmentAABB(l, b); n != 0 {
+		t.Error("Intersection2D.LineSegmentAABB", l, b, "want", 0, "got", n)
+	}
+}
+
+func Benchmark_Intersection2D_RayAABB(b *testing.B) {
+	r := &Line2D{Vector2D{0, 0}, Vector2D{2, 2}}
+	x := &AABB2D{Vector2D{1, 1}, Vector2D{3, 3}}
+	for i := 0; i < b.N; i++ {
+		Intersection2DRayAABB(r, x)
+	}
+}
+
+type intersection2DCircleCircleData struct {
+	a, b Circle
+	y, z Vector2D
+	n    int
+}
+
+var intersection2DCircleCircleValues = []intersection2DCircleCircleData{
+	{Circle{Vector2D{0, 0}, 5}, Circle{Vector2D{8, 0}, 5}, Vector2D{4, 3}, Vector2D{4, -3}, 2},
+	{Circle{Vector2D{1, 1}, 5}, Circle{Vector2D{1, 9}, 5}, Vector2D{-2, 5}, Vector2D{4, 5}, 2},
+	{Circle{Vector2D{0, 0}, 1}, Circle{Vector2D{2, 0}, 1}, Vector2D{1, 0}, Vector2D{}, 1},
+	{Circle{Vector2D{0, 0}, 2}, Circle{Vector2D{1, 0}, 1}, Vector2D{2, 0}, Vector2D{}, 1},
+	{Circle{Vector2D{0, 0}, 1}, Circle{Vector2D{3, 0}, 1}, Vector2D{}, Vector2D{}, 0},
+	{Circle{Vector2D{0, 0}, 3}, Circle{Vector2D{1, 0}, 1}, Vector2D{}, Vector2D{}, 0},
+	{Circle{Vector2D{0, 0}, 3}, Circle{Vector2D{0, 0}, 1}, Vector2D{}, Vector2D{}, 0},
+	{Circle{Vector2D{1, 2}, 3}, Circle{Vector2D{1, 2}, 3}, Vector2D{}, Vector2D{}, -1},
+}
+
+func testIntersection2DCircleCircle(d intersection2DCircleCircleData, f func(a, b *Circle, y, z *Vector2D) int,
+	name string, t *testing.T) {
+	var y, z Vector2D
+	n := f(&d.a, &d.b, &y, &z)
+	if n != d.n {
+		t.Error(name, d.a, d.b, "want", d.n, "got", n)
+		return
+	}
+	if (n >= 1 && !y.FuzzyEqual(&d.y)) || (n == 2 && !z.FuzzyEqual(&d.z)) {
+		t.Error(name, d.a, d.b, "want", d.y, d.z, "got", y, z)
+	}
+}
+
+func TestIntersection2DCircleCircle(t *testing.T) {
+	for _, v := range intersection2DCircleCircleValues {
+		testIntersection2DCircleCircle(v, Intersection2DCircleCircle, "Intersection2D.CircleCircle", t)
+		testIntersection2DCircleCircle(v, Intersection2DFuzzyCircleCircle, "Intersection2D.FuzzyCircleCircle", t)
+	}
+}
+
+func TestIntersection2DFuzzyCircleCircle(t *testing.T) {
+	a, b := Circle{Vector2D{0, 0}, 1}, Circle{Vector2D{2 + 1e-14, 0}, 1}
+	var y, z Vector2D
+	if n := Intersection2DCircleCircle(&a, &b, &y, &z); n != 0 {
+		t.Error("Intersection2D.CircleCircle", a, b, "want", 0, "got", n)
+	}
+	if n := Intersection2DFuzzyCircleCircle(&a, &b, &y, &z); n != 1 || !y.FuzzyEqual(&Vector2D{1, 0}) {
+		t.Error("Intersection2D.FuzzyCircleCircle", a, b, "want", 1, Vector2D{1, 0}, "got", n, y)
+	}
+	b = Circle{Vector2D{1e-14, 0}, 1}
+	if n := Intersection2DFuzzyCircleCircle(&a, &b, &y, &z); n != -1 {
+		t.Error("Intersection2D.FuzzyCircleCircle", a, b, "want", -1, "got", n)
+	}
+}
+
+func TestIntersection2DCircleCircleNearTangent(t *testing.T) {
+	// centers rounded to about the sum or difference of the radii apart
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		ra, rb := 0.1+3*r.Float64(), 0.1+3*r.Float64()
+		d := ra + rb
+		if i%2 == 1 {
+			d = math.Abs(ra - rb)
+		}
+		s, c := math.Sincos(r.Float64() * 2 * math.Pi)
+		a := Circle{Vector2D{r.Float64(), r.Float64()}, ra}
+		b := Circle{Vector2D{a.C.X + d*c, a.C.Y + d*s}, rb}
+		var y, z Vector2D
+		k := Intersect2DCircleCircle(&a, &b, &y, &z)
+		for j, p := range [2]*Vector2D{&y, &z} {
+			if (k == IntersectionPoint && j == 0) || k == IntersectionTwoPoints {
+				// written so NaN fails
+				if !(math.Abs(Distance2DPointPoint(p, &a.C)-ra) <= 1e-6 && math.Abs(Distance2DPointPoint(p, &b.C)-rb) <= 1e-6) {
+					t.Fatal("Intersect2D.CircleCircle", a, b, "got", k, y, z)
+				}
+			}
+		}
+	}
+}
+
+func Benchmark_Intersection2D_CircleCircle(b *testing.B) {
+	c1, c2 := &Circle{Vector2D{0, 0}, 5}, &Circle{Vector2D{8, 0}, 5}
+	var y, z Vector2D
+	for i := 0; i < b.N; i++ {
+		Intersection2DCircleCircle(c1, c2, &y, &z)
+	}
+}
+
+type intersection2DLineCircleData struct {
+	l    Line2D
+	y, z Vector2D
+	n    [3]int // line, line segment, and ray
+}
+
+var intersection2DLineCircleValues = []intersection2DLineCircleData{
+	{Line2D{Vector2D{-2, 0}, Vector2D{1, 0}}, Vector2D{-1, 0}, Vector2D{1, 0}, [3]int{2, 1, 2}},
+	{Line2D{Vector2D{-2, 0}, Vector2D{4, 0}}, Vector2D{-1, 0}, Vector2D{1, 0}, [3]int{2, 2, 2}},
+	{Line2D{Vector2D{0, 0}, Vector2D{2, 0}}, Vector2D{-1, 0}, Vector2D{1, 0}, [3]int{2, 1, 1}},
+	{Line2D{Vector2D{3, 0}, Vector2D{-1, 0}}, Vector2D{1, 0}, Vector2D{-1, 0}, [3]int{2, 0, 2}},
+	{Line2D{Vector2D{3, 0}, Vector2D{1, 0}}, Vector2D{-1, 0}, Vector2D{1, 0}, [3]int{2, 0, 0}},
+	{Line2D{Vector2D{-2, 1}, Vector2D{4, 0}}, Vector2D{0, 1}, Vector2D{}, [3]int{1, 1, 1}},
+	{Line2D{Vector2D{-2, 2}, Vector2D{4, 0}}, Vector2D{}, Vector2D{}, [3]int{0, 0, 0}},
+	{Line2D{Vector2D{-3, -4}, Vector2D{1, 1}}, Vector2D{0, -1}, Vector2D{1, 0}, [3]int{2, 0, 2}},
+}
+
+func TestIntersection2DLineCircle(t *testing.T) {
+	c := Circle{Vector2D{}, 1}
+	fs := [...]struct {
+		name   string
+		f, ff  func(a *Line2D, b *Circle, y, z *Vector2D) int
+		lo, hi float64
+	}{
+		{"LineCircle", Intersection2DLineCircle, Intersection2DFuzzyLineCircle, math.Inf(-1), math.Inf(1)},
+		{"LineSegmentCircle", Intersection2DLineSegmentCircle, Intersection2DFuzzyLineSegmentCircle, 0, 1},
+		{"RayCircle", Intersection2DRayCircle, Intersection2DFuzzyRayCircle, 0, math.Inf(1)},
+	}
+	for _, v := range intersection2DLineCircleValues {
+		// the intersections of the line that are within range, in order
+		line := []Vector2D{v.y, v.z}[:v.n[0]]
+		for i, f := range fs {
+			var want []Vector2D
+			for _, p := range line {
+				u := ((p.X-v.l.P.X)*v.l.V.X + (p.Y-v.l.P.Y)*v.l.V.Y) / v.l.LengthSquared()
+				if f.lo <= u && u <= f.hi {
+					want = append(want, p)
+				}
+			}
+			if len(want) != v.n[i] {
+				t.Fatal("bad test data", v)
+			}
+			for _, g := range []func(a *Line2D, b *Circle, y, z *Vector2D) int{f.f, f.ff} {
+				var got [2]Vector2D
+				n := g(&v.l, &c, &got[0], &got[1])
+				if n != len(want) {
+					t.Error("Intersection2D."+f.name, v.l, c, "want", len(want), "got", n)
+					continue
+				}
+				for j := range want {
+					if !got[j].FuzzyEqual(&want[j]) {
+						t.Error("Intersection2D."+f.name, v.l, c, "want", want, "got", got[:n])
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestIntersection2DFuzzyLineCircle(t *testing.T) {
+	c := Circle{Vector2D{}, 1}
+	var y, z Vector2D
+	l := Line2D{Vector2D{-2, 1 + 1e-14}, Vector2D{4, 0}}
+	if n := Intersection2DLineSegmentCircle(&l, &c, &y, &z); n != 0 {
+		t.Error("Intersection2D.LineSegmentCircle", l, c, "want", 0, "got", n)
+	}
+	if n := Intersection2DFuzzyLineSegmentCircle(&l, &c, &y, &z); n != 1 || !y.FuzzyEqual(&Vector2D{0, 1}) {
+		t.Error("Intersection2D.FuzzyLineSegmentCircle", l, c, "want", 1, "got", n, y)
+	}
+	// ends just short of the circle
+	l = Line2D{Vector2D{-2, 0}, Vector2D{1 - 1e-14, 0}}
+	if n := Intersection2DLineSegmentCircle(&l, &c, &y, &z); n != 0 {
+		t.Error("Intersection2D.LineSegmentCircle", l, c, "want", 0, "got", n)
+	}
+	if n := Intersection2DFuzzyLineSegmentCircle(&l, &c, &y, &z); n != 1 || !y.FuzzyEqual(&Vector2D{-1, 0}) {
+		t.Error("Intersection2D.FuzzyLineSegmentCircle", l, c, "want", 1, "got", n, y)
+	}
+}
+
+func Benchmark_Intersection2D_LineCircle(b *testing.B) {
+	l := &Line2D{Vector2D{-3, -4}, Vector2D{1, 1}}
+	c := &Circle{Vector2D{}, 1}
+	var y, z Vector2D
+	for i := 0; i < b.N; i++ {
+		Intersection2DLineCircle(l, c, &y, &z)
+	}
+}
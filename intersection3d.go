@@ -7,6 +7,12 @@ import (
 // Intersection3DLineLine, sets z to the shortest line between a and b then
 // returns 1.
 func Intersection3DLineLine(a, b, z *Line3D) int {
+	return Intersect3DLineLine(a, b, z).code()
+}
+
+// Intersect3DLineLine is like Intersection3DLineLine but returns the kind of
+// intersection, always IntersectionSegment.
+func Intersect3DLineLine(a, b, z *Line3D) IntersectionKind {
 	// http://local.wasp.uwa.edu.au/~pbourke/geometry/lineline3d/
 	pdx, pdy, pdz := a.P.X-b.P.X, a.P.Y-b.P.Y, a.P.Z-b.P.Z
 	d1343 := pdx*b.V.X + pdy*b.V.Y + pdz*b.V.Z
@@ -22,7 +28,7 @@ func Intersection3DLineLine(a, b, z *Line3D) int {
 	z.V.X = (b.V.X*mub + b.P.X) - z.P.X
 	z.V.Y = (b.V.Y*mub + b.P.Y) - z.P.Y
 	z.V.Z = (b.V.Z*mub + b.P.Z) - z.P.Z
-	return 1
+	return IntersectionSegment
 }
 
 // Intersection3DLineSphere sets y and z to the possible intersections of line a
@@ -34,6 +40,19 @@ func Intersection3DLineLine(a, b, z *Line3D) int {
 // 2 if the line intersects the sphere, y and z are set to the two intersection
 // points.
 func Intersection3DLineSphere(a *Line3D, b *Sphere, y, z *Vector3D) int {
+	return Intersect3DLineSphere(a, b, y, z).code()
+}
+
+// Intersect3DLineSphere is like Intersection3DLineSphere but returns the kind
+// of intersection.
+//
+// Possible return values are:
+// IntersectionNone for no intersections, y and z are untouched.
+// IntersectionPoint if the line is a tangent of the sphere, y is set and z is
+// untouched.
+// IntersectionTwoPoints if the line intersects the sphere, y and z are set to
+// the two intersection points.
+func Intersect3DLineSphere(a *Line3D, b *Sphere, y, z *Vector3D) IntersectionKind {
 	// http://paulbourke.net/geometry/circlesphere/index.html
 	aa := a.V.X*a.V.X + a.V.Y*a.V.Y + a.V.Z*a.V.Z
 	bb := 2 * (a.V.X*(a.P.X-b.C.X) + a.V.Y*(a.P.Y-b.C.Y) + a.V.Z*(a.P.Z-b.C.Z))
@@ -42,14 +61,14 @@ func Intersection3DLineSphere(a *Line3D, b *Sphere, y, z *Vector3D) int {
 	cc -= b.R * b.R
 	rr := bb*bb - 4*aa*cc
 	if rr < 0 {
-		return 0
+		return IntersectionNone
 	}
 	if rr == 0 {
 		u := -bb / (2 * aa)
 		y.X = a.P.X + u*a.V.X
 		y.Y = a.P.Y + u*a.V.Y
 		y.Z = a.P.Z + u*a.V.Z
-		return 1
+		return IntersectionPoint
 	}
 	rr = math.Sqrt(rr)
 	aa = 1 / (2 * aa)
@@ -61,12 +80,19 @@ func Intersection3DLineSphere(a *Line3D, b *Sphere, y, z *Vector3D) int {
 	z.X = a.P.X + u*a.V.X
 	z.Y = a.P.Y + u*a.V.Y
 	z.Z = a.P.Z + u*a.V.Z
-	return 2
+	return IntersectionTwoPoints
 }
 
 // Intersection3DLineSegmentLineSegment determines the shortest line segment
 // between a and b, then returns 1.
 func Intersection3DLineSegmentLineSegment(a, b, z *Line3D) int {
+	return Intersect3DLineSegmentLineSegment(a, b, z).code()
+}
+
+// Intersect3DLineSegmentLineSegment is like
+// Intersection3DLineSegmentLineSegment but returns the kind of intersection,
+// always IntersectionSegment.
+func Intersect3DLineSegmentLineSegment(a, b, z *Line3D) IntersectionKind {
 	// http://local.wasp.uwa.edu.au/~pbourke/geometry/lineline3d/
 	pdx, pdy, pdz := a.P.X-b.P.X, a.P.Y-b.P.Y, a.P.Z-b.P.Z
 	d1343 := pdx*b.V.X + pdy*b.V.Y + pdz*b.V.Z
@@ -102,23 +128,102 @@ func Intersection3DLineSegmentLineSegment(a, b, z *Line3D) int {
 		z.V.Y = (b.V.Y*mub + b.P.Y) - z.P.Y
 		z.V.Z = (b.V.Z*mub + b.P.Z) - z.P.Z
 	}
-	return 1
+	return IntersectionSegment
+}
+
+// Intersection3DLineSegmentAABB determines the intersection of line segment a
+// with box b, then returns the number of intersections along with the
+// parameters t0 and t1, both in [0, 1], at which a enters and leaves b. If a
+// starts inside b t0 is 0 and if it ends inside b t1 is 1.
+//
+// Possible return values are:
+// 0 if there is no intersection, t0 and t1 are 0.
+// 1 if there is an intersection.
+func Intersection3DLineSegmentAABB(a *Line3D, b *AABB3D) (n int, t0, t1 float64) {
+	k, t0, t1 := Intersect3DLineSegmentAABB(a, b)
+	return k.code(), t0, t1
+}
+
+// Intersect3DLineSegmentAABB is like Intersection3DLineSegmentAABB but returns
+// the kind of intersection.
+//
+// Possible return values are:
+// IntersectionNone if there is no intersection, t0 and t1 are 0.
+// IntersectionSegment if there is an intersection.
+func Intersect3DLineSegmentAABB(a *Line3D, b *AABB3D) (n IntersectionKind, t0, t1 float64) {
+	t0, t1 = 0, 1
+	if !aabbSlab(a.P.X, a.V.X, b.Min.X, b.Max.X, &t0, &t1) ||
+		!aabbSlab(a.P.Y, a.V.Y, b.Min.Y, b.Max.Y, &t0, &t1) ||
+		!aabbSlab(a.P.Z, a.V.Z, b.Min.Z, b.Max.Z, &t0, &t1) {
+		return IntersectionNone, 0, 0
+	}
+	return IntersectionSegment, t0, t1
+}
+
+// Intersection3DLineSegmentTriangle determines the intersection of line
+// segment a with triangle b, then returns the number of intersections along
+// with the position t of the intersection along a and its barycentric
+// coordinates u and v (the weights of b.B and b.C). If cull is true
+// intersections with the back face of b are ignored.
+//
+// Possible return values are:
+// -1 if the line segment lies in the plane of the triangle, z is untouched.
+// 0 if there is no intersection, z is untouched.
+// 1 if there is an intersection, z is set to the intersection point.
+func Intersection3DLineSegmentTriangle(a *Line3D, b *Triangle3D, cull bool, z *Vector3D) (n int, t, u, v float64) {
+	k, t, u, v := Intersect3DLineSegmentTriangle(a, b, cull, z)
+	return k.code(), t, u, v
+}
+
+// Intersect3DLineSegmentTriangle is like Intersection3DLineSegmentTriangle but
+// returns the kind of intersection.
+//
+// Possible return values are:
+// IntersectionCoplanar if the line segment lies in the plane of the triangle,
+// z is untouched.
+// IntersectionNone if there is no intersection, z is untouched.
+// IntersectionPoint if there is an intersection, z is set to the intersection
+// point.
+func Intersect3DLineSegmentTriangle(a *Line3D, b *Triangle3D, cull bool, z *Vector3D) (n IntersectionKind, t, u, v float64) {
+	n, t, u, v = intersection3DLineTriangle(a, b, cull, nil)
+	if n != IntersectionPoint {
+		return n, 0, 0, 0
+	}
+	if t < 0 || t > 1 {
+		return IntersectionNone, 0, 0, 0
+	}
+	z.X = a.P.X + t*a.V.X
+	z.Y = a.P.Y + t*a.V.Y
+	z.Z = a.P.Z + t*a.V.Z
+	return IntersectionPoint, t, u, v
 }
 
 // Intersection3DPlaneLine sets z to the intersection of plane a and line b,
 // then returns 1.
 func Intersection3DPlaneLine(a *Plane, b *Line3D, z *Vector3D) int {
+	return Intersect3DPlaneLine(a, b, z).code()
+}
+
+// Intersect3DPlaneLine is like Intersection3DPlaneLine but returns the kind of
+// intersection, always IntersectionPoint.
+func Intersect3DPlaneLine(a *Plane, b *Line3D, z *Vector3D) IntersectionKind {
 	// http://paulbourke.net/geometry/planeline/
 	u := (a.A*b.P.X + a.B*b.P.Y + a.C*b.P.Z + a.D) / (a.A*b.V.X + a.B*b.V.Y + a.C*b.V.Z)
 	z.X = b.P.X - u*b.V.X
 	z.Y = b.P.Y - u*b.V.Y
 	z.Z = b.P.Z - u*b.V.Z
-	return 1
+	return IntersectionPoint
 }
 
 // Intersection3DPlanePlane sets z to the intersection of planes a and b, then
 // returns 1.
 func Intersection3DPlanePlane(a, b *Plane, z *Line3D) int {
+	return Intersect3DPlanePlane(a, b, z).code()
+}
+
+// Intersect3DPlanePlane is like Intersection3DPlanePlane but returns the kind
+// of intersection, always IntersectionLine.
+func Intersect3DPlanePlane(a, b *Plane, z *Line3D) IntersectionKind {
 	// http://paulbourke.net/geometry/planeplane/
 	n1n1 := a.A*a.A + a.B*a.B + a.C*a.C
 	n2n2 := b.A*b.A + b.B*b.B + b.C*b.C
@@ -132,12 +237,18 @@ func Intersection3DPlanePlane(a, b *Plane, z *Line3D) int {
 	z.V.X = a.B*b.C - a.C*b.B
 	z.V.Y = a.C*b.A - a.A*b.C
 	z.V.Z = a.A*b.B - a.B*b.A
-	return 1
+	return IntersectionLine
 }
 
 // Intersection3DPlanePlanePlane sets z to the intersection of planes a, b, and
 // c, then returns 1.
 func Intersection3DPlanePlanePlane(a, b, c *Plane, z *Vector3D) int {
+	return Intersect3DPlanePlanePlane(a, b, c, z).code()
+}
+
+// Intersect3DPlanePlanePlane is like Intersection3DPlanePlanePlane but returns
+// the kind of intersection, always IntersectionPoint.
+func Intersect3DPlanePlanePlane(a, b, c *Plane, z *Vector3D) IntersectionKind {
 	// http://paulbourke.net/geometry/3planes/
 	n2n3x := b.B*c.C - b.C*c.B
 	n2n3y := b.C*c.A - b.A*c.C
@@ -152,7 +263,33 @@ func Intersection3DPlanePlanePlane(a, b, c *Plane, z *Vector3D) int {
 	z.X = (a.D*n2n3x + b.D*n3n1x + c.D*n1n2x) * d
 	z.Y = (a.D*n2n3y + b.D*n3n1y + c.D*n1n2y) * d
 	z.Z = (a.D*n2n3z + b.D*n3n1z + c.D*n1n2z) * d
-	return 1
+	return IntersectionPoint
+}
+
+// Intersection3DPlaneSphere sets z to the circle where plane a cuts sphere b
+// and returns the number of intersections. The normal of z is the unit normal
+// of a.
+//
+// Possible return values are:
+// 0 if the plane does not touch the sphere, z is untouched.
+// 1 if the plane is a tangent of the sphere, z is set to the point of contact
+// with a radius of 0.
+// 2 if the plane cuts the sphere, z is set to the circle of intersection.
+func Intersection3DPlaneSphere(a *Plane, b *Sphere, z *Circle3D) int {
+	return Intersect3DPlaneSphere(a, b, z).code()
+}
+
+// Intersect3DPlaneSphere is like Intersection3DPlaneSphere but returns the
+// kind of intersection.
+//
+// Possible return values are:
+// IntersectionNone if the plane does not touch the sphere, z is untouched.
+// IntersectionPoint if the plane is a tangent of the sphere, z is set to the
+// point of contact with a radius of 0.
+// IntersectionCircle if the plane cuts the sphere, z is set to the circle of
+// intersection.
+func Intersect3DPlaneSphere(a *Plane, b *Sphere, z *Circle3D) IntersectionKind {
+	return intersection3DPlaneSphere(a, b, nil, z)
 }
 
 // Intersection3DFuzzyPlaneLine sets z to the intersection of a plane and line,
@@ -163,20 +300,44 @@ func Intersection3DPlanePlanePlane(a, b, c *Plane, z *Vector3D) int {
 // 0 if the plane and line are parallel, z is untouched.
 // 1 if an intersection occurs, z is set to the intersection point.
 func Intersection3DFuzzyPlaneLine(a *Plane, b *Line3D, z *Vector3D) int {
+	return Intersect3DFuzzyPlaneLine(a, b, z).code()
+}
+
+// Intersect3DFuzzyPlaneLine is like Intersection3DFuzzyPlaneLine but returns
+// the kind of intersection.
+//
+// Possible return values are:
+// IntersectionCoincident if the plane and line are coincident, z is untouched.
+// IntersectionParallel if the plane and line are parallel, z is untouched.
+// IntersectionPoint if an intersection occurs, z is set to the intersection
+// point.
+func Intersect3DFuzzyPlaneLine(a *Plane, b *Line3D, z *Vector3D) IntersectionKind {
+	return DefaultTolerance.Intersect3DPlaneLine(a, b, z)
+}
+
+// Intersection3DPlaneLine is like Intersection3DFuzzyPlaneLine but compares
+// with tolerance t.
+func (t *Tolerance) Intersection3DPlaneLine(a *Plane, b *Line3D, z *Vector3D) int {
+	return t.Intersect3DPlaneLine(a, b, z).code()
+}
+
+// Intersect3DPlaneLine is like Tolerance.Intersection3DPlaneLine but returns
+// the kind of intersection.
+func (t *Tolerance) Intersect3DPlaneLine(a *Plane, b *Line3D, z *Vector3D) IntersectionKind {
 	// http://paulbourke.net/geometry/planeline/
 	dot2 := a.A*b.V.X + a.B*b.V.Y + a.C*b.V.Z
 	dot1 := a.A*b.P.X + a.B*b.P.Y + a.C*b.P.Z
-	if FuzzyEqual(dot2, 0) {
-		if FuzzyEqual(dot1, 0) {
-			return -1
+	if t.Equal(dot2, 0) {
+		if t.Equal(dot1, 0) {
+			return IntersectionCoincident
 		}
-		return 0
+		return IntersectionParallel
 	}
 	u := (dot1 + a.D) / dot2
 	z.X = b.P.X - u*b.V.X
 	z.Y = b.P.Y - u*b.V.Y
 	z.Z = b.P.Z - u*b.V.Z
-	return 1
+	return IntersectionPoint
 }
 
 // Intersection3DFuzzyPlanePlane sets z to the intersection of planes a and b,
@@ -187,18 +348,42 @@ func Intersection3DFuzzyPlaneLine(a *Plane, b *Line3D, z *Vector3D) int {
 // 0 if the planes are parallel, z is untouched.
 // 1 if an intersection occurs, z is set to the intersection line.
 func Intersection3DFuzzyPlanePlane(a, b *Plane, z *Line3D) int {
+	return Intersect3DFuzzyPlanePlane(a, b, z).code()
+}
+
+// Intersect3DFuzzyPlanePlane is like Intersection3DFuzzyPlanePlane but returns
+// the kind of intersection.
+//
+// Possible return values are:
+// IntersectionCoincident if the planes are coincident, z is untouched.
+// IntersectionParallel if the planes are parallel, z is untouched.
+// IntersectionLine if an intersection occurs, z is set to the intersection
+// line.
+func Intersect3DFuzzyPlanePlane(a, b *Plane, z *Line3D) IntersectionKind {
+	return DefaultTolerance.Intersect3DPlanePlane(a, b, z)
+}
+
+// Intersection3DPlanePlane is like Intersection3DFuzzyPlanePlane but compares
+// with tolerance t.
+func (t *Tolerance) Intersection3DPlanePlane(a, b *Plane, z *Line3D) int {
+	return t.Intersect3DPlanePlane(a, b, z).code()
+}
+
+// Intersect3DPlanePlane is like Tolerance.Intersection3DPlanePlane but returns
+// the kind of intersection.
+func (t *Tolerance) Intersect3DPlanePlane(a, b *Plane, z *Line3D) IntersectionKind {
 	// http://paulbourke.net/geometry/planeplane/
 	cpx, cpy, cpz := a.B*b.C-a.C*b.B, a.C*b.A-a.A*b.C, a.A*b.B-a.B*b.A
 	n1n1 := a.A*a.A + a.B*a.B + a.C*a.C
 	n2n2 := b.A*b.A + b.B*b.B + b.C*b.C
-	if FuzzyEqual(cpx*cpx+cpy*cpy+cpz*cpz, 0) {
+	if t.Equal(cpx*cpx+cpy*cpy+cpz*cpz, 0) {
 		// TODO a.A or b.A almost zero
 		s := a.A / b.A
-		if s*a.D*b.D < 0 || !FuzzyEqual(a.B, s*b.B) || !FuzzyEqual(a.C, s*b.C) ||
-			!FuzzyEqual(b.D*b.D*n1n1, a.D*a.D*n2n2) {
-			return 0
+		if s*a.D*b.D < 0 || !t.Equal(a.B, s*b.B) || !t.Equal(a.C, s*b.C) ||
+			!t.Equal(b.D*b.D*n1n1, a.D*a.D*n2n2) {
+			return IntersectionParallel
 		}
-		return -1
+		return IntersectionCoincident
 	}
 	n1n2 := a.A*b.A + a.B*b.B + a.C*b.C
 	d := 1 / (n1n1*n2n2 - n1n2*n1n2)
@@ -210,7 +395,7 @@ func Intersection3DFuzzyPlanePlane(a, b *Plane, z *Line3D) int {
 	z.V.X = cpx
 	z.V.Y = cpy
 	z.V.Z = cpz
-	return 1
+	return IntersectionLine
 }
 
 // Intersection3DFuzzyPlanePlanePlane sets z to the intersection of 3 planes,
@@ -223,24 +408,60 @@ func Intersection3DFuzzyPlanePlane(a, b *Plane, z *Line3D) int {
 // 0 if all planes are parallel (two could be coincident), z is untouched.
 // 1 if the planes intersect at a point, z is set to the intersection point.
 func Intersection3DFuzzyPlanePlanePlane(a, b, c *Plane, z *Vector3D) int {
+	k := Intersect3DFuzzyPlanePlanePlane(a, b, c, z)
+	if k == IntersectionLine {
+		return -2
+	}
+	return k.code()
+}
+
+// Intersect3DFuzzyPlanePlanePlane is like Intersection3DFuzzyPlanePlanePlane
+// but returns the kind of intersection.
+//
+// Possible return values are:
+// IntersectionParallelPairs if two planes are parallel and the third
+// intersects at two lines.
+// IntersectionLine if all three planes intersect at a line.
+// IntersectionCoincident if all three planes are coincident.
+// IntersectionParallel if all planes are parallel (two could be coincident), z
+// is untouched.
+// IntersectionPoint if the planes intersect at a point, z is set to the
+// intersection point.
+func Intersect3DFuzzyPlanePlanePlane(a, b, c *Plane, z *Vector3D) IntersectionKind {
+	return DefaultTolerance.Intersect3DPlanePlanePlane(a, b, c, z)
+}
+
+// Intersection3DPlanePlanePlane is like Intersection3DFuzzyPlanePlanePlane but
+// compares with tolerance t.
+func (t *Tolerance) Intersection3DPlanePlanePlane(a, b, c *Plane, z *Vector3D) int {
+	k := t.Intersect3DPlanePlanePlane(a, b, c, z)
+	if k == IntersectionLine {
+		return -2
+	}
+	return k.code()
+}
+
+// Intersect3DPlanePlanePlane is like Tolerance.Intersection3DPlanePlanePlane
+// but returns the kind of intersection.
+func (t *Tolerance) Intersect3DPlanePlanePlane(a, b, c *Plane, z *Vector3D) IntersectionKind {
 	n1n1 := a.A*a.A + a.B*a.B + a.C*a.C
 	n2n2 := b.A*b.A + b.B*b.B + b.C*b.C
 	n3n3 := c.A*c.A + c.B*c.B + c.C*c.C
 
 	// use cross products to check if plane normals are equal
 	cpabx, cpaby, cpabz := a.B*b.C-a.C*b.B, a.C*b.A-a.A*b.C, a.A*b.B-a.B*b.A
-	n1n2d := FuzzyEqual(cpabx*cpabx+cpaby*cpaby+cpabz*cpabz, 0)
+	n1n2d := t.Equal(cpabx*cpabx+cpaby*cpaby+cpabz*cpabz, 0)
 	cpcax, cpcay, cpcaz := c.B*a.C-c.C*a.B, c.C*a.A-c.A*a.C, c.A*a.B-c.B*a.A
-	n3n1d := FuzzyEqual(cpcax*cpcax+cpcay*cpcay+cpcaz*cpcaz, 0)
+	n3n1d := t.Equal(cpcax*cpcax+cpcay*cpcay+cpcaz*cpcaz, 0)
 
 	// check if all planes are parallel
 	if n1n2d && n3n1d {
 		// check if all planes are coincident
-		if FuzzyEqual(b.D*b.D*n1n1, a.D*a.D*n2n2) &&
-			FuzzyEqual(c.D*c.D*n1n1, a.D*a.D*n3n3) {
-			return -1
+		if t.Equal(b.D*b.D*n1n1, a.D*a.D*n2n2) &&
+			t.Equal(c.D*c.D*n1n1, a.D*a.D*n3n3) {
+			return IntersectionCoincident
 		} else {
-			return 0
+			return IntersectionParallel
 		}
 	}
 
@@ -248,7 +469,7 @@ func Intersection3DFuzzyPlanePlanePlane(a, b, c *Plane, z *Vector3D) int {
 
 	// check if lines from pair of plane intersections have the same direction
 	if ldx, ldy, ldz := cpaby*cpbcz-cpabz*cpbcy, cpabz*cpbcx-cpabx*cpbcz,
-		cpabx*cpbcy-cpaby*cpbcx; FuzzyEqual(ldx*ldx+ldy*ldy+ldz*ldz, 0) {
+		cpabx*cpbcy-cpaby*cpbcx; t.Equal(ldx*ldx+ldy*ldy+ldz*ldz, 0) {
 		// get point on each line
 		n1n2 := a.A*b.A + a.B*b.B + a.C*b.C
 		n2n3 := b.A*c.A + b.B*c.B + b.C*c.C
@@ -262,16 +483,16 @@ func Intersection3DFuzzyPlanePlanePlane(a, b, c *Plane, z *Vector3D) int {
 		p2x, p2y, p2z := c3*b.A+c4*c.A, c3*b.B+c4*c.B, c3*b.C+c4*c.C
 
 		// check if points lie on the third plane
-		if FuzzyEqual(p1x*c.A+p1y*c.B+p1z*c.C+c.D, 0) &&
-			FuzzyEqual(p2x*a.A+p2y*a.B+p2z*a.C+a.D, 0) {
-			return -2
+		if t.Equal(p1x*c.A+p1y*c.B+p1z*c.C+c.D, 0) &&
+			t.Equal(p2x*a.A+p2y*a.B+p2z*a.C+a.D, 0) {
+			return IntersectionLine
 		}
 	}
 
 	// check for a pair of parallel planes resulting in 2 lines, all 3 parallel
 	// and 2 coincident have been caught already
-	if n1n2d || n3n1d || FuzzyEqual(cpbcx*cpbcx+cpbcy*cpbcy+cpbcz*cpbcz, 0) {
-		return -3
+	if n1n2d || n3n1d || t.Equal(cpbcx*cpbcx+cpbcy*cpbcy+cpbcz*cpbcz, 0) {
+		return IntersectionParallelPairs
 	}
 
 	// having ruled out all degenerate cases calculate intersection
@@ -279,12 +500,266 @@ func Intersection3DFuzzyPlanePlanePlane(a, b, c *Plane, z *Vector3D) int {
 	z.X = (a.D*cpbcx + b.D*cpcax + c.D*cpabx) * d
 	z.Y = (a.D*cpbcy + b.D*cpcay + c.D*cpaby) * d
 	z.Z = (a.D*cpbcz + b.D*cpcaz + c.D*cpabz) * d
-	return 1
+	return IntersectionPoint
+}
+
+// Intersection3DFuzzyPlaneSphere is like Intersection3DPlaneSphere but a
+// plane very close to being a tangent of the sphere is treated as a tangent.
+//
+// Possible return values are:
+// 0 if the plane does not touch the sphere, z is untouched.
+// 1 if the plane is a tangent of the sphere, z is set to the point of contact
+// with a radius of 0.
+// 2 if the plane cuts the sphere, z is set to the circle of intersection.
+func Intersection3DFuzzyPlaneSphere(a *Plane, b *Sphere, z *Circle3D) int {
+	return Intersect3DFuzzyPlaneSphere(a, b, z).code()
+}
+
+// Intersect3DFuzzyPlaneSphere is like Intersection3DFuzzyPlaneSphere but
+// returns the kind of intersection.
+//
+// Possible return values are:
+// IntersectionNone if the plane does not touch the sphere, z is untouched.
+// IntersectionPoint if the plane is a tangent of the sphere, z is set to the
+// point of contact with a radius of 0.
+// IntersectionCircle if the plane cuts the sphere, z is set to the circle of
+// intersection.
+func Intersect3DFuzzyPlaneSphere(a *Plane, b *Sphere, z *Circle3D) IntersectionKind {
+	return DefaultTolerance.Intersect3DPlaneSphere(a, b, z)
+}
+
+// Intersection3DPlaneSphere is like Intersection3DFuzzyPlaneSphere but
+// compares with tolerance t.
+func (t *Tolerance) Intersection3DPlaneSphere(a *Plane, b *Sphere, z *Circle3D) int {
+	return t.Intersect3DPlaneSphere(a, b, z).code()
+}
+
+// Intersect3DPlaneSphere is like Tolerance.Intersection3DPlaneSphere but
+// returns the kind of intersection.
+func (t *Tolerance) Intersect3DPlaneSphere(a *Plane, b *Sphere, z *Circle3D) IntersectionKind {
+	return intersection3DPlaneSphere(a, b, t, z)
+}
+
+// Intersection3DFuzzySphereSphere is like Intersection3DSphereSphere but
+// spheres very close to touching are treated as touching and spheres very
+// close to each other are treated as coincident.
+//
+// Possible return values are:
+// -2 if one sphere is inside the other without touching, z is untouched.
+// -1 if the spheres are coincident, z is untouched.
+// 0 if the spheres are apart, z is untouched.
+// 1 if the spheres touch, z is set to the point of contact with a radius of 0.
+// 2 if the spheres intersect, z is set to the circle of intersection.
+func Intersection3DFuzzySphereSphere(a, b *Sphere, z *Circle3D) int {
+	return Intersect3DFuzzySphereSphere(a, b, z).code()
+}
+
+// Intersect3DFuzzySphereSphere is like Intersection3DFuzzySphereSphere but
+// returns the kind of intersection.
+//
+// Possible return values are:
+// IntersectionContained if one sphere is inside the other without touching, z
+// is untouched.
+// IntersectionCoincident if the spheres are coincident, z is untouched.
+// IntersectionNone if the spheres are apart, z is untouched.
+// IntersectionPoint if the spheres touch, z is set to the point of contact
+// with a radius of 0.
+// IntersectionCircle if the spheres intersect, z is set to the circle of
+// intersection.
+func Intersect3DFuzzySphereSphere(a, b *Sphere, z *Circle3D) IntersectionKind {
+	return DefaultTolerance.Intersect3DSphereSphere(a, b, z)
+}
+
+// Intersection3DSphereSphere is like Intersection3DFuzzySphereSphere but
+// compares with tolerance t.
+func (t *Tolerance) Intersection3DSphereSphere(a, b *Sphere, z *Circle3D) int {
+	return t.Intersect3DSphereSphere(a, b, z).code()
+}
+
+// Intersect3DSphereSphere is like Tolerance.Intersection3DSphereSphere but
+// returns the kind of intersection.
+func (t *Tolerance) Intersect3DSphereSphere(a, b *Sphere, z *Circle3D) IntersectionKind {
+	return intersection3DSphereSphere(a, b, t, z)
+}
+
+// Intersection3DFuzzyLineSegmentTriangle is like
+// Intersection3DLineSegmentTriangle but intersections very close to the edges
+// of the triangle or the ends of the line segment are counted and a line
+// segment very close to parallel with the triangle is treated as parallel.
+//
+// Possible return values are:
+// -1 if the line segment lies in the plane of the triangle, z is untouched.
+// 0 if there is no intersection, z is untouched.
+// 1 if there is an intersection, z is set to the intersection point.
+func Intersection3DFuzzyLineSegmentTriangle(a *Line3D, b *Triangle3D, cull bool, z *Vector3D) (n int, t, u, v float64) {
+	k, t, u, v := Intersect3DFuzzyLineSegmentTriangle(a, b, cull, z)
+	return k.code(), t, u, v
+}
+
+// Intersect3DFuzzyLineSegmentTriangle is like
+// Intersection3DFuzzyLineSegmentTriangle but returns the kind of intersection.
+//
+// Possible return values are:
+// IntersectionCoplanar if the line segment lies in the plane of the triangle,
+// z is untouched.
+// IntersectionNone if there is no intersection, z is untouched.
+// IntersectionPoint if there is an intersection, z is set to the intersection
+// point.
+func Intersect3DFuzzyLineSegmentTriangle(a *Line3D, b *Triangle3D, cull bool, z *Vector3D) (n IntersectionKind, t, u, v float64) {
+	return DefaultTolerance.Intersect3DLineSegmentTriangle(a, b, cull, z)
+}
+
+// Intersection3DLineSegmentTriangle is like
+// Intersection3DFuzzyLineSegmentTriangle but compares with tolerance tol.
+func (tol *Tolerance) Intersection3DLineSegmentTriangle(a *Line3D, b *Triangle3D, cull bool, z *Vector3D) (n int, t, u, v float64) {
+	k, t, u, v := tol.Intersect3DLineSegmentTriangle(a, b, cull, z)
+	return k.code(), t, u, v
+}
+
+// Intersect3DLineSegmentTriangle is like
+// Tolerance.Intersection3DLineSegmentTriangle but returns the kind of
+// intersection.
+func (tol *Tolerance) Intersect3DLineSegmentTriangle(a *Line3D, b *Triangle3D, cull bool, z *Vector3D) (n IntersectionKind, t, u, v float64) {
+	n, t, u, v = intersection3DLineTriangle(a, b, cull, tol)
+	if n != IntersectionPoint {
+		return n, 0, 0, 0
+	}
+	if !tol.between(t, 0, 1) {
+		return IntersectionNone, 0, 0, 0
+	}
+	z.X = a.P.X + t*a.V.X
+	z.Y = a.P.Y + t*a.V.Y
+	z.Z = a.P.Z + t*a.V.Z
+	return IntersectionPoint, t, u, v
+}
+
+// Intersection3DFuzzyRayTriangle is like Intersection3DRayTriangle but
+// intersections very close to the edges of the triangle or the origin of the
+// ray are counted and a ray very close to parallel with the triangle is
+// treated as parallel.
+//
+// Possible return values are:
+// -1 if the ray lies in the plane of the triangle, z is untouched.
+// 0 if there is no intersection, z is untouched.
+// 1 if there is an intersection, z is set to the intersection point.
+func Intersection3DFuzzyRayTriangle(a *Line3D, b *Triangle3D, cull bool, z *Vector3D) (n int, t, u, v float64) {
+	k, t, u, v := Intersect3DFuzzyRayTriangle(a, b, cull, z)
+	return k.code(), t, u, v
+}
+
+// Intersect3DFuzzyRayTriangle is like Intersection3DFuzzyRayTriangle but
+// returns the kind of intersection.
+//
+// Possible return values are:
+// IntersectionCoplanar if the ray lies in the plane of the triangle, z is
+// untouched.
+// IntersectionNone if there is no intersection, z is untouched.
+// IntersectionPoint if there is an intersection, z is set to the intersection
+// point.
+func Intersect3DFuzzyRayTriangle(a *Line3D, b *Triangle3D, cull bool, z *Vector3D) (n IntersectionKind, t, u, v float64) {
+	return DefaultTolerance.Intersect3DRayTriangle(a, b, cull, z)
+}
+
+// Intersection3DRayTriangle is like Intersection3DFuzzyRayTriangle but
+// compares with tolerance tol.
+func (tol *Tolerance) Intersection3DRayTriangle(a *Line3D, b *Triangle3D, cull bool, z *Vector3D) (n int, t, u, v float64) {
+	k, t, u, v := tol.Intersect3DRayTriangle(a, b, cull, z)
+	return k.code(), t, u, v
+}
+
+// Intersect3DRayTriangle is like Tolerance.Intersection3DRayTriangle but
+// returns the kind of intersection.
+func (tol *Tolerance) Intersect3DRayTriangle(a *Line3D, b *Triangle3D, cull bool, z *Vector3D) (n IntersectionKind, t, u, v float64) {
+	n, t, u, v = intersection3DLineTriangle(a, b, cull, tol)
+	if n != IntersectionPoint {
+		return n, 0, 0, 0
+	}
+	if t < 0 && !tol.Equal(t, 0) {
+		return IntersectionNone, 0, 0, 0
+	}
+	z.X = a.P.X + t*a.V.X
+	z.Y = a.P.Y + t*a.V.Y
+	z.Z = a.P.Z + t*a.V.Z
+	return IntersectionPoint, t, u, v
+}
+
+// Intersection3DRayAABB determines the intersection of ray a with box b, then
+// returns the number of intersections along with the distances t0 and t1, in
+// multiples of a.V, at which the ray enters and leaves b. If the ray starts
+// inside b t0 is 0.
+//
+// Possible return values are:
+// 0 if there is no intersection, t0 and t1 are 0.
+// 1 if there is an intersection.
+func Intersection3DRayAABB(a *Line3D, b *AABB3D) (n int, t0, t1 float64) {
+	k, t0, t1 := Intersect3DRayAABB(a, b)
+	return k.code(), t0, t1
+}
+
+// Intersect3DRayAABB is like Intersection3DRayAABB but returns the kind of
+// intersection.
+//
+// Possible return values are:
+// IntersectionNone if there is no intersection, t0 and t1 are 0.
+// IntersectionSegment if there is an intersection.
+func Intersect3DRayAABB(a *Line3D, b *AABB3D) (n IntersectionKind, t0, t1 float64) {
+	t0, t1 = 0, math.Inf(1)
+	if !aabbSlab(a.P.X, a.V.X, b.Min.X, b.Max.X, &t0, &t1) ||
+		!aabbSlab(a.P.Y, a.V.Y, b.Min.Y, b.Max.Y, &t0, &t1) ||
+		!aabbSlab(a.P.Z, a.V.Z, b.Min.Z, b.Max.Z, &t0, &t1) {
+		return IntersectionNone, 0, 0
+	}
+	return IntersectionSegment, t0, t1
+}
+
+// Intersection3DFuzzyTriangleTriangle is like Intersection3DTriangleTriangle
+// but vertices very close to the plane of the other triangle are treated as
+// on it, so triangles that very nearly touch are counted as intersecting and
+// triangles very close to coplanar are treated as coplanar.
+//
+// Possible return values are:
+// -1 if the triangles are coplanar and overlap, z is untouched.
+// 0 if the triangles do not intersect, z is untouched.
+// 1 if the triangles intersect, z is set to the line segment they share,
+// which has a zero length vector if they only touch at a point.
+func Intersection3DFuzzyTriangleTriangle(a, b *Triangle3D, z *Line3D) int {
+	return Intersect3DFuzzyTriangleTriangle(a, b, z).code()
+}
+
+// Intersect3DFuzzyTriangleTriangle is like Intersection3DFuzzyTriangleTriangle
+// but returns the kind of intersection.
+//
+// Possible return values are:
+// IntersectionCoplanar if the triangles are coplanar and overlap, z is
+// untouched.
+// IntersectionNone if the triangles do not intersect, z is untouched.
+// IntersectionSegment if the triangles intersect, z is set to the line segment
+// they share, which has a zero length vector if they only touch at a point.
+func Intersect3DFuzzyTriangleTriangle(a, b *Triangle3D, z *Line3D) IntersectionKind {
+	return DefaultTolerance.Intersect3DTriangleTriangle(a, b, z)
+}
+
+// Intersection3DTriangleTriangle is like Intersection3DFuzzyTriangleTriangle
+// but compares with tolerance t.
+func (t *Tolerance) Intersection3DTriangleTriangle(a, b *Triangle3D, z *Line3D) int {
+	return t.Intersect3DTriangleTriangle(a, b, z).code()
+}
+
+// Intersect3DTriangleTriangle is like Tolerance.Intersection3DTriangleTriangle
+// but returns the kind of intersection.
+func (t *Tolerance) Intersect3DTriangleTriangle(a, b *Triangle3D, z *Line3D) IntersectionKind {
+	return intersection3DTriangleTriangle(a, b, t, z)
 }
 
 // Intersection3DRaySphere sets z to the first intersection of ray a with sphere
 // b and returns the number of intersections, either 1 or 0.
 func Intersection3DRaySphere(a *Line3D, b *Sphere, z *Vector3D) int {
+	return Intersect3DRaySphere(a, b, z).code()
+}
+
+// Intersect3DRaySphere is like Intersection3DRaySphere but returns the kind of
+// intersection.
+func Intersect3DRaySphere(a *Line3D, b *Sphere, z *Vector3D) IntersectionKind {
 	aa := a.V.X*a.V.X + a.V.Y*a.V.Y + a.V.Z*a.V.Z
 	bb := 2 * (a.V.X*(a.P.X-b.C.X) + a.V.Y*(a.P.Y-b.C.Y) + a.V.Z*(a.P.Z-b.C.Z))
 	cc := b.C.X*b.C.X + b.C.Y*b.C.Y + b.C.Z*b.C.Z + a.P.X*a.P.X + a.P.Y*a.P.Y + a.P.Z*a.P.Z
@@ -292,14 +767,14 @@ func Intersection3DRaySphere(a *Line3D, b *Sphere, z *Vector3D) int {
 	cc -= b.R * b.R
 	rr := bb*bb - 4*aa*cc
 	if rr < 0 {
-		return 0
+		return IntersectionNone
 	}
 	if rr == 0 {
 		u := -bb / (2 * aa)
 		z.X = a.P.X + u*a.V.X
 		z.Y = a.P.Y + u*a.V.Y
 		z.Z = a.P.Z + u*a.V.Z
-		return 1
+		return IntersectionPoint
 	}
 	rr = math.Sqrt(rr)
 	aa = 1 / (2 * aa)
@@ -307,13 +782,426 @@ func Intersection3DRaySphere(a *Line3D, b *Sphere, z *Vector3D) int {
 		z.X = a.P.X + u*a.V.X
 		z.Y = a.P.Y + u*a.V.Y
 		z.Z = a.P.Z + u*a.V.Z
-		return 1
+		return IntersectionPoint
 	}
 	if u := (-bb - rr) * aa; u > 0 {
 		z.X = a.P.X + u*a.V.X
 		z.Y = a.P.Y + u*a.V.Y
 		z.Z = a.P.Z + u*a.V.Z
-		return 1
+		return IntersectionPoint
 	}
-	return 0
+	return IntersectionNone
+}
+
+// Intersection3DRayTriangle determines the intersection of ray a with
+// triangle b, then returns the number of intersections along with the
+// distance t of the intersection along the ray, in multiples of a.V, and its
+// barycentric coordinates u and v (the weights of b.B and b.C). If cull is
+// true intersections with the back face of b are ignored.
+//
+// Possible return values are:
+// -1 if the ray lies in the plane of the triangle, z is untouched.
+// 0 if there is no intersection, z is untouched.
+// 1 if there is an intersection, z is set to the intersection point.
+func Intersection3DRayTriangle(a *Line3D, b *Triangle3D, cull bool, z *Vector3D) (n int, t, u, v float64) {
+	k, t, u, v := Intersect3DRayTriangle(a, b, cull, z)
+	return k.code(), t, u, v
+}
+
+// Intersect3DRayTriangle is like Intersection3DRayTriangle but returns the
+// kind of intersection.
+//
+// Possible return values are:
+// IntersectionCoplanar if the ray lies in the plane of the triangle, z is
+// untouched.
+// IntersectionNone if there is no intersection, z is untouched.
+// IntersectionPoint if there is an intersection, z is set to the intersection
+// point.
+func Intersect3DRayTriangle(a *Line3D, b *Triangle3D, cull bool, z *Vector3D) (n IntersectionKind, t, u, v float64) {
+	n, t, u, v = intersection3DLineTriangle(a, b, cull, nil)
+	if n != IntersectionPoint {
+		return n, 0, 0, 0
+	}
+	if t < 0 {
+		return IntersectionNone, 0, 0, 0
+	}
+	z.X = a.P.X + t*a.V.X
+	z.Y = a.P.Y + t*a.V.Y
+	z.Z = a.P.Z + t*a.V.Z
+	return IntersectionPoint, t, u, v
+}
+
+// Intersection3DRobustPlaneLine is like Intersection3DFuzzyPlaneLine but
+// decides if the plane and line are parallel or coincident exactly, so the
+// result is always correct. Only the position of the intersection is subject
+// to rounding.
+//
+// Possible return values are:
+// -1 if the plane and line are coincident, z is untouched.
+// 0 if the plane and line are parallel, z is untouched.
+// 1 if an intersection occurs, z is set to the intersection point.
+func Intersection3DRobustPlaneLine(a *Plane, b *Line3D, z *Vector3D) int {
+	return Intersect3DRobustPlaneLine(a, b, z).code()
+}
+
+// Intersect3DRobustPlaneLine is like Intersection3DRobustPlaneLine but returns
+// the kind of intersection.
+//
+// Possible return values are:
+// IntersectionCoincident if the plane and line are coincident, z is untouched.
+// IntersectionParallel if the plane and line are parallel, z is untouched.
+// IntersectionPoint if an intersection occurs, z is set to the intersection
+// point.
+func Intersect3DRobustPlaneLine(a *Plane, b *Line3D, z *Vector3D) IntersectionKind {
+	if productSumSign(a.A, b.V.X, a.B, b.V.Y, a.C, b.V.Z) != 0 {
+		return Intersect3DPlaneLine(a, b, z)
+	}
+	if productSumSign(a.A, b.P.X, a.B, b.P.Y, a.C, b.P.Z, a.D, 1) == 0 {
+		return IntersectionCoincident
+	}
+	return IntersectionParallel
+}
+
+// Intersection3DRobustPlanePlane is like Intersection3DFuzzyPlanePlane but
+// decides if the planes are parallel or coincident exactly, so the result is
+// always correct. Only the position of the intersection is subject to
+// rounding.
+//
+// Possible return values are:
+// -1 if the planes are coincident, z is untouched.
+// 0 if the planes are parallel, z is untouched.
+// 1 if an intersection occurs, z is set to the intersection line.
+func Intersection3DRobustPlanePlane(a, b *Plane, z *Line3D) int {
+	return Intersect3DRobustPlanePlane(a, b, z).code()
+}
+
+// Intersect3DRobustPlanePlane is like Intersection3DRobustPlanePlane but
+// returns the kind of intersection.
+//
+// Possible return values are:
+// IntersectionCoincident if the planes are coincident, z is untouched.
+// IntersectionParallel if the planes are parallel, z is untouched.
+// IntersectionLine if an intersection occurs, z is set to the intersection
+// line.
+func Intersect3DRobustPlanePlane(a, b *Plane, z *Line3D) IntersectionKind {
+	if productSumSign(a.B, b.C, -a.C, b.B) != 0 || productSumSign(a.C, b.A, -a.A, b.C) != 0 ||
+		productSumSign(a.A, b.B, -a.B, b.A) != 0 {
+		return Intersect3DPlanePlane(a, b, z)
+	}
+	// the normals are parallel so the planes coincide if the offsets scale
+	// like any non-zero component of the normals
+	an, bn := a.A, b.A
+	if math.Abs(a.B) > math.Abs(an) {
+		an, bn = a.B, b.B
+	}
+	if math.Abs(a.C) > math.Abs(an) {
+		an, bn = a.C, b.C
+	}
+	if productSumSign(a.D, bn, -b.D, an) == 0 {
+		return IntersectionCoincident
+	}
+	return IntersectionParallel
+}
+
+// Intersection3DSphereSphere sets z to the circle where spheres a and b
+// intersect and returns the number of intersections. The normal of z is the
+// unit vector from the center of a towards the center of b.
+//
+// Possible return values are:
+// -2 if one sphere is inside the other without touching, z is untouched.
+// -1 if the spheres are coincident, z is untouched.
+// 0 if the spheres are apart, z is untouched.
+// 1 if the spheres touch, z is set to the point of contact with a radius of 0.
+// 2 if the spheres intersect, z is set to the circle of intersection.
+func Intersection3DSphereSphere(a, b *Sphere, z *Circle3D) int {
+	return Intersect3DSphereSphere(a, b, z).code()
+}
+
+// Intersect3DSphereSphere is like Intersection3DSphereSphere but returns the
+// kind of intersection.
+//
+// Possible return values are:
+// IntersectionContained if one sphere is inside the other without touching, z
+// is untouched.
+// IntersectionCoincident if the spheres are coincident, z is untouched.
+// IntersectionNone if the spheres are apart, z is untouched.
+// IntersectionPoint if the spheres touch, z is set to the point of contact
+// with a radius of 0.
+// IntersectionCircle if the spheres intersect, z is set to the circle of
+// intersection.
+func Intersect3DSphereSphere(a, b *Sphere, z *Circle3D) IntersectionKind {
+	return intersection3DSphereSphere(a, b, nil, z)
+}
+
+// Intersection3DTriangleTriangle determines the intersection of triangles a
+// and b then returns the number of intersections.
+//
+// Possible return values are:
+// -1 if the triangles are coplanar and overlap, z is untouched.
+// 0 if the triangles do not intersect, z is untouched.
+// 1 if the triangles intersect, z is set to the line segment they share,
+// which has a zero length vector if they only touch at a point.
+func Intersection3DTriangleTriangle(a, b *Triangle3D, z *Line3D) int {
+	return Intersect3DTriangleTriangle(a, b, z).code()
+}
+
+// Intersect3DTriangleTriangle is like Intersection3DTriangleTriangle but
+// returns the kind of intersection.
+//
+// Possible return values are:
+// IntersectionCoplanar if the triangles are coplanar and overlap, z is
+// untouched.
+// IntersectionNone if the triangles do not intersect, z is untouched.
+// IntersectionSegment if the triangles intersect, z is set to the line segment
+// they share, which has a zero length vector if they only touch at a point.
+func Intersect3DTriangleTriangle(a, b *Triangle3D, z *Line3D) IntersectionKind {
+	return intersection3DTriangleTriangle(a, b, nil, z)
+}
+
+// intersection3DLineTriangle intersects line a with triangle b and returns -1
+// if the line lies in the plane of b, 0 if they do not intersect, or 1 along
+// with the line parameter and barycentric coordinates of the intersection.
+func intersection3DLineTriangle(a *Line3D, b *Triangle3D, cull bool, tol *Tolerance) (n IntersectionKind, t, u, v float64) {
+	// Moller and Trumbore, "Fast, Minimum Storage Ray/Triangle Intersection",
+	// Journal of Graphics Tools, 1997.
+	var e1, e2, p, s, q Vector3D
+	e1.Subtract(&b.B, &b.A)
+	e2.Subtract(&b.C, &b.A)
+	p.CrossProduct(&a.V, &e2)
+	d := e1.DotProduct(&p)
+	s.Subtract(&a.P, &b.A)
+	parallel := d == 0
+	if tol != nil {
+		parallel = tol.Equal(d/math.Sqrt(a.V.MagnitudeSquared()*e1.MagnitudeSquared()*e2.MagnitudeSquared()), 0)
+	}
+	if parallel {
+		q.CrossProduct(&e1, &e2)
+		o := s.DotProduct(&q)
+		if o == 0 || (tol != nil && tol.Equal(o/math.Sqrt(s.MagnitudeSquared()*q.MagnitudeSquared()), 0)) {
+			return IntersectionCoplanar, 0, 0, 0
+		}
+		return IntersectionNone, 0, 0, 0
+	}
+	if cull && d < 0 {
+		return IntersectionNone, 0, 0, 0
+	}
+	d = 1 / d
+	u = s.DotProduct(&p) * d
+	q.CrossProduct(&s, &e1)
+	v = a.V.DotProduct(&q) * d
+	if tol != nil {
+		if !tol.between(u, 0, 1) || !tol.between(v, 0, 1) || !tol.between(u+v, 0, 1) {
+			return IntersectionNone, 0, 0, 0
+		}
+	} else if u < 0 || u > 1 || v < 0 || u+v > 1 {
+		return IntersectionNone, 0, 0, 0
+	}
+	return IntersectionPoint, e2.DotProduct(&q) * d, u, v
+}
+
+// intersection3DPlaneSphere implements Intersection3DPlaneSphere and
+// Tolerance.Intersection3DPlaneSphere.
+func intersection3DPlaneSphere(a *Plane, b *Sphere, tol *Tolerance, z *Circle3D) IntersectionKind {
+	var p Plane
+	a.Normalize(&p)
+	d := Distance3DPlaneNormalizedPoint(&p, &b.C)
+	tangent := math.Abs(d) == b.R || (tol != nil && tol.Equal(math.Abs(d), b.R))
+	if !tangent && math.Abs(d) > b.R {
+		return IntersectionNone
+	}
+	p.Normal(&z.N)
+	z.C.X = b.C.X - d*p.A
+	z.C.Y = b.C.Y - d*p.B
+	z.C.Z = b.C.Z - d*p.C
+	if tangent {
+		z.R = 0
+		return IntersectionPoint
+	}
+	z.R = math.Sqrt((b.R - d) * (b.R + d))
+	return IntersectionCircle
+}
+
+// intersection3DSphereSphere implements Intersection3DSphereSphere and
+// Tolerance.Intersection3DSphereSphere.
+func intersection3DSphereSphere(a, b *Sphere, tol *Tolerance, z *Circle3D) IntersectionKind {
+	// http://paulbourke.net/geometry/circlesphere/
+	var n Vector3D
+	n.Subtract(&b.C, &a.C)
+	d := n.Magnitude()
+	if tol != nil {
+		if tol.SphereEqual(a, b) {
+			return IntersectionCoincident
+		}
+	} else if d == 0 && a.R == b.R {
+		return IntersectionCoincident
+	}
+	sum, diff := a.R+b.R, math.Abs(a.R-b.R)
+	tangent := d == sum || (d != 0 && d == diff)
+	if tol != nil {
+		tangent = tol.Equal(d, sum) || (d != 0 && tol.Equal(d, diff))
+	}
+	if !tangent {
+		if d > sum {
+			return IntersectionNone
+		}
+		if d < diff || d == 0 {
+			return IntersectionContained
+		}
+	}
+	n.Scale(&n, 1/d)
+	// distance from a.C to the plane of the intersection
+	h := (a.R*a.R - b.R*b.R + d*d) / (2 * d)
+	z.N = n
+	z.C.X = a.C.X + h*n.X
+	z.C.Y = a.C.Y + h*n.Y
+	z.C.Z = a.C.Z + h*n.Z
+	if tangent {
+		z.R = 0
+		return IntersectionPoint
+	}
+	z.R = math.Sqrt((a.R - h) * (a.R + h))
+	return IntersectionCircle
+}
+
+// intersection3DTriangleTriangle implements Intersection3DTriangleTriangle
+// and Tolerance.Intersection3DTriangleTriangle.
+func intersection3DTriangleTriangle(a, b *Triangle3D, tol *Tolerance, z *Line3D) IntersectionKind {
+	// Moller, "A Fast Triangle-Triangle Intersection Test", Journal of
+	// Graphics Tools, 1997, finding the interval each triangle cuts from the
+	// line where the two planes meet and testing for overlap.
+	var pa, pb Plane
+	a.Plane(&pa)
+	b.Plane(&pb)
+	da, ca := triangleSignedDistances(a, &pb, tol)
+	if ca != 0 {
+		return IntersectionNone
+	}
+	db, cb := triangleSignedDistances(b, &pa, tol)
+	if cb != 0 {
+		return IntersectionNone
+	}
+	if da == [3]float64{} {
+		if trianglesOverlapCoplanar(a, b, &pa) {
+			return IntersectionCoplanar
+		}
+		return IntersectionNone
+	}
+
+	// each triangle meets the other's plane along a line segment, or a point,
+	// on the line both planes share
+	var d Vector3D
+	d.CrossProduct(&Vector3D{pa.A, pa.B, pa.C}, &Vector3D{pb.A, pb.B, pb.C})
+	a0, a1 := trianglePlaneSegment(a, &da)
+	b0, b1 := trianglePlaneSegment(b, &db)
+	ta0, ta1 := d.DotProduct(&a0), d.DotProduct(&a1)
+	if ta0 > ta1 {
+		a0, a1, ta0, ta1 = a1, a0, ta1, ta0
+	}
+	tb0, tb1 := d.DotProduct(&b0), d.DotProduct(&b1)
+	if tb0 > tb1 {
+		b0, b1, tb0, tb1 = b1, b0, tb1, tb0
+	}
+	lo, p0 := ta0, a0
+	if tb0 > lo {
+		lo, p0 = tb0, b0
+	}
+	hi, p1 := ta1, a1
+	if tb1 < hi {
+		hi, p1 = tb1, b1
+	}
+	if lo > hi {
+		if tol == nil || !tol.Equal(lo/d.Magnitude(), hi/d.Magnitude()) {
+			return IntersectionNone
+		}
+		p1 = p0
+	}
+	z.P = p0
+	z.V.Subtract(&p1, &p0)
+	return IntersectionSegment
+}
+
+// triangleSignedDistances returns the signed distances, scaled by the
+// magnitude of p's normal, of the vertices of x from plane p along with 1 if
+// they are all in front of p, -1 if they are all behind it, or 0 otherwise.
+// If tol is not nil distances within tol of zero are set to zero.
+func triangleSignedDistances(x *Triangle3D, p *Plane, tol *Tolerance) (d [3]float64, side int) {
+	m := math.Sqrt(p.A*p.A + p.B*p.B + p.C*p.C)
+	for i, v := range [3]*Vector3D{&x.A, &x.B, &x.C} {
+		d[i] = p.A*v.X + p.B*v.Y + p.C*v.Z + p.D
+		if tol != nil && tol.Equal(d[i]/m, 0) {
+			d[i] = 0
+		}
+	}
+	if d[0] > 0 && d[1] > 0 && d[2] > 0 {
+		return d, 1
+	}
+	if d[0] < 0 && d[1] < 0 && d[2] < 0 {
+		return d, -1
+	}
+	return d, 0
+}
+
+// trianglePlaneSegment returns the end points of the line segment where
+// triangle x meets a plane, given the signed distances d of its vertices from
+// the plane which must not all have the same sign or all be zero.
+func trianglePlaneSegment(x *Triangle3D, d *[3]float64) (p0, p1 Vector3D) {
+	v := [3]*Vector3D{&x.A, &x.B, &x.C}
+	var p [2]Vector3D
+	n := 0
+	for i := 0; i < 3 && n < 2; i++ {
+		if d[i] == 0 {
+			p[n] = *v[i]
+			n++
+		}
+	}
+	for i := 0; i < 3 && n < 2; i++ {
+		j := (i + 1) % 3
+		if (d[i] < 0 && d[j] > 0) || (d[i] > 0 && d[j] < 0) {
+			t := d[i] / (d[i] - d[j])
+			p[n].X = v[i].X + t*(v[j].X-v[i].X)
+			p[n].Y = v[i].Y + t*(v[j].Y-v[i].Y)
+			p[n].Z = v[i].Z + t*(v[j].Z-v[i].Z)
+			n++
+		}
+	}
+	if n == 1 {
+		// a single vertex touches the plane
+		p[1] = p[0]
+	}
+	return p[0], p[1]
+}
+
+// trianglesOverlapCoplanar returns true if triangles a and b, which lie in
+// plane p, overlap or touch or false otherwise.
+func trianglesOverlapCoplanar(a, b *Triangle3D, p *Plane) bool {
+	// project onto the coordinate plane where the triangles have the largest
+	// area
+	ax, ay, az := math.Abs(p.A), math.Abs(p.B), math.Abs(p.C)
+	project := func(v *Vector3D) Vector2D {
+		switch {
+		case ax >= ay && ax >= az:
+			return Vector2D{v.Y, v.Z}
+		case ay >= az:
+			return Vector2D{v.X, v.Z}
+		}
+		return Vector2D{v.X, v.Y}
+	}
+	ta := [3]Vector2D{project(&a.A), project(&a.B), project(&a.C)}
+	tb := [3]Vector2D{project(&b.A), project(&b.B), project(&b.C)}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if segmentsIntersect(&ta[i], &ta[(i+1)%3], &tb[j], &tb[(j+1)%3]) {
+				return true
+			}
+		}
+	}
+	// otherwise one is either entirely inside the other or they are apart
+	return triangle2DContains(&tb, &ta[0]) || triangle2DContains(&ta, &tb[0])
+}
+
+// triangle2DContains returns true if p is inside or on triangle t or false
+// otherwise.
+func triangle2DContains(t *[3]Vector2D, p *Vector2D) bool {
+	d0, d1, d2 := cross2D(&t[0], &t[1], p), cross2D(&t[1], &t[2], p), cross2D(&t[2], &t[0], p)
+	return (d0 >= 0 && d1 >= 0 && d2 >= 0) || (d0 <= 0 && d1 <= 0 && d2 <= 0)
 }
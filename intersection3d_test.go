@@ -1,6 +1,7 @@
 package geometry
 
 import (
+	"math"
 	"testing"
 )
 
@@ -299,6 +300,53 @@ func Benchmark_Intersection3D_FuzzyPlanePlane_Coincident(b *testing.B) {
 	}
 }
 
+func TestIntersection3DRobustPlaneLine(t *testing.T) {
+	p := &Plane{1, 2, 3, -6}
+	var z Vector3D
+	for _, v := range []struct {
+		l Line3D
+		n int
+	}{
+		{Line3D{Vector3D{6, 0, 0}, Vector3D{-2, 1, 0}}, -1},
+		{Line3D{Vector3D{7, 0, 0}, Vector3D{-2, 1, 0}}, 0},
+		{Line3D{Vector3D{0, 0, 0}, Vector3D{1, 1, 1}}, 1},
+		// very nearly parallel
+		{Line3D{Vector3D{7, 0, 0}, Vector3D{-2, 1, 1e-300}}, 1},
+	} {
+		if n := Intersection3DRobustPlaneLine(p, &v.l, &z); n != v.n {
+			t.Error("Intersection3D.RobustPlaneLine", p, v.l, "want", v.n, "got", n)
+		}
+	}
+	l := &Line3D{Vector3D{0, 0, 0}, Vector3D{1, 1, 1}}
+	if Intersection3DRobustPlaneLine(p, l, &z); !z.FuzzyEqual(&Vector3D{1, 1, 1}) {
+		t.Error("Intersection3D.RobustPlaneLine", p, l, "want", Vector3D{1, 1, 1}, "got", z)
+	}
+}
+
+func TestIntersection3DRobustPlanePlane(t *testing.T) {
+	var l Line3D
+	for _, v := range []struct {
+		p1, p2 Plane
+		n      int
+	}{
+		{Plane{1, 0, 0, 1}, Plane{1, 0, 0, 1}, -1},
+		{Plane{1, 2, 3, 4}, Plane{-2, -4, -6, -8}, -1},
+		{Plane{1, 0, 0, 1}, Plane{1, 0, 0, 2}, 0},
+		{Plane{1, 1, 1, 1}, Plane{1, 2, 3, 4}, 1},
+		// coincident to well within FuzzyEqual but only parallel
+		{Plane{1 + 1e-13, 0, 0, 1}, Plane{1, 0, 0, 1}, 0},
+		// parallel to well within FuzzyEqual but not exactly
+		{Plane{1, 1e-300, 0, 1}, Plane{1, 0, 0, 1}, 1},
+	} {
+		if n := Intersection3DRobustPlanePlane(&v.p1, &v.p2, &l); n != v.n {
+			t.Error("Intersection3D.RobustPlanePlane", v.p1, v.p2, "want", v.n, "got", n)
+		}
+		if n := Intersection3DRobustPlanePlane(&v.p2, &v.p1, &l); n != v.n {
+			t.Error("Intersection3D.RobustPlanePlane", v.p2, v.p1, "want", v.n, "got", n)
+		}
+	}
+}
+
 type intersection3DFuzzyPlanePlanePlaneData struct {
 	p1, p2, p3 Plane
 	p          Vector3D
@@ -403,3 +451,321 @@ func Benchmark_Intersection3D_RaySphere(b *testing.B) {
 		Intersection3DRaySphere(&l, &s, &p)
 	}
 }
+
+type intersection3DRayTriangleData struct {
+	r       Line3D
+	tr      Triangle3D
+	cull    bool
+	i       Vector3D
+	t, u, v float64
+	n       int
+}
+
+var intersection3DRayTriangleValues = []intersection3DRayTriangleData{
+	// hit from the front, the triangle is counterclockwise seen from +z
+	{Line3D{Vector3D{0.25, 0.25, 1}, Vector3D{0, 0, -1}}, Triangle3D{Vector3D{}, Vector3D{1, 0, 0}, Vector3D{0, 1, 0}},
+		false, Vector3D{0.25, 0.25, 0}, 1, 0.25, 0.25, 1},
+	{Line3D{Vector3D{0.25, 0.25, 1}, Vector3D{0, 0, -1}}, Triangle3D{Vector3D{}, Vector3D{1, 0, 0}, Vector3D{0, 1, 0}},
+		true, Vector3D{0.25, 0.25, 0}, 1, 0.25, 0.25, 1},
+	// hit from the back
+	{Line3D{Vector3D{0.5, 0.25, -2}, Vector3D{0, 0, 1}}, Triangle3D{Vector3D{}, Vector3D{1, 0, 0}, Vector3D{0, 1, 0}},
+		false, Vector3D{0.5, 0.25, 0}, 2, 0.5, 0.25, 1},
+	{Line3D{Vector3D{0.5, 0.25, -2}, Vector3D{0, 0, 1}}, Triangle3D{Vector3D{}, Vector3D{1, 0, 0}, Vector3D{0, 1, 0}},
+		true, Vector3D{}, 0, 0, 0, 0},
+	// behind the ray
+	{Line3D{Vector3D{0.25, 0.25, 1}, Vector3D{0, 0, 1}}, Triangle3D{Vector3D{}, Vector3D{1, 0, 0}, Vector3D{0, 1, 0}},
+		false, Vector3D{}, 0, 0, 0, 0},
+	// outside the triangle
+	{Line3D{Vector3D{0.75, 0.75, 1}, Vector3D{0, 0, -1}}, Triangle3D{Vector3D{}, Vector3D{1, 0, 0}, Vector3D{0, 1, 0}},
+		false, Vector3D{}, 0, 0, 0, 0},
+	// in the plane of the triangle
+	{Line3D{Vector3D{-1, 0.25, 0}, Vector3D{1, 0, 0}}, Triangle3D{Vector3D{}, Vector3D{1, 0, 0}, Vector3D{0, 1, 0}},
+		false, Vector3D{}, 0, 0, 0, -1},
+	// parallel to the plane of the triangle
+	{Line3D{Vector3D{-1, 0.25, 1}, Vector3D{1, 0, 0}}, Triangle3D{Vector3D{}, Vector3D{1, 0, 0}, Vector3D{0, 1, 0}},
+		false, Vector3D{}, 0, 0, 0, 0},
+	// oblique with a scaled direction
+	{Line3D{Vector3D{0, 0, 0}, Vector3D{2, 2, 2}}, Triangle3D{Vector3D{3, 0, 0}, Vector3D{0, 3, 0}, Vector3D{0, 0, 3}},
+		false, Vector3D{1, 1, 1}, 0.5, 1.0 / 3, 1.0 / 3, 1},
+}
+
+func testIntersection3DRayTriangle(d intersection3DRayTriangleData, t *testing.T) {
+	var i Vector3D
+	n, rt, u, v := Intersection3DRayTriangle(&d.r, &d.tr, d.cull, &i)
+	if n != d.n {
+		t.Error("Intersection3D.RayTriangle", d.r, d.tr, d.cull, "want", d.n, "got", n)
+		return
+	}
+	if n != 1 {
+		return
+	}
+	if !d.i.FuzzyEqual(&i) || !FuzzyEqual(d.t, rt) || !FuzzyEqual(d.u, u) || !FuzzyEqual(d.v, v) {
+		t.Error("Intersection3D.RayTriangle", d.r, d.tr, d.cull, "want", d.i, d.t, d.u, d.v, "got", i, rt, u, v)
+	}
+}
+
+func TestIntersection3DRayTriangle(t *testing.T) {
+	for _, v := range intersection3DRayTriangleValues {
+		testIntersection3DRayTriangle(v, t)
+	}
+}
+
+func TestIntersection3DLineSegmentTriangle(t *testing.T) {
+	tr := Triangle3D{Vector3D{}, Vector3D{1, 0, 0}, Vector3D{0, 1, 0}}
+	var i Vector3D
+	// the segment ends before reaching the triangle
+	l := Line3D{Vector3D{0.25, 0.25, 2}, Vector3D{0, 0, -1}}
+	if n, _, _, _ := Intersection3DLineSegmentTriangle(&l, &tr, false, &i); n != 0 {
+		t.Error("Intersection3D.LineSegmentTriangle", l, tr, "want", 0, "got", n)
+	}
+	l.V.Z = -4
+	if n, rt, _, _ := Intersection3DLineSegmentTriangle(&l, &tr, false, &i); n != 1 || rt != 0.5 ||
+		!i.Equal(&Vector3D{0.25, 0.25, 0}) {
+		t.Error("Intersection3D.LineSegmentTriangle", l, tr, "want", 1, 0.5, "got", n, rt, i)
+	}
+	l = Line3D{Vector3D{-1, 0.25, 0}, Vector3D{2, 0, 0}}
+	if n, _, _, _ := Intersection3DLineSegmentTriangle(&l, &tr, false, &i); n != -1 {
+		t.Error("Intersection3D.LineSegmentTriangle", l, tr, "want", -1, "got", n)
+	}
+}
+
+func TestIntersection3DFuzzyRayTriangle(t *testing.T) {
+	tr := Triangle3D{Vector3D{}, Vector3D{1, 0, 0}, Vector3D{0, 1, 0}}
+	var i Vector3D
+	// just outside the edge from B to C
+	l := Line3D{Vector3D{0.5 + 1e-14, 0.5, 1}, Vector3D{0, 0, -1}}
+	if n, _, _, _ := Intersection3DRayTriangle(&l, &tr, false, &i); n != 0 {
+		t.Error("Intersection3D.RayTriangle", l, tr, "want", 0, "got", n)
+	}
+	if n, _, _, _ := Intersection3DFuzzyRayTriangle(&l, &tr, false, &i); n != 1 {
+		t.Error("Intersection3D.FuzzyRayTriangle", l, tr, "want", 1, "got", n)
+	}
+	// very nearly in the plane of the triangle
+	l = Line3D{Vector3D{-1, 0.25, 0}, Vector3D{1, 0, 1e-14}}
+	if n, _, _, _ := Intersection3DFuzzyRayTriangle(&l, &tr, false, &i); n != -1 {
+		t.Error("Intersection3D.FuzzyRayTriangle", l, tr, "want", -1, "got", n)
+	}
+	// just past the end of the segment
+	l = Line3D{Vector3D{0.25, 0.25, 1}, Vector3D{0, 0, -1 + 1e-14}}
+	if n, _, _, _ := Intersection3DLineSegmentTriangle(&l, &tr, false, &i); n != 0 {
+		t.Error("Intersection3D.LineSegmentTriangle", l, tr, "want", 0, "got", n)
+	}
+	if n, _, _, _ := Intersection3DFuzzyLineSegmentTriangle(&l, &tr, false, &i); n != 1 {
+		t.Error("Intersection3D.FuzzyLineSegmentTriangle", l, tr, "want", 1, "got", n)
+	}
+}
+
+func Benchmark_Intersection3D_RayTriangle(b *testing.B) {
+	l := Line3D{Vector3D{0.25, 0.25, 1}, Vector3D{0, 0, -1}}
+	tr := Triangle3D{Vector3D{}, Vector3D{1, 0, 0}, Vector3D{0, 1, 0}}
+	var p Vector3D
+	for i := 0; i < b.N; i++ {
+		Intersection3DRayTriangle(&l, &tr, false, &p)
+	}
+}
+
+func TestIntersection3DRayAABB(t *testing.T) {
+	b := &AABB3D{Vector3D{1, 1, 1}, Vector3D{3, 3, 3}}
+	r := &Line3D{Vector3D{0, 0, 0}, Vector3D{1, 1, 1}}
+	if n, t0, t1 := Intersection3DRayAABB(r, b); n != 1 || t0 != 1 || t1 != 3 {
+		t.Error("Intersection3D.RayAABB", r, b, "want", 1, 1, 3, "got", n, t0, t1)
+	}
+	r = &Line3D{Vector3D{2, 2, 2}, Vector3D{0, 0, -1}}
+	if n, t0, t1 := Intersection3DRayAABB(r, b); n != 1 || t0 != 0 || t1 != 1 {
+		t.Error("Intersection3D.RayAABB", r, b, "want", 1, 0, 1, "got", n, t0, t1)
+	}
+	r = &Line3D{Vector3D{0, 2, 4}, Vector3D{1, 0, 0}}
+	if n, _, _ := Intersection3DRayAABB(r, b); n != 0 {
+		t.Error("Intersection3D.RayAABB", r, b, "want", 0, "got", n)
+	}
+	r = &Line3D{Vector3D{4, 2, 2}, Vector3D{1, 0, 0}}
+	if n, _, _ := Intersection3DRayAABB(r, b); n != 0 {
+		t.Error("Intersection3D.RayAABB", r, b, "want", 0, "got", n)
+	}
+}
+
+func TestIntersection3DLineSegmentAABB(t *testing.T) {
+	b := &AABB3D{Vector3D{1, 1, 1}, Vector3D{3, 3, 3}}
+	l := &Line3D{Vector3D{0, 2, 2}, Vector3D{4, 0, 0}}
+	if n, t0, t1 := Intersection3DLineSegmentAABB(l, b); n != 1 || t0 != 0.25 || t1 != 0.75 {
+		t.Error("Intersection3D.LineSegmentAABB", l, b, "want", 1, 0.25, 0.75, "got", n, t0, t1)
+	}
+	l.V.X = 0.5
+	if n, _, _ := Intersection3DLineSegmentAABB(l, b); n != 0 {
+		t.Error("Intersection3D.LineSegmentAABB", l, b, "want", 0, "got", n)
+	}
+}
+
+type intersection3DPlaneSphereData struct {
+	p Plane
+	s Sphere
+	c Circle3D
+	n int
+}
+
+var intersection3DPlaneSphereValues = []intersection3DPlaneSphereData{
+	{Plane{0, 0, 2, -2}, Sphere{Vector3D{1, 1, 0}, 2}, Circle3D{Vector3D{1, 1, 1}, Vector3D{0, 0, 1}, math.Sqrt(3)}, 2},
+	{Plane{0, 0, 1, 0}, Sphere{Vector3D{1, 1, 0}, 2}, Circle3D{Vector3D{1, 1, 0}, Vector3D{0, 0, 1}, 2}, 2},
+	{Plane{1, 0, 0, -3}, Sphere{Vector3D{1, 1, 0}, 2}, Circle3D{Vector3D{3, 1, 0}, Vector3D{1, 0, 0}, 0}, 1},
+	{Plane{-1, 0, 0, -1}, Sphere{Vector3D{1, 1, 0}, 2}, Circle3D{Vector3D{-1, 1, 0}, Vector3D{-1, 0, 0}, 0}, 1},
+	{Plane{1, 0, 0, -4}, Sphere{Vector3D{1, 1, 0}, 2}, Circle3D{}, 0},
+}
+
+func TestIntersection3DPlaneSphere(t *testing.T) {
+	for _, v := range intersection3DPlaneSphereValues {
+		for _, f := range []func(*Plane, *Sphere, *Circle3D) int{Intersection3DPlaneSphere, Intersection3DFuzzyPlaneSphere} {
+			var c Circle3D
+			if n := f(&v.p, &v.s, &c); n != v.n || (n > 0 && !c.FuzzyEqual(&v.c)) {
+				t.Error("Intersection3D.PlaneSphere", v.p, v.s, "want", v.n, v.c, "got", n, c)
+			}
+		}
+	}
+	p, s := Plane{0, 0, 1, -2 - 1e-14}, Sphere{Vector3D{}, 2}
+	var c Circle3D
+	if n := Intersection3DPlaneSphere(&p, &s, &c); n != 0 {
+		t.Error("Intersection3D.PlaneSphere", p, s, "want", 0, "got", n)
+	}
+	if n := Intersection3DFuzzyPlaneSphere(&p, &s, &c); n != 1 {
+		t.Error("Intersection3D.FuzzyPlaneSphere", p, s, "want", 1, "got", n)
+	}
+}
+
+type intersection3DSphereSphereData struct {
+	a, b Sphere
+	c    Circle3D
+	n    int
+}
+
+var intersection3DSphereSphereValues = []intersection3DSphereSphereData{
+	{Sphere{Vector3D{0, 0, 0}, 5}, Sphere{Vector3D{0, 8, 0}, 5}, Circle3D{Vector3D{0, 4, 0}, Vector3D{0, 1, 0}, 3}, 2},
+	{Sphere{Vector3D{1, 1, 1}, 2}, Sphere{Vector3D{1, 1, 5}, 2}, Circle3D{Vector3D{1, 1, 3}, Vector3D{0, 0, 1}, 0}, 1},
+	{Sphere{Vector3D{0, 0, 0}, 3}, Sphere{Vector3D{1, 0, 0}, 2}, Circle3D{Vector3D{3, 0, 0}, Vector3D{1, 0, 0}, 0}, 1},
+	{Sphere{Vector3D{0, 0, 0}, 1}, Sphere{Vector3D{3, 0, 0}, 1}, Circle3D{}, 0},
+	{Sphere{Vector3D{0, 0, 0}, 3}, Sphere{Vector3D{1, 0, 0}, 1}, Circle3D{}, -2},
+	{Sphere{Vector3D{0, 0, 0}, 3}, Sphere{Vector3D{0, 0, 0}, 1}, Circle3D{}, -2},
+	{Sphere{Vector3D{1, 2, 3}, 3}, Sphere{Vector3D{1, 2, 3}, 3}, Circle3D{}, -1},
+}
+
+func TestIntersection3DSphereSphere(t *testing.T) {
+	for _, v := range intersection3DSphereSphereValues {
+		for _, f := range []func(a, b *Sphere, z *Circle3D) int{Intersection3DSphereSphere, Intersection3DFuzzySphereSphere} {
+			var c Circle3D
+			if n := f(&v.a, &v.b, &c); n != v.n || (n > 0 && !c.FuzzyEqual(&v.c)) {
+				t.Error("Intersection3D.SphereSphere", v.a, v.b, "want", v.n, v.c, "got", n, c)
+			}
+		}
+	}
+	a, b := Sphere{Vector3D{}, 1}, Sphere{Vector3D{2 + 1e-14, 0, 0}, 1}
+	var c Circle3D
+	if n := Intersection3DSphereSphere(&a, &b, &c); n != 0 {
+		t.Error("Intersection3D.SphereSphere", a, b, "want", 0, "got", n)
+	}
+	if n := Intersection3DFuzzySphereSphere(&a, &b, &c); n != 1 || !c.C.FuzzyEqual(&Vector3D{1, 0, 0}) {
+		t.Error("Intersection3D.FuzzySphereSphere", a, b, "want", 1, "got", n, c)
+	}
+}
+
+func Benchmark_Intersection3D_SphereSphere(b *testing.B) {
+	s1, s2 := &Sphere{Vector3D{0, 0, 0}, 5}, &Sphere{Vector3D{0, 8, 0}, 5}
+	var c Circle3D
+	for i := 0; i < b.N; i++ {
+		Intersection3DSphereSphere(s1, s2, &c)
+	}
+}
+
+type intersection3DTriangleTriangleData struct {
+	a, b Triangle3D
+	l    Line3D
+	n    int
+}
+
+var intersection3DTriangleTriangleValues = []intersection3DTriangleTriangleData{
+	// crossing, b pierces a
+	{Triangle3D{Vector3D{0, 0, 0}, Vector3D{4, 0, 0}, Vector3D{0, 4, 0}},
+		Triangle3D{Vector3D{1, 1, -1}, Vector3D{1, 1, 1}, Vector3D{1, 5, 1}},
+		Line3D{Vector3D{1, 1, 0}, Vector3D{0, 2, 0}}, 1},
+	// crossing, the segment is bounded by both triangles
+	{Triangle3D{Vector3D{0, 0, 0}, Vector3D{4, 0, 0}, Vector3D{0, 4, 0}},
+		Triangle3D{Vector3D{1, -1, -1}, Vector3D{1, 5, -1}, Vector3D{1, 2, 2}},
+		Line3D{Vector3D{1, 0, 0}, Vector3D{0, 3, 0}}, 1},
+	// a vertex of b touches the inside of a
+	{Triangle3D{Vector3D{0, 0, 0}, Vector3D{4, 0, 0}, Vector3D{0, 4, 0}},
+		Triangle3D{Vector3D{1, 1, 0}, Vector3D{1, 1, 1}, Vector3D{2, 1, 1}},
+		Line3D{Vector3D{1, 1, 0}, Vector3D{}}, 1},
+	// sharing an edge
+	{Triangle3D{Vector3D{0, 0, 0}, Vector3D{4, 0, 0}, Vector3D{0, 4, 0}},
+		Triangle3D{Vector3D{0, 0, 0}, Vector3D{4, 0, 0}, Vector3D{0, 0, 4}},
+		Line3D{Vector3D{0, 0, 0}, Vector3D{4, 0, 0}}, 1},
+	// b's plane cuts a but the triangles miss
+	{Triangle3D{Vector3D{0, 0, 0}, Vector3D{4, 0, 0}, Vector3D{0, 4, 0}},
+		Triangle3D{Vector3D{5, 5, -1}, Vector3D{5, 5, 1}, Vector3D{9, 5, 1}},
+		Line3D{}, 0},
+	{Triangle3D{Vector3D{0, 0, 0}, Vector3D{4, 0, 0}, Vector3D{0, 4, 0}},
+		Triangle3D{Vector3D{3, 3, -1}, Vector3D{3, 3, 1}, Vector3D{3, 9, 1}},
+		Line3D{}, 0},
+	// b entirely above a
+	{Triangle3D{Vector3D{0, 0, 0}, Vector3D{4, 0, 0}, Vector3D{0, 4, 0}},
+		Triangle3D{Vector3D{1, 1, 1}, Vector3D{2, 1, 1}, Vector3D{1, 2, 3}},
+		Line3D{}, 0},
+	// coplanar
+	{Triangle3D{Vector3D{0, 0, 0}, Vector3D{4, 0, 0}, Vector3D{0, 4, 0}},
+		Triangle3D{Vector3D{1, 1, 0}, Vector3D{5, 1, 0}, Vector3D{1, 5, 0}},
+		Line3D{}, -1},
+	{Triangle3D{Vector3D{0, 0, 0}, Vector3D{4, 0, 0}, Vector3D{0, 4, 0}},
+		Triangle3D{Vector3D{1, 1, 0}, Vector3D{2, 1, 0}, Vector3D{1, 2, 0}},
+		Line3D{}, -1},
+	{Triangle3D{Vector3D{0, 0, 0}, Vector3D{4, 0, 2}, Vector3D{0, 4, 2}},
+		Triangle3D{Vector3D{-1, -1, -1}, Vector3D{9, -1, 4}, Vector3D{-1, 9, 4}},
+		Line3D{}, -1},
+	{Triangle3D{Vector3D{0, 0, 0}, Vector3D{4, 0, 0}, Vector3D{0, 4, 0}},
+		Triangle3D{Vector3D{3, 3, 0}, Vector3D{5, 3, 0}, Vector3D{3, 5, 0}},
+		Line3D{}, 0},
+}
+
+func TestIntersection3DTriangleTriangle(t *testing.T) {
+	for _, v := range intersection3DTriangleTriangleValues {
+		for _, f := range []func(a, b *Triangle3D, z *Line3D) int{Intersection3DTriangleTriangle,
+			Intersection3DFuzzyTriangleTriangle} {
+			for _, swap := range []bool{false, true} {
+				a, b := &v.a, &v.b
+				if swap {
+					a, b = b, a
+				}
+				var l Line3D
+				n := f(a, b, &l)
+				if n != v.n || (n == 1 && !l.SegmentFuzzyEqual(&v.l) &&
+					!(v.l.V == Vector3D{} && l.P.FuzzyEqual(&v.l.P) && l.V.FuzzyEqual(&v.l.V))) {
+					t.Error("Intersection3D.TriangleTriangle", *a, *b, "want", v.n, v.l, "got", n, l)
+				}
+			}
+		}
+	}
+}
+
+func TestIntersection3DFuzzyTriangleTriangle(t *testing.T) {
+	a := Triangle3D{Vector3D{0, 0, 0}, Vector3D{4, 0, 0}, Vector3D{0, 4, 0}}
+	b := Triangle3D{Vector3D{1, 1, 1e-14}, Vector3D{1, 1, 1}, Vector3D{2, 1, 1}}
+	var l Line3D
+	if n := Intersection3DTriangleTriangle(&a, &b, &l); n != 0 {
+		t.Error("Intersection3D.TriangleTriangle", a, b, "want", 0, "got", n)
+	}
+	if n := Intersection3DFuzzyTriangleTriangle(&a, &b, &l); n != 1 || !l.P.FuzzyEqual(&Vector3D{1, 1, 1e-14}) {
+		t.Error("Intersection3D.FuzzyTriangleTriangle", a, b, "want", 1, "got", n, l)
+	}
+	b = Triangle3D{Vector3D{1, 1, 1e-14}, Vector3D{2, 1, 0}, Vector3D{1, 2, -1e-14}}
+	if n := Intersection3DTriangleTriangle(&a, &b, &l); n != 1 {
+		t.Error("Intersection3D.TriangleTriangle", a, b, "want", 1, "got", n)
+	}
+	if n := Intersection3DFuzzyTriangleTriangle(&a, &b, &l); n != -1 {
+		t.Error("Intersection3D.FuzzyTriangleTriangle", a, b, "want", -1, "got", n)
+	}
+}
+
+func Benchmark_Intersection3D_TriangleTriangle(b *testing.B) {
+	t1 := &Triangle3D{Vector3D{0, 0, 0}, Vector3D{4, 0, 0}, Vector3D{0, 4, 0}}
+	t2 := &Triangle3D{Vector3D{1, -1, -1}, Vector3D{1, 5, -1}, Vector3D{1, 2, 2}}
+	var l Line3D
+	for i := 0; i < b.N; i++ {
+		Intersection3DTriangleTriangle(t1, t2, &l)
+	}
+}
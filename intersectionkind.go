@@ -0,0 +1,89 @@
+package geometry
+
+import (
+	"strconv"
+)
+
+// An IntersectionKind describes how two shapes meet. The Intersect functions
+// return it in place of the int codes of the Intersection functions, whose
+// meaning depends on the function.
+type IntersectionKind int
+
+const (
+	// IntersectionNone means the shapes do not meet.
+	IntersectionNone IntersectionKind = iota
+
+	// IntersectionPoint means the shapes meet at a single point.
+	IntersectionPoint
+
+	// IntersectionTwoPoints means the shapes meet at two separate points.
+	IntersectionTwoPoints
+
+	// IntersectionSegment means the shapes meet along a line segment, or for
+	// functions finding the shortest line between shapes that it was found.
+	IntersectionSegment
+
+	// IntersectionLine means the shapes meet along an infinite line.
+	IntersectionLine
+
+	// IntersectionCircle means the shapes meet along a circle.
+	IntersectionCircle
+
+	// IntersectionCoincident means the shapes are the same, or overlap along
+	// the same line.
+	IntersectionCoincident
+
+	// IntersectionCoplanar means one shape lies in the plane of the other.
+	IntersectionCoplanar
+
+	// IntersectionParallel means the shapes are parallel but apart.
+	IntersectionParallel
+
+	// IntersectionParallelPairs means that of three planes two are parallel
+	// and the third cuts them along two lines.
+	IntersectionParallelPairs
+
+	// IntersectionContained means one shape is inside the other without
+	// their surfaces meeting.
+	IntersectionContained
+)
+
+var intersectionKindNames = [...]string{
+	IntersectionNone:          "None",
+	IntersectionPoint:         "Point",
+	IntersectionTwoPoints:     "TwoPoints",
+	IntersectionSegment:       "Segment",
+	IntersectionLine:          "Line",
+	IntersectionCircle:        "Circle",
+	IntersectionCoincident:    "Coincident",
+	IntersectionCoplanar:      "Coplanar",
+	IntersectionParallel:      "Parallel",
+	IntersectionParallelPairs: "ParallelPairs",
+	IntersectionContained:     "Contained",
+}
+
+// String returns the name of k.
+func (k IntersectionKind) String() string {
+	if k < 0 || int(k) >= len(intersectionKindNames) {
+		return "IntersectionKind(" + strconv.Itoa(int(k)) + ")"
+	}
+	return intersectionKindNames[k]
+}
+
+// code returns the int code the Intersection functions use for k. Where an
+// Intersection function documents another code it converts k itself.
+func (k IntersectionKind) code() int {
+	switch k {
+	case IntersectionPoint, IntersectionSegment, IntersectionLine:
+		return 1
+	case IntersectionTwoPoints, IntersectionCircle:
+		return 2
+	case IntersectionCoincident, IntersectionCoplanar:
+		return -1
+	case IntersectionContained:
+		return -2
+	case IntersectionParallelPairs:
+		return -3
+	}
+	return 0
+}
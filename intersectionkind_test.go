@@ -0,0 +1,85 @@
+package geometry
+
+import (
+	"testing"
+)
+
+func TestIntersectionKindString(t *testing.T) {
+	for k, want := range map[IntersectionKind]string{
+		IntersectionNone:          "None",
+		IntersectionTwoPoints:     "TwoPoints",
+		IntersectionParallelPairs: "ParallelPairs",
+		IntersectionContained:     "Contained",
+		IntersectionKind(-1):      "IntersectionKind(-1)",
+		IntersectionKind(100):     "IntersectionKind(100)",
+	} {
+		if got := k.String(); got != want {
+			t.Error("IntersectionKind.String", int(k), "want", want, "got", got)
+		}
+	}
+}
+
+func TestIntersectKinds(t *testing.T) {
+	var p, q Vector2D
+	c := &Circle{Vector2D{0, 0}, 1}
+	if k := Intersect2DCircleCircle(c, c, &p, &q); k != IntersectionCoincident {
+		t.Error("Intersect2DCircleCircle", c, c, "want", IntersectionCoincident, "got", k)
+	}
+	l1 := &Line2D{Vector2D{0, 0}, Vector2D{1, 1}}
+	l2 := &Line2D{Vector2D{1, 0}, Vector2D{1, 1}}
+	if k := Intersect2DFuzzyLineLine(l1, l2, &p); k != IntersectionParallel {
+		t.Error("Intersect2DFuzzyLineLine", l1, l2, "want", IntersectionParallel, "got", k)
+	}
+	if k := Intersect2DFuzzyLineSegmentLineSegment(l1, l2, &p); k != IntersectionParallel {
+		t.Error("Intersect2DFuzzyLineSegmentLineSegment", l1, l2, "want", IntersectionParallel, "got", k)
+	}
+	if k, _, _ := Intersect2DRayAABB(l1, &AABB2D{Vector2D{2, 2}, Vector2D{3, 3}}); k != IntersectionSegment {
+		t.Error("Intersect2DRayAABB", l1, "want", IntersectionSegment, "got", k)
+	}
+	if k := Intersect2DLineCircle(&Line2D{Vector2D{-2, 1}, Vector2D{1, 0}}, c, &p, &q); k != IntersectionPoint {
+		t.Error("Intersect2DLineCircle want", IntersectionPoint, "got", k)
+	}
+
+	var z Vector3D
+	var l Line3D
+	var s Circle3D
+	p1, p2, p3 := &Plane{1, 0, 0, 0}, &Plane{1, 0, 0, 1}, &Plane{1, 1, 0, 0}
+	if k := Intersect3DFuzzyPlanePlanePlane(p1, p2, p3, &z); k != IntersectionParallelPairs {
+		t.Error("Intersect3DFuzzyPlanePlanePlane", p1, p2, p3, "want", IntersectionParallelPairs, "got", k)
+	}
+	if k := Intersect3DFuzzyPlanePlanePlane(p1, &Plane{0, 1, 0, 0}, p3, &z); k != IntersectionLine {
+		t.Error("Intersect3DFuzzyPlanePlanePlane", "want", IntersectionLine, "got", k)
+	}
+	if k := Intersect3DRobustPlanePlane(p1, p2, &l); k != IntersectionParallel {
+		t.Error("Intersect3DRobustPlanePlane", p1, p2, "want", IntersectionParallel, "got", k)
+	}
+	s1, s2 := &Sphere{Vector3D{0, 0, 0}, 3}, &Sphere{Vector3D{1, 0, 0}, 1}
+	if k := Intersect3DSphereSphere(s1, s2, &s); k != IntersectionContained {
+		t.Error("Intersect3DSphereSphere", s1, s2, "want", IntersectionContained, "got", k)
+	}
+	if k := Intersect3DPlaneSphere(p1, s1, &s); k != IntersectionCircle {
+		t.Error("Intersect3DPlaneSphere", p1, s1, "want", IntersectionCircle, "got", k)
+	}
+	tr := &Triangle3D{Vector3D{0, 0, 0}, Vector3D{1, 0, 0}, Vector3D{0, 1, 0}}
+	if k, _, _, _ := Intersect3DRayTriangle(&Line3D{Vector3D{0.5, 0, 0}, Vector3D{-1, 0.5, 0}}, tr, false, &z); k != IntersectionCoplanar {
+		t.Error("Intersect3DRayTriangle want", IntersectionCoplanar, "got", k)
+	}
+}
+
+func TestIntersectionKindCodes(t *testing.T) {
+	// the int codes of the Intersection functions are unchanged
+	p1, p2, p3 := &Plane{1, 0, 0, 0}, &Plane{0, 1, 0, 0}, &Plane{1, 1, 0, 0}
+	var z Vector3D
+	if n := Intersection3DFuzzyPlanePlanePlane(p1, p2, p3, &z); n != -2 {
+		t.Error("Intersection3DFuzzyPlanePlanePlane want", -2, "got", n)
+	}
+	var l Line3D
+	if n := Intersection3DPlanePlane(p1, p2, &l); n != 1 {
+		t.Error("Intersection3DPlanePlane want", 1, "got", n)
+	}
+	s1, s2 := &Sphere{Vector3D{0, 0, 0}, 3}, &Sphere{Vector3D{1, 0, 0}, 1}
+	var c Circle3D
+	if n := Intersection3DSphereSphere(s1, s2, &c); n != -2 {
+		t.Error("Intersection3DSphereSphere want", -2, "got", n)
+	}
+}
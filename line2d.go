@@ -38,14 +38,19 @@ func (a *Line2D) Equal(b *Line2D) bool {
 // FuzzyEqual compares a and b and returns true of they are very close or false
 // otherwise.
 func (a *Line2D) FuzzyEqual(b *Line2D) bool {
-	if FuzzyEqual(a.V.X, 0) {
-		if FuzzyEqual(b.V.X, 0) {
-			return FuzzyEqual(a.P.X, b.P.X)
+	return DefaultTolerance.Line2DEqual(a, b)
+}
+
+// Line2DEqual is like Line2D.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) Line2DEqual(a, b *Line2D) bool {
+	if t.Equal(a.V.X, 0) {
+		if t.Equal(b.V.X, 0) {
+			return t.Equal(a.P.X, b.P.X)
 		}
 		return false
 	}
 	am, bm := a.V.Y/a.V.X, b.V.Y/b.V.X
-	return FuzzyEqual(am, bm) && FuzzyEqual(a.P.Y-am*a.P.X, b.P.Y-bm*b.P.X)
+	return t.Equal(am, bm) && t.Equal(a.P.Y-am*a.P.X, b.P.Y-bm*b.P.X)
 }
 
 // Length returns the length of line segment x.
@@ -73,6 +78,14 @@ func (x *Line2D) Normal(z *Vector2D) *Vector2D {
 	return z
 }
 
+// SegmentBounds sets z to the smallest box containing line segment x then
+// returns z.
+func (x *Line2D) SegmentBounds(z *AABB2D) *AABB2D {
+	z.Min.X, z.Max.X = math.Min(x.P.X, x.P.X+x.V.X), math.Max(x.P.X, x.P.X+x.V.X)
+	z.Min.Y, z.Max.Y = math.Min(x.P.Y, x.P.Y+x.V.Y), math.Max(x.P.Y, x.P.Y+x.V.Y)
+	return z
+}
+
 // SegmentEqual compares a and b and returns true if the line segments are
 // exactly equal and false otherwise.
 func (a *Line2D) SegmentEqual(b *Line2D) bool {
@@ -83,11 +96,26 @@ func (a *Line2D) SegmentEqual(b *Line2D) bool {
 // SegmentFuzzyEqual compares a and b as line segments and returns true if they
 // are very close and false otherwise.
 func (a *Line2D) SegmentFuzzyEqual(b *Line2D) bool {
-	return (a.P.FuzzyEqual(&b.P) && a.V.FuzzyEqual(&b.V)) || (FuzzyEqual(a.P.X, b.P.X+b.V.X) &&
-		FuzzyEqual(a.P.Y, b.P.Y+b.V.Y) && FuzzyEqual(a.P.X+a.V.X, b.P.X) && FuzzyEqual(a.P.Y+a.V.Y, b.P.Y))
+	return DefaultTolerance.Line2DSegmentEqual(a, b)
+}
+
+// Line2DSegmentEqual is like Line2D.SegmentFuzzyEqual but compares with
+// tolerance t.
+func (t *Tolerance) Line2DSegmentEqual(a, b *Line2D) bool {
+	return (t.Vector2DEqual(&a.P, &b.P) && t.Vector2DEqual(&a.V, &b.V)) || (t.Equal(a.P.X, b.P.X+b.V.X) &&
+		t.Equal(a.P.Y, b.P.Y+b.V.Y) && t.Equal(a.P.X+a.V.X, b.P.X) && t.Equal(a.P.Y+a.V.Y, b.P.Y))
 }
 
 // Slope returns the slope of x.
 func (x *Line2D) Slope() float64 {
 	return x.V.Y / x.V.X
 }
+
+// Transform sets z to x transformed by m, P as a point and V as a vector,
+// then returns z. Affine transforms keep the meaning of V for lines, rays,
+// and line segments alike.
+func (z *Line2D) Transform(m *Affine2D, x *Line2D) *Line2D {
+	z.P.TransformPoint(m, &x.P)
+	z.V.TransformVector(m, &x.V)
+	return z
+}
@@ -256,3 +256,13 @@ func Benchmark_Line2D_Slope(b *testing.B) {
 		l.Slope()
 	}
 }
+
+func TestLine2DTransform(t *testing.T) {
+	var m Affine2D
+	var l Line2D
+	m.Compose(&Vector2D{1, 0}, &Vector2D{2, 3}, math.Pi/2, 0)
+	want := &Line2D{Vector2D{1, 2}, Vector2D{-3, 2}}
+	if !l.Transform(&m, &Line2D{Vector2D{1, 0}, Vector2D{1, 1}}).SegmentFuzzyEqual(want) {
+		t.Error("Line2D.Transform", "want", want, "got", l)
+	}
+}
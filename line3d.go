@@ -48,22 +48,27 @@ func (a *Line3D) Equal(b *Line3D) bool {
 // FuzzyEqual compares a and b and returns true if they are very close or false
 // otherwise.
 func (a *Line3D) FuzzyEqual(b *Line3D) bool {
+	return DefaultTolerance.Line3DEqual(a, b)
+}
+
+// Line3DEqual is like Line3D.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) Line3DEqual(a, b *Line3D) bool {
 	// check if b.P lies on a
 	u := (a.V.X*(b.P.X-a.P.X) + a.V.Y*(b.P.Y-a.P.Y) + a.V.Z*(b.P.Z-a.P.Z)) / (a.V.X*a.V.X + a.V.Y*a.V.Y + a.V.Z*a.V.Z)
 	d := math.Abs(b.P.X-(a.P.X+a.V.X*u)) + math.Abs(b.P.Y-(a.P.Y+a.V.Y*u)) + math.Abs(b.P.Z-(a.P.Z+a.V.Z*u))
-	if !FuzzyEqual(d, 0) {
+	if !t.Equal(d, 0) {
 		return false
 	}
 	// check if the direction of the two lines is equal
-	if FuzzyEqual(a.V.X, 0) && FuzzyEqual(b.V.X, 0) {
-		if FuzzyEqual(a.V.Y, 0) && FuzzyEqual(b.V.Y, 0) {
+	if t.Equal(a.V.X, 0) && t.Equal(b.V.X, 0) {
+		if t.Equal(a.V.Y, 0) && t.Equal(b.V.Y, 0) {
 			return true
 		}
 		iady, ibdy := 1/a.V.Y, 1/b.V.Y
-		return FuzzyEqual(a.V.X*iady-b.V.X*ibdy, 0) && FuzzyEqual(a.V.Z*iady-b.V.Z*ibdy, 0)
+		return t.Equal(a.V.X*iady-b.V.X*ibdy, 0) && t.Equal(a.V.Z*iady-b.V.Z*ibdy, 0)
 	}
 	iadx, ibdx := 1/a.V.X, 1/b.V.X
-	return FuzzyEqual(a.V.Y*iadx-b.V.Y*ibdx, 0) && FuzzyEqual(a.V.Z*iadx-b.V.Z*ibdx, 0)
+	return t.Equal(a.V.Y*iadx-b.V.Y*ibdx, 0) && t.Equal(a.V.Z*iadx-b.V.Z*ibdx, 0)
 }
 
 // Length returns the length of line segment x.
@@ -84,6 +89,15 @@ func (x *Line3D) Midpoint(z *Vector3D) *Vector3D {
 	return z
 }
 
+// SegmentBounds sets z to the smallest box containing line segment x then
+// returns z.
+func (x *Line3D) SegmentBounds(z *AABB3D) *AABB3D {
+	z.Min.X, z.Max.X = math.Min(x.P.X, x.P.X+x.V.X), math.Max(x.P.X, x.P.X+x.V.X)
+	z.Min.Y, z.Max.Y = math.Min(x.P.Y, x.P.Y+x.V.Y), math.Max(x.P.Y, x.P.Y+x.V.Y)
+	z.Min.Z, z.Max.Z = math.Min(x.P.Z, x.P.Z+x.V.Z), math.Max(x.P.Z, x.P.Z+x.V.Z)
+	return z
+}
+
 // SegmentEqual compares line segments a and b and returns true if they are
 // exactly equal or false otherwise.
 func (a *Line3D) SegmentEqual(b *Line3D) bool {
@@ -94,7 +108,33 @@ func (a *Line3D) SegmentEqual(b *Line3D) bool {
 // SegmentFuzzyEqual compares line segments a and b and returns true if they
 // are very close and false otherwise.
 func (a *Line3D) SegmentFuzzyEqual(b *Line3D) bool {
-	return (a.P.FuzzyEqual(&b.P) && a.V.FuzzyEqual(&b.V)) || (FuzzyEqual(a.P.X+a.V.X, b.P.X) &&
-		FuzzyEqual(a.P.Y+a.V.Y, b.P.Y) && FuzzyEqual(a.P.Z+a.V.Z, b.P.Z) && FuzzyEqual(-a.V.X, b.V.X) &&
-		FuzzyEqual(-a.V.Y, b.V.Y) && FuzzyEqual(-a.V.Z, b.V.Z))
+	return DefaultTolerance.Line3DSegmentEqual(a, b)
+}
+
+// Line3DSegmentEqual is like Line3D.SegmentFuzzyEqual but compares with
+// tolerance t.
+func (t *Tolerance) Line3DSegmentEqual(a, b *Line3D) bool {
+	return (t.Vector3DEqual(&a.P, &b.P) && t.Vector3DEqual(&a.V, &b.V)) || (t.Equal(a.P.X+a.V.X, b.P.X) &&
+		t.Equal(a.P.Y+a.V.Y, b.P.Y) && t.Equal(a.P.Z+a.V.Z, b.P.Z) && t.Equal(-a.V.X, b.V.X) &&
+		t.Equal(-a.V.Y, b.V.Y) && t.Equal(-a.V.Z, b.V.Z))
+}
+
+// SegmentTransform sets z to line segment x with both end points transformed
+// by m then returns z. Unlike Transform it is correct for projective
+// transforms.
+func (z *Line3D) SegmentTransform(m *Matrix4x4, x *Line3D) *Line3D {
+	var q Vector3D
+	q.Add(&x.P, &x.V).TransformPoint(m, &q)
+	z.P.TransformPoint(m, &x.P)
+	z.V.Subtract(&q, &z.P)
+	return z
+}
+
+// Transform sets z to x transformed by affine transform m, P as a point and V
+// as a direction, then returns z. This keeps the meaning of V for lines, rays,
+// and line segments alike.
+func (z *Line3D) Transform(m *Matrix4x4, x *Line3D) *Line3D {
+	z.P.TransformPoint(m, &x.P)
+	z.V.TransformDirection(m, &x.V)
+	return z
 }
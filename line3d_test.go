@@ -228,3 +228,23 @@ func Benchmark_Line3D_SegmentFuzzyEqual(b *testing.B) {
 		l1.SegmentFuzzyEqual(l2)
 	}
 }
+
+func TestLine3DTransform(t *testing.T) {
+	var m Matrix4x4
+	var got Line3D
+	l := &Line3D{Vector3D{1, 0, 0}, Vector3D{1, 1, 0}}
+	m.Multiply(m.Translation(&Vector3D{0, 0, 1}), new(Matrix4x4).Scaling(&Vector3D{2, 3, 4}))
+	want := &Line3D{Vector3D{2, 0, 1}, Vector3D{2, 3, 0}}
+	if !got.Transform(&m, l).SegmentFuzzyEqual(want) {
+		t.Error("Line3D.Transform", "want", want, "got", got)
+	}
+	if !got.SegmentTransform(&m, l).SegmentFuzzyEqual(want) {
+		t.Error("Line3D.SegmentTransform", "want", want, "got", got)
+	}
+	m.Perspective(math.Pi/2, 1, 1, 3)
+	l = &Line3D{Vector3D{1, 1, -1}, Vector3D{2, 2, -2}}
+	want = &Line3D{Vector3D{1, 1, -1}, Vector3D{0, 0, 2}}
+	if !got.SegmentTransform(&m, l).SegmentFuzzyEqual(want) {
+		t.Error("Line3D.SegmentTransform perspective", "want", want, "got", got)
+	}
+}
@@ -0,0 +1,118 @@
+package geometry
+
+import (
+	"math"
+)
+
+// A Matrix3x3 is a 3x3 matrix stored in row major order. It operates on
+// column vectors, so a Vector3D v is transformed by M as M*v.
+type Matrix3x3 [3][3]float64
+
+// Copy sets z to x then returns z.
+func (z *Matrix3x3) Copy(x *Matrix3x3) *Matrix3x3 {
+	*z = *x
+	return z
+}
+
+// Determinant returns the determinant of x.
+func (x *Matrix3x3) Determinant() float64 {
+	return x[0][0]*(x[1][1]*x[2][2]-x[1][2]*x[2][1]) -
+		x[0][1]*(x[1][0]*x[2][2]-x[1][2]*x[2][0]) +
+		x[0][2]*(x[1][0]*x[2][1]-x[1][1]*x[2][0])
+}
+
+// Equal compares a and b then returns true if they are exactly equal or false
+// otherwise.
+func (a *Matrix3x3) Equal(b *Matrix3x3) bool {
+	return *a == *b
+}
+
+// FromMatrix4x4 sets z to the upper left 3x3 (linear) part of x then returns
+// z.
+func (z *Matrix3x3) FromMatrix4x4(x *Matrix4x4) *Matrix3x3 {
+	for i := 0; i < 3; i++ {
+		z[i][0] = x[i][0]
+		z[i][1] = x[i][1]
+		z[i][2] = x[i][2]
+	}
+	return z
+}
+
+// FuzzyEqual compares a and b then returns true if they are very close or
+// false otherwise.
+func (a *Matrix3x3) FuzzyEqual(b *Matrix3x3) bool {
+	return DefaultTolerance.Matrix3x3Equal(a, b)
+}
+
+// Matrix3x3Equal is like Matrix3x3.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) Matrix3x3Equal(a, b *Matrix3x3) bool {
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if !t.Equal(a[i][j], b[i][j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Identity sets z to the identity matrix then returns z.
+func (z *Matrix3x3) Identity() *Matrix3x3 {
+	*z = Matrix3x3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	return z
+}
+
+// Inverse sets z to the inverse of x then returns z. If x is singular the
+// elements of z will be infinite or NaN.
+func (z *Matrix3x3) Inverse(x *Matrix3x3) *Matrix3x3 {
+	c00 := x[1][1]*x[2][2] - x[1][2]*x[2][1]
+	c01 := x[1][2]*x[2][0] - x[1][0]*x[2][2]
+	c02 := x[1][0]*x[2][1] - x[1][1]*x[2][0]
+	d := 1 / (x[0][0]*c00 + x[0][1]*c01 + x[0][2]*c02)
+	*z = Matrix3x3{
+		{c00 * d, (x[0][2]*x[2][1] - x[0][1]*x[2][2]) * d, (x[0][1]*x[1][2] - x[0][2]*x[1][1]) * d},
+		{c01 * d, (x[0][0]*x[2][2] - x[0][2]*x[2][0]) * d, (x[0][2]*x[1][0] - x[0][0]*x[1][2]) * d},
+		{c02 * d, (x[0][1]*x[2][0] - x[0][0]*x[2][1]) * d, (x[0][0]*x[1][1] - x[0][1]*x[1][0]) * d},
+	}
+	return z
+}
+
+// Multiply sets z to the matrix product a*b then returns z.
+func (z *Matrix3x3) Multiply(a, b *Matrix3x3) *Matrix3x3 {
+	var r Matrix3x3
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			r[i][j] = a[i][0]*b[0][j] + a[i][1]*b[1][j] + a[i][2]*b[2][j]
+		}
+	}
+	*z = r
+	return z
+}
+
+// Rotation sets z to a counterclockwise rotation of angle radians about axis
+// (not necessarily normalized) then returns z.
+func (z *Matrix3x3) Rotation(axis *Vector3D, angle float64) *Matrix3x3 {
+	l := 1 / math.Sqrt(axis.X*axis.X+axis.Y*axis.Y+axis.Z*axis.Z)
+	x, y, w := axis.X*l, axis.Y*l, axis.Z*l
+	s, c := math.Sincos(angle)
+	t := 1 - c
+	*z = Matrix3x3{
+		{t*x*x + c, t*x*y - s*w, t*x*w + s*y},
+		{t*x*y + s*w, t*y*y + c, t*y*w - s*x},
+		{t*x*w - s*y, t*y*w + s*x, t*w*w + c},
+	}
+	return z
+}
+
+// Scaling sets z to a scale by the components of s then returns z.
+func (z *Matrix3x3) Scaling(s *Vector3D) *Matrix3x3 {
+	*z = Matrix3x3{{s.X, 0, 0}, {0, s.Y, 0}, {0, 0, s.Z}}
+	return z
+}
+
+// Transpose sets z to the transpose of x then returns z.
+func (z *Matrix3x3) Transpose(x *Matrix3x3) *Matrix3x3 {
+	z[0][0], z[0][1], z[0][2], z[1][0], z[1][1], z[1][2], z[2][0], z[2][1], z[2][2] =
+		x[0][0], x[1][0], x[2][0], x[0][1], x[1][1], x[2][1], x[0][2], x[1][2], x[2][2]
+	return z
+}
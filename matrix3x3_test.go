@@ -0,0 +1,68 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMatrix3x3Determinant(t *testing.T) {
+	m := &Matrix3x3{{2, 0, 1}, {1, 3, 2}, {1, 1, 2}}
+	if got := m.Determinant(); got != 6 {
+		t.Error("Matrix3x3.Determinant", "want", 6, "got", got)
+	}
+}
+
+func TestMatrix3x3Inverse(t *testing.T) {
+	m := &Matrix3x3{{2, 0, 1}, {1, 3, 2}, {1, 1, 2}}
+	var inv, r, id Matrix3x3
+	if !r.Multiply(m, inv.Inverse(m)).FuzzyEqual(id.Identity()) {
+		t.Error("Matrix3x3.Inverse", m, "got", inv)
+	}
+	r.Copy(m)
+	if !r.Inverse(&r).FuzzyEqual(&inv) {
+		t.Error("Matrix3x3.Inverse in place", m, "got", r)
+	}
+}
+
+func Benchmark_Matrix3x3_Inverse(b *testing.B) {
+	m := &Matrix3x3{{2, 0, 1}, {1, 3, 2}, {1, 1, 2}}
+	var r Matrix3x3
+	for i := 0; i < b.N; i++ {
+		r.Inverse(m)
+	}
+}
+
+func TestMatrix3x3Multiply(t *testing.T) {
+	a := &Matrix3x3{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+	b := &Matrix3x3{{9, 8, 7}, {6, 5, 4}, {3, 2, 1}}
+	want := &Matrix3x3{{30, 24, 18}, {84, 69, 54}, {138, 114, 90}}
+	var r Matrix3x3
+	if !r.Multiply(a, b).Equal(want) {
+		t.Error("Matrix3x3.Multiply", "want", want, "got", r)
+	}
+	r.Copy(a)
+	if !r.Multiply(&r, b).Equal(want) {
+		t.Error("Matrix3x3.Multiply in place", "want", want, "got", r)
+	}
+}
+
+func TestMatrix3x3Rotation(t *testing.T) {
+	var m Matrix3x3
+	var v Vector3D
+	m.Rotation(&Vector3D{0, 0, 2}, math.Pi/2)
+	if !v.Transform(&m, &Vector3D{1, 0, 0}).FuzzyEqual(&Vector3D{0, 1, 0}) {
+		t.Error("Matrix3x3.Rotation", "got", v)
+	}
+	m.Rotation(&Vector3D{1, 1, 1}, 2*math.Pi/3)
+	if !v.Transform(&m, &Vector3D{1, 0, 0}).FuzzyEqual(&Vector3D{0, 1, 0}) {
+		t.Error("Matrix3x3.Rotation", "got", v)
+	}
+}
+
+func TestMatrix3x3Transpose(t *testing.T) {
+	m := Matrix3x3{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+	want := &Matrix3x3{{1, 4, 7}, {2, 5, 8}, {3, 6, 9}}
+	if !m.Transpose(&m).Equal(want) {
+		t.Error("Matrix3x3.Transpose", "want", want, "got", m)
+	}
+}
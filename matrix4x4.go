@@ -0,0 +1,241 @@
+package geometry
+
+import (
+	"math"
+)
+
+// A Matrix4x4 is a 4x4 matrix stored in row major order representing an affine
+// or projective transform in homogeneous coordinates. It operates on column
+// vectors, so a Vector3D v is transformed by M as M*(v, 1) and the translation
+// is held in the last column.
+type Matrix4x4 [4][4]float64
+
+// Copy sets z to x then returns z.
+func (z *Matrix4x4) Copy(x *Matrix4x4) *Matrix4x4 {
+	*z = *x
+	return z
+}
+
+// Determinant returns the determinant of x.
+func (x *Matrix4x4) Determinant() float64 {
+	s0 := x[0][0]*x[1][1] - x[1][0]*x[0][1]
+	s1 := x[0][0]*x[1][2] - x[1][0]*x[0][2]
+	s2 := x[0][0]*x[1][3] - x[1][0]*x[0][3]
+	s3 := x[0][1]*x[1][2] - x[1][1]*x[0][2]
+	s4 := x[0][1]*x[1][3] - x[1][1]*x[0][3]
+	s5 := x[0][2]*x[1][3] - x[1][2]*x[0][3]
+	c5 := x[2][2]*x[3][3] - x[3][2]*x[2][3]
+	c4 := x[2][1]*x[3][3] - x[3][1]*x[2][3]
+	c3 := x[2][1]*x[3][2] - x[3][1]*x[2][2]
+	c2 := x[2][0]*x[3][3] - x[3][0]*x[2][3]
+	c1 := x[2][0]*x[3][2] - x[3][0]*x[2][2]
+	c0 := x[2][0]*x[3][1] - x[3][0]*x[2][1]
+	return s0*c5 - s1*c4 + s2*c3 + s3*c2 - s4*c1 + s5*c0
+}
+
+// Equal compares a and b then returns true if they are exactly equal or false
+// otherwise.
+func (a *Matrix4x4) Equal(b *Matrix4x4) bool {
+	return *a == *b
+}
+
+// FromMatrix3x3 sets z to the affine transform with linear part x and no
+// translation then returns z.
+func (z *Matrix4x4) FromMatrix3x3(x *Matrix3x3) *Matrix4x4 {
+	*z = Matrix4x4{
+		{x[0][0], x[0][1], x[0][2], 0},
+		{x[1][0], x[1][1], x[1][2], 0},
+		{x[2][0], x[2][1], x[2][2], 0},
+		{0, 0, 0, 1},
+	}
+	return z
+}
+
+// FuzzyEqual compares a and b then returns true if they are very close or
+// false otherwise.
+func (a *Matrix4x4) FuzzyEqual(b *Matrix4x4) bool {
+	return DefaultTolerance.Matrix4x4Equal(a, b)
+}
+
+// Matrix4x4Equal is like Matrix4x4.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) Matrix4x4Equal(a, b *Matrix4x4) bool {
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			if !t.Equal(a[i][j], b[i][j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Identity sets z to the identity matrix then returns z.
+func (z *Matrix4x4) Identity() *Matrix4x4 {
+	*z = Matrix4x4{{1, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 1}}
+	return z
+}
+
+// Inverse sets z to the inverse of x then returns z. If x is singular the
+// elements of z will be infinite or NaN.
+func (z *Matrix4x4) Inverse(x *Matrix4x4) *Matrix4x4 {
+	// http://www.geometrictools.com/Documentation/LaplaceExpansionTheorem.pdf
+	s0 := x[0][0]*x[1][1] - x[1][0]*x[0][1]
+	s1 := x[0][0]*x[1][2] - x[1][0]*x[0][2]
+	s2 := x[0][0]*x[1][3] - x[1][0]*x[0][3]
+	s3 := x[0][1]*x[1][2] - x[1][1]*x[0][2]
+	s4 := x[0][1]*x[1][3] - x[1][1]*x[0][3]
+	s5 := x[0][2]*x[1][3] - x[1][2]*x[0][3]
+	c5 := x[2][2]*x[3][3] - x[3][2]*x[2][3]
+	c4 := x[2][1]*x[3][3] - x[3][1]*x[2][3]
+	c3 := x[2][1]*x[3][2] - x[3][1]*x[2][2]
+	c2 := x[2][0]*x[3][3] - x[3][0]*x[2][3]
+	c1 := x[2][0]*x[3][2] - x[3][0]*x[2][2]
+	c0 := x[2][0]*x[3][1] - x[3][0]*x[2][1]
+	d := 1 / (s0*c5 - s1*c4 + s2*c3 + s3*c2 - s4*c1 + s5*c0)
+	*z = Matrix4x4{
+		{
+			(x[1][1]*c5 - x[1][2]*c4 + x[1][3]*c3) * d,
+			(-x[0][1]*c5 + x[0][2]*c4 - x[0][3]*c3) * d,
+			(x[3][1]*s5 - x[3][2]*s4 + x[3][3]*s3) * d,
+			(-x[2][1]*s5 + x[2][2]*s4 - x[2][3]*s3) * d,
+		},
+		{
+			(-x[1][0]*c5 + x[1][2]*c2 - x[1][3]*c1) * d,
+			(x[0][0]*c5 - x[0][2]*c2 + x[0][3]*c1) * d,
+			(-x[3][0]*s5 + x[3][2]*s2 - x[3][3]*s1) * d,
+			(x[2][0]*s5 - x[2][2]*s2 + x[2][3]*s1) * d,
+		},
+		{
+			(x[1][0]*c4 - x[1][1]*c2 + x[1][3]*c0) * d,
+			(-x[0][0]*c4 + x[0][1]*c2 - x[0][3]*c0) * d,
+			(x[3][0]*s4 - x[3][1]*s2 + x[3][3]*s0) * d,
+			(-x[2][0]*s4 + x[2][1]*s2 - x[2][3]*s0) * d,
+		},
+		{
+			(-x[1][0]*c3 + x[1][1]*c1 - x[1][2]*c0) * d,
+			(x[0][0]*c3 - x[0][1]*c1 + x[0][2]*c0) * d,
+			(-x[3][0]*s3 + x[3][1]*s1 - x[3][2]*s0) * d,
+			(x[2][0]*s3 - x[2][1]*s1 + x[2][2]*s0) * d,
+		},
+	}
+	return z
+}
+
+// IsSimilarity returns true if x is very close to a similarity transform, one
+// made only of translation, rotation, reflection, and uniform scaling, or
+// false otherwise. Similarity transforms map spheres to spheres.
+func (x *Matrix4x4) IsSimilarity() bool {
+	if x[3][0] != 0 || x[3][1] != 0 || x[3][2] != 0 || x[3][3] != 1 {
+		return false
+	}
+	var c [3]Vector3D
+	for i := range c {
+		c[i] = Vector3D{x[0][i], x[1][i], x[2][i]}
+	}
+	l := c[0].MagnitudeSquared()
+	return FuzzyEqual(l, c[1].MagnitudeSquared()) && FuzzyEqual(l, c[2].MagnitudeSquared()) &&
+		FuzzyEqual(c[0].DotProduct(&c[1])/l, 0) && FuzzyEqual(c[0].DotProduct(&c[2])/l, 0) &&
+		FuzzyEqual(c[1].DotProduct(&c[2])/l, 0)
+}
+
+// LookAt sets z to a right handed view transform for a viewer at eye looking
+// towards center with up (not necessarily normalized or perpendicular to the
+// view direction) pointing upwards, then returns z. The viewer looks down the
+// negative z axis after the transform.
+func (z *Matrix4x4) LookAt(eye, center, up *Vector3D) *Matrix4x4 {
+	var f, s, u Vector3D
+	f.Subtract(center, eye).Normalize()
+	s.CrossProduct(&f, up).Normalize()
+	u.CrossProduct(&s, &f)
+	*z = Matrix4x4{
+		{s.X, s.Y, s.Z, -s.DotProduct(eye)},
+		{u.X, u.Y, u.Z, -u.DotProduct(eye)},
+		{-f.X, -f.Y, -f.Z, f.DotProduct(eye)},
+		{0, 0, 0, 1},
+	}
+	return z
+}
+
+// Multiply sets z to the matrix product a*b, the transform that applies b then
+// a, then returns z.
+func (z *Matrix4x4) Multiply(a, b *Matrix4x4) *Matrix4x4 {
+	var r Matrix4x4
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			r[i][j] = a[i][0]*b[0][j] + a[i][1]*b[1][j] + a[i][2]*b[2][j] + a[i][3]*b[3][j]
+		}
+	}
+	*z = r
+	return z
+}
+
+// Perspective sets z to a right handed perspective projection with a vertical
+// field of view of fovy, a width to height ratio of aspect, and near and far
+// clipping planes at the given (positive) distances, then returns z. The view
+// frustum is mapped to the cube from -1 to 1 on each axis.
+func (z *Matrix4x4) Perspective(fovy, aspect, near, far float64) *Matrix4x4 {
+	f := 1 / math.Tan(fovy/2)
+	d := 1 / (near - far)
+	*z = Matrix4x4{
+		{f / aspect, 0, 0, 0},
+		{0, f, 0, 0},
+		{0, 0, (far + near) * d, 2 * far * near * d},
+		{0, 0, -1, 0},
+	}
+	return z
+}
+
+// Rotation sets z to a counterclockwise rotation of angle radians about axis
+// (not necessarily normalized) through the origin, then returns z.
+func (z *Matrix4x4) Rotation(axis *Vector3D, angle float64) *Matrix4x4 {
+	var r Matrix3x3
+	return z.FromMatrix3x3(r.Rotation(axis, angle))
+}
+
+// RotationX sets z to a counterclockwise rotation of angle radians about the x
+// axis then returns z.
+func (z *Matrix4x4) RotationX(angle float64) *Matrix4x4 {
+	s, c := math.Sincos(angle)
+	*z = Matrix4x4{{1, 0, 0, 0}, {0, c, -s, 0}, {0, s, c, 0}, {0, 0, 0, 1}}
+	return z
+}
+
+// RotationY sets z to a counterclockwise rotation of angle radians about the y
+// axis then returns z.
+func (z *Matrix4x4) RotationY(angle float64) *Matrix4x4 {
+	s, c := math.Sincos(angle)
+	*z = Matrix4x4{{c, 0, s, 0}, {0, 1, 0, 0}, {-s, 0, c, 0}, {0, 0, 0, 1}}
+	return z
+}
+
+// RotationZ sets z to a counterclockwise rotation of angle radians about the z
+// axis then returns z.
+func (z *Matrix4x4) RotationZ(angle float64) *Matrix4x4 {
+	s, c := math.Sincos(angle)
+	*z = Matrix4x4{{c, -s, 0, 0}, {s, c, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 1}}
+	return z
+}
+
+// Scaling sets z to a scale by the components of s about the origin then
+// returns z.
+func (z *Matrix4x4) Scaling(s *Vector3D) *Matrix4x4 {
+	*z = Matrix4x4{{s.X, 0, 0, 0}, {0, s.Y, 0, 0}, {0, 0, s.Z, 0}, {0, 0, 0, 1}}
+	return z
+}
+
+// Translation sets z to a translation by t then returns z.
+func (z *Matrix4x4) Translation(t *Vector3D) *Matrix4x4 {
+	*z = Matrix4x4{{1, 0, 0, t.X}, {0, 1, 0, t.Y}, {0, 0, 1, t.Z}, {0, 0, 0, 1}}
+	return z
+}
+
+// Transpose sets z to the transpose of x then returns z.
+func (z *Matrix4x4) Transpose(x *Matrix4x4) *Matrix4x4 {
+	r := *x
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			z[i][j] = r[j][i]
+		}
+	}
+	return z
+}
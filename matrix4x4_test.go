@@ -0,0 +1,125 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+var matrix4x4TestValue = Matrix4x4{{2, 0, 1, 3}, {1, 3, 2, -1}, {1, 1, 1, 2}, {0, 1, 0, 1}}
+
+func TestMatrix4x4Determinant(t *testing.T) {
+	if got := matrix4x4TestValue.Determinant(); got != 4 {
+		t.Error("Matrix4x4.Determinant", "want", 4, "got", got)
+	}
+	var m Matrix4x4
+	m.Scaling(&Vector3D{2, 3, 4})
+	if got := m.Determinant(); got != 24 {
+		t.Error("Matrix4x4.Determinant", "want", 24, "got", got)
+	}
+}
+
+func TestMatrix4x4Inverse(t *testing.T) {
+	m := &matrix4x4TestValue
+	var inv, r, id Matrix4x4
+	if !r.Multiply(m, inv.Inverse(m)).FuzzyEqual(id.Identity()) {
+		t.Error("Matrix4x4.Inverse", m, "got", inv)
+	}
+	if !r.Multiply(&inv, m).FuzzyEqual(&id) {
+		t.Error("Matrix4x4.Inverse", m, "got", inv)
+	}
+	r.Copy(m)
+	if !r.Inverse(&r).FuzzyEqual(&inv) {
+		t.Error("Matrix4x4.Inverse in place", m, "got", r)
+	}
+	r.Scaling(&Vector3D{1, 0, 1})
+	if r.Inverse(&r); !math.IsInf(r[0][0], 0) && !math.IsNaN(r[0][0]) {
+		t.Error("Matrix4x4.Inverse singular", "got", r)
+	}
+}
+
+func Benchmark_Matrix4x4_Inverse(b *testing.B) {
+	var r Matrix4x4
+	for i := 0; i < b.N; i++ {
+		r.Inverse(&matrix4x4TestValue)
+	}
+}
+
+func TestMatrix4x4LookAt(t *testing.T) {
+	var m Matrix4x4
+	var v Vector3D
+	eye, center, up := &Vector3D{1, 2, 3}, &Vector3D{1, 2, -7}, &Vector3D{0, 5, 0}
+	m.LookAt(eye, center, up)
+	if !v.TransformPoint(&m, eye).FuzzyEqual(&Vector3D{}) {
+		t.Error("Matrix4x4.LookAt eye", "got", v)
+	}
+	if !v.TransformPoint(&m, center).FuzzyEqual(&Vector3D{0, 0, -10}) {
+		t.Error("Matrix4x4.LookAt center", "got", v)
+	}
+	if !v.TransformDirection(&m, up).FuzzyEqual(&Vector3D{0, 5, 0}) {
+		t.Error("Matrix4x4.LookAt up", "got", v)
+	}
+}
+
+func TestMatrix4x4Multiply(t *testing.T) {
+	var tr, rz, m Matrix4x4
+	var v Vector3D
+	tr.Translation(&Vector3D{1, 2, 3})
+	rz.RotationZ(math.Pi / 2)
+	// rotate then translate
+	m.Multiply(&tr, &rz)
+	if !v.TransformPoint(&m, &Vector3D{1, 0, 0}).FuzzyEqual(&Vector3D{1, 3, 3}) {
+		t.Error("Matrix4x4.Multiply", "got", v)
+	}
+	m.Copy(&tr)
+	m.Multiply(&rz, &m)
+	if !v.TransformPoint(&m, &Vector3D{1, 0, 0}).FuzzyEqual(&Vector3D{-2, 2, 3}) {
+		t.Error("Matrix4x4.Multiply in place", "got", v)
+	}
+}
+
+func Benchmark_Matrix4x4_Multiply(b *testing.B) {
+	var r Matrix4x4
+	for i := 0; i < b.N; i++ {
+		r.Multiply(&matrix4x4TestValue, &matrix4x4TestValue)
+	}
+}
+
+func TestMatrix4x4Perspective(t *testing.T) {
+	var m Matrix4x4
+	var v Vector3D
+	m.Perspective(math.Pi/2, 2, 1, 10)
+	if !v.TransformPoint(&m, &Vector3D{2, 1, -1}).FuzzyEqual(&Vector3D{1, 1, -1}) {
+		t.Error("Matrix4x4.Perspective near", "got", v)
+	}
+	if !v.TransformPoint(&m, &Vector3D{-20, -10, -10}).FuzzyEqual(&Vector3D{-1, -1, 1}) {
+		t.Error("Matrix4x4.Perspective far", "got", v)
+	}
+}
+
+func TestMatrix4x4Rotation(t *testing.T) {
+	var m, r Matrix4x4
+	if !m.Rotation(&Vector3D{1, 0, 0}, 0.3).FuzzyEqual(r.RotationX(0.3)) {
+		t.Error("Matrix4x4.RotationX", "want", m, "got", r)
+	}
+	if !m.Rotation(&Vector3D{0, 1, 0}, 0.3).FuzzyEqual(r.RotationY(0.3)) {
+		t.Error("Matrix4x4.RotationY", "want", m, "got", r)
+	}
+	if !m.Rotation(&Vector3D{0, 0, 1}, 0.3).FuzzyEqual(r.RotationZ(0.3)) {
+		t.Error("Matrix4x4.RotationZ", "want", m, "got", r)
+	}
+}
+
+func TestMatrix4x4Transpose(t *testing.T) {
+	var m Matrix4x4
+	m.Transpose(&matrix4x4TestValue)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			if m[i][j] != matrix4x4TestValue[j][i] {
+				t.Fatal("Matrix4x4.Transpose", "got", m)
+			}
+		}
+	}
+	if !m.Transpose(&m).Equal(&matrix4x4TestValue) {
+		t.Error("Matrix4x4.Transpose in place", "got", m)
+	}
+}
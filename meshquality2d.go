@@ -0,0 +1,54 @@
+package geometry
+
+import (
+	"math"
+)
+
+// A MeshQuality2D summarizes the shapes of the triangles of a mesh.
+type MeshQuality2D struct {
+	Triangles       int     // number of triangles
+	MinAngle        float64 // smallest angle in radians
+	MaxAngle        float64 // largest angle in radians
+	MinArea         float64 // smallest area
+	MaxArea         float64 // largest area
+	MaxAspectRatio  float64 // largest aspect ratio
+	MeanAspectRatio float64 // mean aspect ratio
+}
+
+// FromTriangles sets z to the quality of the triangles, given as indices into
+// points, then returns z. The aspect ratio of a triangle is its circumradius
+// over twice its inradius, 1 for an equilateral triangle and infinite for a
+// degenerate one. No triangles give the zero value.
+func (z *MeshQuality2D) FromTriangles(points []Vector2D, triangles [][3]int) *MeshQuality2D {
+	*z = MeshQuality2D{Triangles: len(triangles)}
+	if len(triangles) == 0 {
+		return z
+	}
+	z.MinAngle, z.MinArea = math.Inf(1), math.Inf(1)
+	for _, v := range triangles {
+		var l [3]float64
+		for i := range v {
+			a, b, c := &points[v[i]], &points[v[(i+1)%3]], &points[v[(i+2)%3]]
+			ux, uy := b.X-a.X, b.Y-a.Y
+			wx, wy := c.X-a.X, c.Y-a.Y
+			angle := math.Atan2(math.Abs(ux*wy-uy*wx), ux*wx+uy*wy)
+			z.MinAngle = math.Min(z.MinAngle, angle)
+			z.MaxAngle = math.Max(z.MaxAngle, angle)
+			l[i] = math.Hypot(ux, uy)
+		}
+		t := Triangle2D{points[v[0]], points[v[1]], points[v[2]]}
+		area := t.Area()
+		z.MinArea = math.Min(z.MinArea, area)
+		z.MaxArea = math.Max(z.MaxArea, area)
+		// R = abc/(4A) and r = A/s for semiperimeter s
+		s := (l[0] + l[1] + l[2]) / 2
+		ratio := math.Inf(1)
+		if area > 0 {
+			ratio = l[0] * l[1] * l[2] * s / (8 * area * area)
+		}
+		z.MaxAspectRatio = math.Max(z.MaxAspectRatio, ratio)
+		z.MeanAspectRatio += ratio
+	}
+	z.MeanAspectRatio /= float64(len(triangles))
+	return z
+}
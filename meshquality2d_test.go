@@ -0,0 +1,34 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMeshQuality2DFromTriangles(t *testing.T) {
+	var q MeshQuality2D
+	h := math.Sqrt(3) / 2
+	points := []Vector2D{{0, 0}, {1, 0}, {0.5, h}, {0, 1}, {2, 0}}
+	q.FromTriangles(points, [][3]int{{0, 1, 2}})
+	if q.Triangles != 1 || !FuzzyEqual(q.MinAngle, math.Pi/3) || !FuzzyEqual(q.MaxAngle, math.Pi/3) ||
+		!FuzzyEqual(q.MaxAspectRatio, 1) || !FuzzyEqual(q.MinArea, h/2) {
+		t.Error("MeshQuality2D.FromTriangles equilateral got", q)
+	}
+	q.FromTriangles(points, [][3]int{{0, 1, 2}, {0, 4, 3}})
+	if q.Triangles != 2 || !FuzzyEqual(q.MinAngle, math.Atan(0.5)) || !FuzzyEqual(q.MaxAngle, math.Pi/2) ||
+		!FuzzyEqual(q.MaxArea, 1) || !FuzzyEqual(q.MinArea, h/2) {
+		t.Error("MeshQuality2D.FromTriangles got", q)
+	}
+	// a right triangle with legs 1 and 2 has R = sqrt(5)/2 and r = (3-sqrt(5))/2
+	want := math.Sqrt(5) / 2 / (3 - math.Sqrt(5))
+	if !FuzzyEqual(q.MaxAspectRatio, want) || !FuzzyEqual(q.MeanAspectRatio, (want+1)/2) {
+		t.Error("MeshQuality2D.FromTriangles aspect ratio want", want, "got", q)
+	}
+	q.FromTriangles(points, [][3]int{{0, 1, 4}})
+	if !math.IsInf(q.MaxAspectRatio, 1) || q.MinArea != 0 {
+		t.Error("MeshQuality2D.FromTriangles degenerate got", q)
+	}
+	if *q.FromTriangles(points, nil) != (MeshQuality2D{}) {
+		t.Error("MeshQuality2D.FromTriangles empty got", q)
+	}
+}
@@ -0,0 +1,40 @@
+package geometry
+
+// A MultiPolygon2D is a set of polygons, the result of operations such as
+// Union that can produce several separate pieces. The polygons' interiors
+// are expected not to overlap, though they may touch.
+type MultiPolygon2D []Polygon2D
+
+// Area returns the unsigned area of the polygons.
+func (x *MultiPolygon2D) Area() float64 {
+	a := 0.0
+	for i := range *x {
+		p := &(*x)[i]
+		if v := p.Area(); v < 0 {
+			a -= v
+		} else {
+			a += v
+		}
+	}
+	return a
+}
+
+// Contains determines where point p lies relative to the polygons, as
+// Polygon2D.Contains does for each.
+//
+// Possible return values are:
+// -1 if p is outside every polygon.
+// 0 if p is on the boundary of a polygon and inside none.
+// 1 if p is inside a polygon.
+func (x *MultiPolygon2D) Contains(p *Vector2D) int {
+	z := -1
+	for i := range *x {
+		if c := (*x)[i].Contains(p); c > z {
+			z = c
+			if z == 1 {
+				break
+			}
+		}
+	}
+	return z
+}
@@ -0,0 +1,328 @@
+package geometry
+
+import (
+	"math"
+)
+
+// An OBB3D is an oriented bounding box with center C, orthonormal axes Axes
+// forming a right handed frame, and half extents E along each axis.
+type OBB3D struct {
+	C    Vector3D
+	Axes [3]Vector3D
+	E    [3]float64
+}
+
+// Bounds sets z to the smallest axis aligned box containing x then returns z.
+func (x *OBB3D) Bounds(z *AABB3D) *AABB3D {
+	var r Vector3D
+	for i := range x.Axes {
+		r.X += x.E[i] * math.Abs(x.Axes[i].X)
+		r.Y += x.E[i] * math.Abs(x.Axes[i].Y)
+		r.Z += x.E[i] * math.Abs(x.Axes[i].Z)
+	}
+	z.Min.Subtract(&x.C, &r)
+	z.Max.Add(&x.C, &r)
+	return z
+}
+
+// ClassifyPlane determines which side of plane p box x is on.
+//
+// Possible return values are:
+// -1 if x is entirely behind p, on the side opposite its normal.
+// 0 if x touches or straddles p.
+// 1 if x is entirely in front of p.
+func (x *OBB3D) ClassifyPlane(p *Plane) int {
+	n := Vector3D{p.A, p.B, p.C}
+	r := 0.0
+	for i := range x.Axes {
+		r += x.E[i] * math.Abs(n.DotProduct(&x.Axes[i]))
+	}
+	s := n.DotProduct(&x.C) + p.D
+	if s > r {
+		return 1
+	}
+	if s < -r {
+		return -1
+	}
+	return 0
+}
+
+// ClosestPoint sets z to the point in x closest to p then returns z.
+func (x *OBB3D) ClosestPoint(p, z *Vector3D) *Vector3D {
+	// Ericson, Real-Time Collision Detection, 5.1.4
+	var d Vector3D
+	d.Subtract(p, &x.C)
+	r := x.C
+	for i := range x.Axes {
+		t := math.Max(-x.E[i], math.Min(d.DotProduct(&x.Axes[i]), x.E[i]))
+		r.X += t * x.Axes[i].X
+		r.Y += t * x.Axes[i].Y
+		r.Z += t * x.Axes[i].Z
+	}
+	*z = r
+	return z
+}
+
+// Contains returns true if point p is inside or on x or false otherwise.
+func (x *OBB3D) Contains(p *Vector3D) bool {
+	var d Vector3D
+	d.Subtract(p, &x.C)
+	for i := range x.Axes {
+		if math.Abs(d.DotProduct(&x.Axes[i])) > x.E[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Copy sets z to x then returns z.
+func (z *OBB3D) Copy(x *OBB3D) *OBB3D {
+	*z = *x
+	return z
+}
+
+// Equal compares a and b then returns true if they are exactly equal or false
+// otherwise.
+func (a *OBB3D) Equal(b *OBB3D) bool {
+	return *a == *b
+}
+
+// FromAABB3D sets z to the box equivalent to x with the coordinate axes as
+// its axes then returns z.
+func (z *OBB3D) FromAABB3D(x *AABB3D) *OBB3D {
+	x.Center(&z.C)
+	z.Axes = [3]Vector3D{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	z.E = [3]float64{(x.Max.X - x.Min.X) / 2, (x.Max.Y - x.Min.Y) / 2, (x.Max.Z - x.Min.Z) / 2}
+	return z
+}
+
+// FromPoints sets z to a box containing points, with its axes along the
+// principal components of the points, then returns z. The box is quick to
+// compute but can be noticeably larger than the smallest box, see
+// FromPointsTight. There must be at least one point.
+func (z *OBB3D) FromPoints(points []Vector3D) *OBB3D {
+	var m Vector3D
+	for i := range points {
+		m.Add(&m, &points[i])
+	}
+	m.Scale(&m, 1/float64(len(points)))
+	var c Matrix3x3
+	for i := range points {
+		d := [3]float64{points[i].X - m.X, points[i].Y - m.Y, points[i].Z - m.Z}
+		for j := 0; j < 3; j++ {
+			for k := j; k < 3; k++ {
+				c[j][k] += d[j] * d[k]
+			}
+		}
+	}
+	c[1][0], c[2][0], c[2][1] = c[0][1], c[0][2], c[1][2]
+	var v Matrix3x3
+	symmetricEigen3(&c, &v)
+	z.Axes[0] = Vector3D{v[0][0], v[1][0], v[2][0]}
+	z.Axes[1] = Vector3D{v[0][1], v[1][1], v[2][1]}
+	z.Axes[0].Normalize()
+	z.Axes[1].Normalize()
+	z.Axes[2].CrossProduct(&z.Axes[0], &z.Axes[1])
+	return z.fit(points)
+}
+
+// FromPointsTight sets z to a box containing points then returns z. Besides
+// the principal components of the points it tries every frame made by a face
+// normal and an edge of the convex hull of the points and keeps the box with
+// the least volume, which is much closer to the smallest box than FromPoints
+// but slower. There must be at least one point.
+func (z *OBB3D) FromPointsTight(points []Vector3D) *OBB3D {
+	z.FromPoints(points)
+	var h Hull3D
+	if ConvexHull3D(points, &h) != 3 {
+		return z
+	}
+	// only the hull vertices affect the box
+	var hp []Vector3D
+	seen := make(map[int]bool)
+	for _, f := range h.Faces {
+		for _, i := range f {
+			if !seen[i] {
+				seen[i] = true
+				hp = append(hp, points[i])
+			}
+		}
+	}
+	best := z.Volume()
+	var b OBB3D
+	for fi, f := range h.Faces {
+		h.Normal(fi, &b.Axes[0])
+		for j := 0; j < 3; j++ {
+			b.Axes[1].Subtract(&points[f[(j+1)%3]], &points[f[j]]).Normalize()
+			b.Axes[2].CrossProduct(&b.Axes[0], &b.Axes[1])
+			if v := b.fit(hp).Volume(); v < best {
+				*z, best = b, v
+			}
+		}
+	}
+	return z
+}
+
+// FuzzyEqual compares a and b then returns true if they are very close or
+// false otherwise.
+func (a *OBB3D) FuzzyEqual(b *OBB3D) bool {
+	return DefaultTolerance.OBB3DEqual(a, b)
+}
+
+// OBB3DEqual is like OBB3D.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) OBB3DEqual(a, b *OBB3D) bool {
+	if !t.Vector3DEqual(&a.C, &b.C) {
+		return false
+	}
+	for i := range a.Axes {
+		if !t.Vector3DEqual(&a.Axes[i], &b.Axes[i]) || !t.Equal(a.E[i], b.E[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Overlaps returns true if a and b overlap or touch or false otherwise.
+func (a *OBB3D) Overlaps(b *OBB3D) bool {
+	// Ericson, Real-Time Collision Detection, 4.4.1, testing the 15 potential
+	// separating axes: the 3 axes of each box and the 9 cross products
+	var r, ar [3][3]float64
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			r[i][j] = a.Axes[i].DotProduct(&b.Axes[j])
+			// counteract arithmetic errors when two edges are parallel and
+			// their cross product is near zero
+			ar[i][j] = math.Abs(r[i][j]) + 1e-12
+		}
+	}
+	var d Vector3D
+	d.Subtract(&b.C, &a.C)
+	t := [3]float64{d.DotProduct(&a.Axes[0]), d.DotProduct(&a.Axes[1]), d.DotProduct(&a.Axes[2])}
+	ae, be := &a.E, &b.E
+
+	for i := 0; i < 3; i++ {
+		if math.Abs(t[i]) > ae[i]+be[0]*ar[i][0]+be[1]*ar[i][1]+be[2]*ar[i][2] {
+			return false
+		}
+	}
+	for j := 0; j < 3; j++ {
+		if math.Abs(t[0]*r[0][j]+t[1]*r[1][j]+t[2]*r[2][j]) > ae[0]*ar[0][j]+ae[1]*ar[1][j]+ae[2]*ar[2][j]+be[j] {
+			return false
+		}
+	}
+	for i := 0; i < 3; i++ {
+		i1, i2 := (i+1)%3, (i+2)%3
+		for j := 0; j < 3; j++ {
+			j1, j2 := (j+1)%3, (j+2)%3
+			ra := ae[i1]*ar[i2][j] + ae[i2]*ar[i1][j]
+			rb := be[j1]*ar[i][j2] + be[j2]*ar[i][j1]
+			if math.Abs(t[i2]*r[i1][j]-t[i1]*r[i2][j]) > ra+rb {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Support returns the point of x furthest in direction d.
+func (x *OBB3D) Support(d Vector3D) Vector3D {
+	p := x.C
+	for i := range x.Axes {
+		e := x.E[i]
+		if d.DotProduct(&x.Axes[i]) < 0 {
+			e = -e
+		}
+		p.X += e * x.Axes[i].X
+		p.Y += e * x.Axes[i].Y
+		p.Z += e * x.Axes[i].Z
+	}
+	return p
+}
+
+// SurfaceArea returns the surface area of x.
+func (x *OBB3D) SurfaceArea() float64 {
+	return 8 * (x.E[0]*x.E[1] + x.E[1]*x.E[2] + x.E[2]*x.E[0])
+}
+
+// Volume returns the volume of x.
+func (x *OBB3D) Volume() float64 {
+	return 8 * x.E[0] * x.E[1] * x.E[2]
+}
+
+// fit sets the center and extents of z to the smallest box with z's axes
+// containing points then returns z.
+func (z *OBB3D) fit(points []Vector3D) *OBB3D {
+	var lo, hi [3]float64
+	for i := range lo {
+		lo[i], hi[i] = math.Inf(1), math.Inf(-1)
+	}
+	for i := range points {
+		for j := range z.Axes {
+			d := points[i].DotProduct(&z.Axes[j])
+			lo[j] = math.Min(lo[j], d)
+			hi[j] = math.Max(hi[j], d)
+		}
+	}
+	z.C = Vector3D{}
+	for j := range z.Axes {
+		m := (lo[j] + hi[j]) / 2
+		z.C.X += m * z.Axes[j].X
+		z.C.Y += m * z.Axes[j].Y
+		z.C.Z += m * z.Axes[j].Z
+		z.E[j] = (hi[j] - lo[j]) / 2
+	}
+	return z
+}
+
+// symmetricEigen3 sets the columns of z to the unit eigenvectors of symmetric
+// matrix x, in order of decreasing eigenvalue, then returns the eigenvalues.
+func symmetricEigen3(x, z *Matrix3x3) [3]float64 {
+	// cyclic Jacobi, Golub and Van Loan, Matrix Computations, 8.4
+	a := *x
+	z.Identity()
+	for sweep := 0; sweep < 50; sweep++ {
+		off := a[0][1]*a[0][1] + a[0][2]*a[0][2] + a[1][2]*a[1][2]
+		if off == 0 || off < 1e-30*(a[0][0]*a[0][0]+a[1][1]*a[1][1]+a[2][2]*a[2][2]) {
+			break
+		}
+		for p := 0; p < 2; p++ {
+			for q := p + 1; q < 3; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for k := 0; k < 3; k++ {
+					akp, akq := a[k][p], a[k][q]
+					a[k][p], a[k][q] = c*akp-s*akq, s*akp+c*akq
+				}
+				for k := 0; k < 3; k++ {
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k], a[q][k] = c*apk-s*aqk, s*apk+c*aqk
+				}
+				for k := 0; k < 3; k++ {
+					zkp, zkq := z[k][p], z[k][q]
+					z[k][p], z[k][q] = c*zkp-s*zkq, s*zkp+c*zkq
+				}
+			}
+		}
+	}
+	e := [3]float64{a[0][0], a[1][1], a[2][2]}
+	// sort the eigenvalues and their eigenvectors
+	for i := 0; i < 2; i++ {
+		for j := i + 1; j < 3; j++ {
+			if e[j] > e[i] {
+				e[i], e[j] = e[j], e[i]
+				for k := 0; k < 3; k++ {
+					z[k][i], z[k][j] = z[k][j], z[k][i]
+				}
+			}
+		}
+	}
+	return e
+}
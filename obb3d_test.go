@@ -0,0 +1,161 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// obb3DRotated returns a box with center c and half extents e rotated by angle
+// about axis.
+func obb3DRotated(c Vector3D, e [3]float64, axis Vector3D, angle float64) *OBB3D {
+	var m Matrix3x3
+	m.Rotation(&axis, angle)
+	b := &OBB3D{C: c, E: e}
+	for i := range b.Axes {
+		b.Axes[i] = Vector3D{m[0][i], m[1][i], m[2][i]}
+	}
+	return b
+}
+
+func TestOBB3DBounds(t *testing.T) {
+	b := obb3DRotated(Vector3D{1, 2, 3}, [3]float64{1, 1, 1}, Vector3D{0, 0, 1}, math.Pi/4)
+	var a AABB3D
+	want := AABB3D{Vector3D{1 - math.Sqrt2, 2 - math.Sqrt2, 2}, Vector3D{1 + math.Sqrt2, 2 + math.Sqrt2, 4}}
+	if !b.Bounds(&a).FuzzyEqual(&want) {
+		t.Error("OBB3D.Bounds", b, "want", want, "got", a)
+	}
+}
+
+func TestOBB3DClassifyPlane(t *testing.T) {
+	b := obb3DRotated(Vector3D{0, 0, 0}, [3]float64{1, 1, 1}, Vector3D{0, 0, 1}, math.Pi/4)
+	for _, v := range []struct {
+		p Plane
+		c int
+	}{
+		{Plane{1, 0, 0, -1.5}, -1},
+		{Plane{1, 0, 0, -1.4}, 0},
+		{Plane{-2, 0, 0, 3}, 1},
+		{Plane{-2, 0, 0, -3}, -1},
+		{Plane{0, 0, 1, -1}, 0},
+		{Plane{0, 0, 1, 1.1}, 1},
+	} {
+		if c := b.ClassifyPlane(&v.p); c != v.c {
+			t.Error("OBB3D.ClassifyPlane", b, v.p, "want", v.c, "got", c)
+		}
+	}
+}
+
+func TestOBB3DClosestPoint(t *testing.T) {
+	b := obb3DRotated(Vector3D{0, 0, 0}, [3]float64{1, 2, 3}, Vector3D{0, 0, 1}, math.Pi/2)
+	var c Vector3D
+	if !b.ClosestPoint(&Vector3D{5, 5, 5}, &c).FuzzyEqual(&Vector3D{2, 1, 3}) {
+		t.Error("OBB3D.ClosestPoint", b, "got", c)
+	}
+	if !b.ClosestPoint(&Vector3D{0.5, 0.5, 0.5}, &c).FuzzyEqual(&Vector3D{0.5, 0.5, 0.5}) {
+		t.Error("OBB3D.ClosestPoint", b, "got", c)
+	}
+	if !b.Contains(&Vector3D{1.9, 0.9, -2.9}) || b.Contains(&Vector3D{0.9, 1.9, 0}) {
+		t.Error("OBB3D.Contains", b)
+	}
+}
+
+func TestOBB3DFromPoints(t *testing.T) {
+	// the corners of a rotated box, which PCA recovers exactly
+	want := obb3DRotated(Vector3D{1, 2, 3}, [3]float64{4, 2, 1}, Vector3D{1, 1, 0}, 0.5)
+	var points []Vector3D
+	for _, s := range [][3]float64{{-1, -1, -1}, {-1, -1, 1}, {-1, 1, -1}, {-1, 1, 1},
+		{1, -1, -1}, {1, -1, 1}, {1, 1, -1}, {1, 1, 1}} {
+		p := want.C
+		for i := range want.Axes {
+			var a Vector3D
+			a.Scale(&want.Axes[i], s[i]*want.E[i])
+			p.Add(&p, &a)
+		}
+		points = append(points, p)
+	}
+	var b OBB3D
+	b.FromPoints(points)
+	if !FuzzyEqual(b.Volume(), want.Volume()) || !b.C.FuzzyEqual(&want.C) {
+		t.Error("OBB3D.FromPoints", "want", want, "got", b)
+	}
+	for i := range points {
+		if !b.Contains(&points[i]) && !FuzzyEqual(Distance3DPointPoint(b.ClosestPoint(&points[i], &Vector3D{}), &points[i]), 0) {
+			t.Error("OBB3D.FromPoints", "does not contain", points[i])
+		}
+	}
+	if b.FromPointsTight(points); !FuzzyEqual(b.Volume(), want.Volume()) {
+		t.Error("OBB3D.FromPointsTight", "want", want.Volume(), "got", b.Volume())
+	}
+}
+
+func TestOBB3DFromPointsTight(t *testing.T) {
+	// a regular tetrahedron has isotropic covariance so PCA picks arbitrary
+	// axes, the hull edges find a box no larger than the cube it sits in
+	points := []Vector3D{{1, 1, 1}, {1, -1, -1}, {-1, 1, -1}, {-1, -1, 1}}
+	var b OBB3D
+	b.FromPointsTight(points)
+	if b.Volume() > 8+1e-9 {
+		t.Error("OBB3D.FromPointsTight", "want at most", 8, "got", b.Volume())
+	}
+	r := rand.New(rand.NewSource(1))
+	points = points[:0]
+	for i := 0; i < 200; i++ {
+		points = append(points, Vector3D{r.Float64() * 4, r.Float64(), r.Float64() * 2})
+	}
+	var p OBB3D
+	p.FromPoints(points)
+	b.FromPointsTight(points)
+	if b.Volume() > p.Volume()*(1+1e-12) {
+		t.Error("OBB3D.FromPointsTight", "larger than FromPoints", b.Volume(), p.Volume())
+	}
+	for i := range points {
+		var c Vector3D
+		if !FuzzyEqual(Distance3DPointPoint(b.ClosestPoint(&points[i], &c), &points[i])+1, 1) {
+			t.Error("OBB3D.FromPointsTight", "does not contain", points[i])
+		}
+	}
+	for i := range b.Axes {
+		if !FuzzyEqual(b.Axes[i].Magnitude(), 1) || !FuzzyEqual(b.Axes[i].DotProduct(&b.Axes[(i+1)%3])+1, 1) {
+			t.Error("OBB3D.FromPointsTight", "axes not orthonormal", b.Axes)
+		}
+	}
+}
+
+type obb3DOverlapsData struct {
+	a, b    *OBB3D
+	overlap bool
+}
+
+var obb3DOverlapsValues = []obb3DOverlapsData{
+	{obb3DRotated(Vector3D{}, [3]float64{1, 1, 1}, Vector3D{0, 0, 1}, 0),
+		obb3DRotated(Vector3D{1.5, 0, 0}, [3]float64{1, 1, 1}, Vector3D{0, 0, 1}, 0), true},
+	{obb3DRotated(Vector3D{}, [3]float64{1, 1, 1}, Vector3D{0, 0, 1}, 0),
+		obb3DRotated(Vector3D{2.5, 0, 0}, [3]float64{1, 1, 1}, Vector3D{0, 0, 1}, 0), false},
+	// a face axis of b separates
+	{obb3DRotated(Vector3D{}, [3]float64{1, 1, 1}, Vector3D{0, 0, 1}, 0),
+		obb3DRotated(Vector3D{2.3, 0, 0}, [3]float64{1, 1, 1}, Vector3D{0, 0, 1}, math.Pi/4), true},
+	{obb3DRotated(Vector3D{}, [3]float64{1, 1, 1}, Vector3D{0, 0, 1}, 0),
+		obb3DRotated(Vector3D{2.5, 0, 0}, [3]float64{1, 1, 1}, Vector3D{0, 0, 1}, math.Pi/4), false},
+	// crossed ridges, only the cross product of the edges separates
+	{obb3DRotated(Vector3D{}, [3]float64{1, 1, 1}, Vector3D{1, 0, 0}, math.Pi/4),
+		obb3DRotated(Vector3D{0, 0, 3}, [3]float64{1, 1, 1}, Vector3D{0, 1, 0}, math.Pi/4), false},
+	{obb3DRotated(Vector3D{}, [3]float64{1, 1, 1}, Vector3D{1, 0, 0}, math.Pi/4),
+		obb3DRotated(Vector3D{0, 0, 2.7}, [3]float64{1, 1, 1}, Vector3D{0, 1, 0}, math.Pi/4), true},
+}
+
+func TestOBB3DOverlaps(t *testing.T) {
+	for _, v := range obb3DOverlapsValues {
+		if v.a.Overlaps(v.b) != v.overlap || v.b.Overlaps(v.a) != v.overlap {
+			t.Error("OBB3D.Overlaps", v.a, v.b, "want", v.overlap)
+		}
+	}
+}
+
+func Benchmark_OBB3D_Overlaps(b *testing.B) {
+	x := obb3DRotated(Vector3D{}, [3]float64{1, 1, 1}, Vector3D{1, 0, 0}, math.Pi/4)
+	y := obb3DRotated(Vector3D{0, 0, 2.7}, [3]float64{1, 1, 1}, Vector3D{0, 1, 0}, math.Pi/4)
+	for i := 0; i < b.N; i++ {
+		x.Overlaps(y)
+	}
+}
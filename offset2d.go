@@ -0,0 +1,225 @@
+package geometry
+
+import (
+	"math"
+)
+
+// An OffsetJoin selects how an offset fills the gap at a corner where the
+// offset edges move apart.
+type OffsetJoin int
+
+const (
+	// OffsetJoinMiter extends the edges until they meet, beveling the corner
+	// instead if they would meet further than MiterLimit times the distance
+	// from it.
+	OffsetJoinMiter OffsetJoin = iota
+
+	// OffsetJoinRound joins the edges with an arc about the corner.
+	OffsetJoinRound
+
+	// OffsetJoinBevel joins the ends of the edges with a line.
+	OffsetJoinBevel
+
+	// OffsetJoinSquare extends the edges until they reach a line square to
+	// the corner's bisector at the distance from the corner.
+	OffsetJoinSquare
+)
+
+// An OffsetCap selects how an offset ends an open path.
+type OffsetCap int
+
+const (
+	// OffsetCapButt ends the offset square at the path's end points.
+	OffsetCapButt OffsetCap = iota
+
+	// OffsetCapRound ends the offset with a half circle about the path's end
+	// points.
+	OffsetCapRound
+
+	// OffsetCapSquare extends the offset past the path's end points by the
+	// distance and ends it square.
+	OffsetCapSquare
+)
+
+// An OffsetStyle2D describes the corners and ends of an offset.
+type OffsetStyle2D struct {
+	Join OffsetJoin
+	Cap  OffsetCap
+
+	// MiterLimit is how many times the distance a miter may reach from its
+	// corner, 2 if zero.
+	MiterLimit float64
+
+	// ArcTolerance is the greatest distance of the chords of round joins and
+	// caps from their arcs, 1e-3 of the distance if zero.
+	ArcTolerance float64
+}
+
+// DefaultOffsetStyle2D is the style used by Offset and OffsetPolyline when
+// given nil, mitered corners and butt ends.
+var DefaultOffsetStyle2D = OffsetStyle2D{OffsetJoinMiter, OffsetCapButt, 2, 0}
+
+// Offset sets z to x grown by distance d, or shrunk if d is negative, then
+// returns z. Each edge moves d away from the interior of its polygon, corners
+// where the edges move apart are joined as s describes and where they
+// overlap are trimmed. Parts narrower than twice -d vanish, holes that close
+// are removed and polygons that grow into each other are merged, the result
+// having the form of Union's. s may be nil for DefaultOffsetStyle2D.
+func (z *MultiPolygon2D) Offset(x *MultiPolygon2D, d float64, s *OffsetStyle2D) *MultiPolygon2D {
+	if s == nil {
+		s = &DefaultOffsetStyle2D
+	}
+	var rings [][]Vector2D
+	for _, r := range x.rings() {
+		rings = append(rings, offsetRing2D(r, false, d, s))
+	}
+	*z = polygonBoolean2D([2][][]Vector2D{rings, nil}, offsetInside2D)
+	return z
+}
+
+// OffsetPolyline sets z to the region covered by the open path through points
+// widened by distance |d| to either side then returns z. Corners are joined
+// and the ends capped as s describes, a path of one point being capped as if
+// it ran along X. Where the path crosses or doubles back on itself the
+// region is merged, the result having the form of Union's. s may be nil for
+// DefaultOffsetStyle2D.
+func (z *MultiPolygon2D) OffsetPolyline(points []Vector2D, d float64, s *OffsetStyle2D) *MultiPolygon2D {
+	if s == nil {
+		s = &DefaultOffsetStyle2D
+	}
+	if d == 0 {
+		*z = nil
+		return z
+	}
+	r := offsetRing2D(points, true, math.Abs(d), s)
+	*z = polygonBoolean2D([2][][]Vector2D{{r}, nil}, offsetInside2D)
+	return z
+}
+
+// offsetInside2D returns true for the winding numbers of points inside an
+// offset. Raw offset rings loop backwards where edges overlap, so only a
+// positive winding number is inside.
+func offsetInside2D(w [2]int) bool {
+	return w[0] > 0
+}
+
+// offsetRing2D returns ring r with each edge moved d to its right and the
+// corners joined, which for the counterclockwise outer rings and clockwise
+// holes of a polygon is away from its interior. An open r is offset as the
+// ring running along it and back, with caps at its ends.
+func offsetRing2D(r []Vector2D, open bool, d float64, s *OffsetStyle2D) []Vector2D {
+	var p []Vector2D
+	for i := range r {
+		if len(p) == 0 || r[i] != p[len(p)-1] {
+			p = append(p, r[i])
+		}
+	}
+	for !open && len(p) > 1 && p[len(p)-1] == p[0] {
+		p = p[:len(p)-1]
+	}
+	if d == 0 || len(p) == 0 || (len(p) == 1 && !open) {
+		return p
+	}
+
+	var edges []Line2D
+	if len(p) == 1 {
+		edges = []Line2D{{p[0], Vector2D{1, 0}}, {p[0], Vector2D{-1, 0}}}
+	} else {
+		if open {
+			for i := len(p) - 2; i > 0; i-- {
+				p = append(p, p[i])
+			}
+		}
+		edges = make([]Line2D, len(p))
+		for i := range p {
+			edges[i].P = p[i]
+			edges[i].V.Subtract(&p[(i+1)%len(p)], &p[i])
+		}
+	}
+	normals := make([]Vector2D, len(edges))
+	for i := range edges {
+		edges[i].Normal(&normals[i])
+		normals[i].Scale(&normals[i], 1/edges[i].Length())
+	}
+
+	var z []Vector2D
+	n := len(edges)
+	for i := range edges {
+		h := (i + n - 1) % n
+		join := s.Join
+		if open && (i == 0 || i == n/2) {
+			join = [...]OffsetJoin{
+				OffsetCapButt:   OffsetJoinBevel,
+				OffsetCapRound:  OffsetJoinRound,
+				OffsetCapSquare: OffsetJoinSquare,
+			}[s.Cap]
+		}
+		z = offsetCorner2D(z, &edges[i].P, &normals[h], &normals[i], d, join, s)
+	}
+	return z
+}
+
+// offsetCorner2D appends to z the offset by d of the corner at p between
+// edges with unit right normals a and b then returns z.
+func offsetCorner2D(z []Vector2D, p, a, b *Vector2D, d float64, join OffsetJoin, s *OffsetStyle2D) []Vector2D {
+	var m1, m2, q Vector2D
+	m1.Scale(a, d)
+	m2.Scale(b, d)
+	c := a.X*b.Y - a.Y*b.X
+	dot := a.DotProduct(b)
+
+	// where the offset edges overlap they cross near the corner, going
+	// through the corner keeps the loop between them from having a positive
+	// winding number
+	if c*d < 0 || (c == 0 && dot > 0) {
+		z = append(z, *q.Add(p, &m1))
+		if dot < 0.99 {
+			z = append(z, *p)
+		}
+		return append(z, *q.Add(p, &m2))
+	}
+
+	// theta is the turn between the edges, from 0 to pi
+	theta := math.Atan2(math.Abs(c), dot)
+	switch join {
+	case OffsetJoinMiter:
+		limit := s.MiterLimit
+		if limit == 0 {
+			limit = 2
+		}
+		// the miter is 1/cos(theta/2) times the distance from the corner
+		if 1+dot > 0 && 2 <= limit*limit*(1+dot) {
+			q.Add(&m1, &m2)
+			q.Scale(&q, 1/(1+dot))
+			return append(z, *q.Add(&q, p))
+		}
+	case OffsetJoinRound:
+		tol := s.ArcTolerance
+		if tol <= 0 {
+			tol = 1e-3 * math.Abs(d)
+		}
+		// a chord spanning angle t is 1-cos(t/2) times the radius from
+		// its arc
+		step := 2 * math.Acos(1-math.Min(tol/math.Abs(d), 1))
+		n := int(math.Max(math.Ceil(theta/step), 1))
+		t := math.Copysign(theta/float64(n), d)
+		sin, cos := math.Sincos(t)
+		q = m1
+		for k := 0; k < n; k++ {
+			var v Vector2D
+			z = append(z, *v.Add(p, &q))
+			q.X, q.Y = q.X*cos-q.Y*sin, q.X*sin+q.Y*cos
+		}
+		return append(z, *q.Add(p, &m2))
+	case OffsetJoinSquare:
+		// each edge is extended tan(theta/4) times the distance, where it
+		// meets the square line
+		t := math.Abs(d) * math.Tan(theta/4)
+		q.Add(p, &m1)
+		z = append(z, Vector2D{q.X - a.Y*t, q.Y + a.X*t})
+		q.Add(p, &m2)
+		return append(z, Vector2D{q.X + b.Y*t, q.Y - b.X*t})
+	}
+	z = append(z, *q.Add(p, &m1))
+	return append(z, *q.Add(p, &m2))
+}
@@ -0,0 +1,132 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+var offset2DValues = []struct {
+	x        MultiPolygon2D
+	d        float64
+	style    OffsetStyle2D
+	area     float64
+	polygons int
+}{
+	// each join growing a square
+	{MultiPolygon2D{boolean2DSquare(0, 0, 2, 2)}, 1, OffsetStyle2D{Join: OffsetJoinMiter}, 16, 1},
+	{MultiPolygon2D{boolean2DSquare(0, 0, 2, 2)}, 1, OffsetStyle2D{Join: OffsetJoinBevel}, 14, 1},
+	{MultiPolygon2D{boolean2DSquare(0, 0, 2, 2)}, 1, OffsetStyle2D{Join: OffsetJoinSquare}, 4 + 8*math.Sqrt2, 1},
+	{MultiPolygon2D{boolean2DSquare(0, 0, 2, 2)}, 1, OffsetStyle2D{Join: OffsetJoinRound, ArcTolerance: 1e-6}, 12 + math.Pi, 1},
+	// a miter past its limit is beveled
+	{MultiPolygon2D{boolean2DSquare(0, 0, 2, 2)}, 1, OffsetStyle2D{Join: OffsetJoinMiter, MiterLimit: 1.4}, 14, 1},
+	// shrinking, until the polygon vanishes
+	{MultiPolygon2D{boolean2DSquare(0, 0, 2, 2)}, -0.5, OffsetStyle2D{}, 1, 1},
+	{MultiPolygon2D{boolean2DSquare(0, 0, 2, 2)}, -1.5, OffsetStyle2D{}, 0, 0},
+	{MultiPolygon2D{polygon2DL}, -0.25, OffsetStyle2D{}, 1.25, 1},
+	{MultiPolygon2D{polygon2DL}, 0, OffsetStyle2D{}, 3, 1},
+	// growing into a hole until it closes, and shrinking the hole's walls
+	{MultiPolygon2D{polygon2DSquareWithHole}, 0.5, OffsetStyle2D{}, 25 - 1, 1},
+	{MultiPolygon2D{polygon2DSquareWithHole}, 1.5, OffsetStyle2D{}, 49, 1},
+	{MultiPolygon2D{polygon2DSquareWithHole}, -0.25, OffsetStyle2D{}, 3.5*3.5 - 2.5*2.5, 1},
+	// polygons growing into each other
+	{MultiPolygon2D{boolean2DSquare(0, 0, 1, 1), boolean2DSquare(2, 0, 3, 1)}, 0.5, OffsetStyle2D{}, 8, 1},
+	{MultiPolygon2D{boolean2DSquare(0, 0, 1, 1), boolean2DSquare(2, 0, 3, 1)}, 0.25, OffsetStyle2D{}, 4.5, 2},
+	{nil, 1, OffsetStyle2D{}, 0, 0},
+}
+
+func TestMultiPolygon2DOffset(t *testing.T) {
+	for _, v := range offset2DValues {
+		var z MultiPolygon2D
+		z.Offset(&v.x, v.d, &v.style)
+		if err := checkMultiPolygon2D(z); err != "" {
+			t.Error("MultiPolygon2D.Offset", v.x, v.d, err, z)
+		}
+		if math.Abs(z.Area()-v.area) > 1e-5 || len(z) != v.polygons {
+			t.Error("MultiPolygon2D.Offset", v.x, v.d, v.style, "want", v.area, "got", z.Area(), z)
+		}
+	}
+	// the default style mitres
+	z := MultiPolygon2D{boolean2DSquare(0, 0, 2, 2)}
+	if z.Offset(&z, 1, nil); len(z) != 1 || len(z[0].Outer) != 4 || !FuzzyEqual(z.Area(), 16) {
+		t.Error("MultiPolygon2D.Offset default style got", z)
+	}
+}
+
+var offsetPolyline2DValues = []struct {
+	points []Vector2D
+	d      float64
+	style  OffsetStyle2D
+	area   float64
+}{
+	// each cap on a line segment
+	{[]Vector2D{{0, 0}, {2, 0}}, 1, OffsetStyle2D{Cap: OffsetCapButt}, 4},
+	{[]Vector2D{{0, 0}, {2, 0}}, -1, OffsetStyle2D{Cap: OffsetCapSquare}, 8},
+	{[]Vector2D{{0, 0}, {2, 0}}, 1, OffsetStyle2D{Cap: OffsetCapRound, ArcTolerance: 1e-6}, 4 + math.Pi},
+	// and on a point
+	{[]Vector2D{{1, 1}, {1, 1}}, 1, OffsetStyle2D{Cap: OffsetCapButt}, 0},
+	{[]Vector2D{{1, 1}}, 1, OffsetStyle2D{Cap: OffsetCapSquare}, 4},
+	{[]Vector2D{{1, 1}}, 1, OffsetStyle2D{Cap: OffsetCapRound, ArcTolerance: 1e-6}, math.Pi},
+	{nil, 1, OffsetStyle2D{}, 0},
+	{[]Vector2D{{0, 0}, {2, 0}}, 0, OffsetStyle2D{}, 0},
+	// a corner, mitered on the outside and trimmed on the inside
+	{[]Vector2D{{0, 0}, {2, 0}, {2, 2}}, 1, OffsetStyle2D{}, 8},
+	{[]Vector2D{{0, 0}, {2, 0}, {2, 2}}, 1, OffsetStyle2D{Join: OffsetJoinBevel}, 7.5},
+	// a path doubling back on and crossing itself
+	{[]Vector2D{{0, 0}, {4, 0}, {2, 0}}, 1, OffsetStyle2D{Cap: OffsetCapSquare}, 10},
+	{[]Vector2D{{0, 0}, {4, 0}, {4, 2}, {2, 2}, {2, -2}}, 0.5, OffsetStyle2D{}, 11},
+}
+
+func TestMultiPolygon2DOffsetPolyline(t *testing.T) {
+	for _, v := range offsetPolyline2DValues {
+		var z MultiPolygon2D
+		z.OffsetPolyline(v.points, v.d, &v.style)
+		if err := checkMultiPolygon2D(z); err != "" {
+			t.Error("MultiPolygon2D.OffsetPolyline", v.points, v.d, err, z)
+		}
+		if math.Abs(z.Area()-v.area) > 1e-5 {
+			t.Error("MultiPolygon2D.OffsetPolyline", v.points, v.d, v.style, "want", v.area, "got", z.Area(), z)
+		}
+	}
+}
+
+func TestMultiPolygon2DOffsetRandom(t *testing.T) {
+	// with round joins a point is in the offset when it's closer than the
+	// distance to the polygon, for growing, or further than the distance
+	// inside it, for shrinking
+	const tol = 1e-3
+	style := OffsetStyle2D{Join: OffsetJoinRound, ArcTolerance: tol}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		x := MultiPolygon2D{boolean2DStar(r, Vector2D{0, 0}, 3+r.Intn(20))}
+		d := r.Float64() - 0.5
+		var z MultiPolygon2D
+		z.Offset(&x, d, &style)
+		if err := checkMultiPolygon2D(z); err != "" {
+			t.Fatal("MultiPolygon2D.Offset", x, d, err, z)
+		}
+		for j := 0; j < 100; j++ {
+			p := Vector2D{r.Float64()*4 - 2, r.Float64()*4 - 2}
+			e := math.Sqrt(ringDistanceSquared(x[0].Outer, &p))
+			if x.Contains(&p) < 0 {
+				e = -e
+			}
+			// e is the signed distance into x
+			c := z.Contains(&p)
+			if (e+d > tol && c < 0) || (e+d < -tol && c > 0) {
+				t.Fatal("MultiPolygon2D.Offset", x, d, p, "distance", e, "got", c)
+			}
+		}
+	}
+}
+
+func Benchmark_MultiPolygon2DOffset(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	x := MultiPolygon2D{boolean2DStar(r, Vector2D{0, 0}, 500)}
+	style := OffsetStyle2D{Join: OffsetJoinRound}
+	var z MultiPolygon2D
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		z.Offset(&x, 0.1, &style)
+	}
+}
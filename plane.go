@@ -60,20 +60,25 @@ func (z *Plane) FromPoints(p1, p2, p3 *Vector3D) *Plane {
 
 // FuzzyEqual returns true if the two planes are very close or false otherwise.
 func (a *Plane) FuzzyEqual(b *Plane) bool {
-	if FuzzyEqual(math.Abs(a.A)+math.Abs(b.A), 0) {
-		if !FuzzyEqual(math.Abs(a.B)+math.Abs(b.B), 0) {
+	return DefaultTolerance.PlaneEqual(a, b)
+}
+
+// PlaneEqual is like Plane.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) PlaneEqual(a, b *Plane) bool {
+	if t.Equal(math.Abs(a.A)+math.Abs(b.A), 0) {
+		if !t.Equal(math.Abs(a.B)+math.Abs(b.B), 0) {
 			s := a.B / b.B
-			if s*a.D*b.D < 0 || !FuzzyEqual(a.C, s*b.C) {
+			if s*a.D*b.D < 0 || !t.Equal(a.C, s*b.C) {
 				return false
 			}
 		}
 	} else {
 		s := a.A / b.A
-		if s*a.D*b.D < 0 || !FuzzyEqual(a.B, s*b.B) || !FuzzyEqual(a.C, s*b.C) {
+		if s*a.D*b.D < 0 || !t.Equal(a.B, s*b.B) || !t.Equal(a.C, s*b.C) {
 			return false
 		}
 	}
-	return FuzzyEqual(b.D*b.D*(a.A*a.A+a.B*a.B+a.C*a.C),
+	return t.Equal(b.D*b.D*(a.A*a.A+a.B*a.B+a.C*a.C),
 		a.D*a.D*(b.A*b.A+b.B*b.B+b.C*b.C))
 }
 
@@ -104,3 +109,16 @@ func (a *Plane) NormalizedEqual(b *Plane) bool {
 	}
 	return a.A == b.A && a.B == b.B && a.C == b.C && a.D == b.D
 }
+
+// Transform sets z to plane x transformed by m then returns z. Planes are
+// transformed by the inverse transpose of m so the normal remains
+// perpendicular to the plane under non-uniform scaling.
+func (z *Plane) Transform(m *Matrix4x4, x *Plane) *Plane {
+	var n Matrix4x4
+	n.Inverse(m)
+	z.A, z.B, z.C, z.D = n[0][0]*x.A+n[1][0]*x.B+n[2][0]*x.C+n[3][0]*x.D,
+		n[0][1]*x.A+n[1][1]*x.B+n[2][1]*x.C+n[3][1]*x.D,
+		n[0][2]*x.A+n[1][2]*x.B+n[2][2]*x.C+n[3][2]*x.D,
+		n[0][3]*x.A+n[1][3]*x.B+n[2][3]*x.C+n[3][3]*x.D
+	return z
+}
@@ -183,3 +183,21 @@ func Benchmark_Plane_NormalizedEqual(b *testing.B) {
 		p1.NormalizedEqual(p2)
 	}
 }
+
+func TestPlaneTransform(t *testing.T) {
+	var m, s Matrix4x4
+	var p Plane
+	var pt Vector3D
+	// plane x + y = 1 through (1, 0, 0) and (0, 1, 0)
+	x := &Plane{1, 1, 0, -1}
+	m.Multiply(m.RotationZ(0.7), s.Scaling(&Vector3D{2, 5, 1}))
+	p.Transform(&m, x)
+	for _, v := range []Vector3D{{1, 0, 0}, {0, 1, 0}, {0.5, 0.5, 3}} {
+		if d := Distance3DPlanePoint(&p, pt.TransformPoint(&m, &v)); !FuzzyEqual(d, 0) {
+			t.Error("Plane.Transform", x, "point", v, "distance", d)
+		}
+	}
+	if d := Distance3DPlanePoint(&p, pt.TransformPoint(&m, &Vector3D{})); d >= 0 {
+		t.Error("Plane.Transform", x, "origin side changed", d)
+	}
+}
@@ -0,0 +1,380 @@
+package geometry
+
+import (
+	"math"
+)
+
+// A Polygon2D is a simple polygon, possibly non-convex and with holes. Each
+// ring is a list of vertices with the closing edge from the last vertex back
+// to the first implied. The outer ring and holes may have either orientation.
+type Polygon2D struct {
+	Outer []Vector2D
+	Holes [][]Vector2D
+}
+
+// NewPolygon2D returns a new Polygon2D. The rings are used directly and not
+// copied.
+func NewPolygon2D(outer []Vector2D, holes ...[]Vector2D) *Polygon2D {
+	return &Polygon2D{outer, holes}
+}
+
+// Area returns the signed area of the polygon, the area of the outer ring
+// less the area of the holes. It is positive if the outer ring is
+// counterclockwise or negative if it is clockwise.
+func (x *Polygon2D) Area() float64 {
+	a := ringArea(x.Outer)
+	h := 0.0
+	for _, r := range x.Holes {
+		h += math.Abs(ringArea(r))
+	}
+	if a < 0 {
+		return a + h
+	}
+	return a - h
+}
+
+// Bounds sets z to the smallest box containing the outer ring of the polygon
+// then returns z.
+func (x *Polygon2D) Bounds(z *AABB2D) *AABB2D {
+	return z.FromPoints(x.Outer...)
+}
+
+// Centroid sets z to the centroid of the area of the polygon then returns z.
+// If the polygon has no area z is set to NaN.
+func (x *Polygon2D) Centroid(z *Vector2D) *Vector2D {
+	var c Vector2D
+	a := ringCentroid(x.Outer, &c)
+	a = math.Abs(a)
+	sx, sy := c.X*a, c.Y*a
+	for _, r := range x.Holes {
+		h := math.Abs(ringCentroid(r, &c))
+		sx -= c.X * h
+		sy -= c.Y * h
+		a -= h
+	}
+	if a == 0 {
+		z.X, z.Y = math.NaN(), math.NaN()
+		return z
+	}
+	z.X = sx / a
+	z.Y = sy / a
+	return z
+}
+
+// Contains determines where point p lies relative to the polygon, treating
+// points within FuzzyEqual distance of an edge as on the boundary.
+//
+// Possible return values are:
+// -1 if p is outside the polygon, including inside a hole.
+// 0 if p is on the boundary of the outer ring or a hole.
+// 1 if p is inside the polygon.
+func (x *Polygon2D) Contains(p *Vector2D) int {
+	if ringOnBoundary(x.Outer, p) {
+		return 0
+	}
+	for _, r := range x.Holes {
+		if ringOnBoundary(r, p) {
+			return 0
+		}
+	}
+	if !ringContains(x.Outer, p) {
+		return -1
+	}
+	for _, r := range x.Holes {
+		if ringContains(r, p) {
+			return -1
+		}
+	}
+	return 1
+}
+
+// Copy sets z to a deep copy of x then returns z.
+func (z *Polygon2D) Copy(x *Polygon2D) *Polygon2D {
+	z.Outer = append([]Vector2D(nil), x.Outer...)
+	holes := make([][]Vector2D, len(x.Holes))
+	for i, r := range x.Holes {
+		holes[i] = append([]Vector2D(nil), r...)
+	}
+	z.Holes = holes
+	return z
+}
+
+// Equal compares a and b then returns true if they have exactly equal rings
+// starting from the same vertices or false otherwise.
+func (a *Polygon2D) Equal(b *Polygon2D) bool {
+	if len(a.Holes) != len(b.Holes) || !ringEqual(a.Outer, b.Outer, (*Vector2D).Equal) {
+		return false
+	}
+	for i := range a.Holes {
+		if !ringEqual(a.Holes[i], b.Holes[i], (*Vector2D).Equal) {
+			return false
+		}
+	}
+	return true
+}
+
+// FuzzyEqual compares a and b then returns true if they have very close rings
+// starting from the same vertices or false otherwise.
+func (a *Polygon2D) FuzzyEqual(b *Polygon2D) bool {
+	return DefaultTolerance.Polygon2DEqual(a, b)
+}
+
+// Polygon2DEqual is like Polygon2D.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) Polygon2DEqual(a, b *Polygon2D) bool {
+	if len(a.Holes) != len(b.Holes) || !ringEqual(a.Outer, b.Outer, t.Vector2DEqual) {
+		return false
+	}
+	for i := range a.Holes {
+		if !ringEqual(a.Holes[i], b.Holes[i], t.Vector2DEqual) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsConvex returns true if the polygon has no holes and its outer ring is
+// convex or false otherwise. Collinear vertices are allowed.
+func (x *Polygon2D) IsConvex() bool {
+	n := len(x.Outer)
+	if len(x.Holes) != 0 || n < 3 {
+		return false
+	}
+	sign, turn := 0.0, 0.0
+	for i := range x.Outer {
+		a, b, c := &x.Outer[i], &x.Outer[(i+1)%n], &x.Outer[(i+2)%n]
+		ux, uy := b.X-a.X, b.Y-a.Y
+		vx, vy := c.X-b.X, c.Y-b.Y
+		cross := ux*vy - uy*vx
+		if cross*sign < 0 {
+			return false
+		}
+		if cross != 0 {
+			sign = cross
+		}
+		turn += math.Atan2(cross, ux*vx+uy*vy)
+	}
+	// a star shaped ring turns the same way at every vertex but winds more
+	// than once
+	return sign != 0 && math.Abs(math.Abs(turn)-2*math.Pi) < 1e-6
+}
+
+// IsSimple returns true if no two edges of the polygon, in any ring, cross or
+// touch other than adjacent edges meeting at their shared vertex, or false
+// otherwise.
+func (x *Polygon2D) IsSimple() bool {
+	rings := append([][]Vector2D{x.Outer}, x.Holes...)
+	for ri, r := range rings {
+		n := len(r)
+		if n < 3 {
+			return false
+		}
+		for i := 0; i < n; i++ {
+			a1, a2 := &r[i], &r[(i+1)%n]
+			// edges in the same ring
+			for j := i + 1; j < n; j++ {
+				b1, b2 := &r[j], &r[(j+1)%n]
+				switch {
+				case j == i+1:
+					if segmentsOverlapAtJoint(a1, a2, b2) {
+						return false
+					}
+				case i == 0 && j == n-1:
+					if segmentsOverlapAtJoint(a2, a1, b1) {
+						return false
+					}
+				case segmentsIntersect(a1, a2, b1, b2):
+					return false
+				}
+			}
+			// edges in later rings
+			for _, s := range rings[ri+1:] {
+				for j := range s {
+					if segmentsIntersect(a1, a2, &s[j], &s[(j+1)%len(s)]) {
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Orientation returns 1 if the outer ring is counterclockwise, -1 if it is
+// clockwise, or 0 if it has no area.
+func (x *Polygon2D) Orientation() int {
+	a := ringArea(x.Outer)
+	if a > 0 {
+		return 1
+	}
+	if a < 0 {
+		return -1
+	}
+	return 0
+}
+
+// Perimeter returns the total length of the edges of all rings.
+func (x *Polygon2D) Perimeter() float64 {
+	p := ringPerimeter(x.Outer)
+	for _, r := range x.Holes {
+		p += ringPerimeter(r)
+	}
+	return p
+}
+
+// Reverse reverses the orientation of every ring in x then returns x.
+func (x *Polygon2D) Reverse() *Polygon2D {
+	ringReverse(x.Outer)
+	for _, r := range x.Holes {
+		ringReverse(r)
+	}
+	return x
+}
+
+// Support returns the vertex of the outer ring furthest in direction d, so
+// collision tests using it treat the polygon as the convex hull of its outer
+// ring.
+func (x *Polygon2D) Support(d Vector2D) Vector2D {
+	return supportPoints2D(x.Outer, &d)
+}
+
+// ringArea returns the signed area of ring r, positive if counterclockwise.
+func ringArea(r []Vector2D) float64 {
+	if len(r) < 3 {
+		return 0
+	}
+	// shoelace formula relative to the first vertex to limit cancellation
+	o, a := r[0], 0.0
+	for i := 1; i < len(r)-1; i++ {
+		a += (r[i].X-o.X)*(r[i+1].Y-o.Y) - (r[i+1].X-o.X)*(r[i].Y-o.Y)
+	}
+	return a / 2
+}
+
+// ringCentroid sets z to the centroid of ring r then returns its signed area.
+func ringCentroid(r []Vector2D, z *Vector2D) float64 {
+	if len(r) < 3 {
+		z.X, z.Y = 0, 0
+		return 0
+	}
+	o := r[0]
+	var a, cx, cy float64
+	for i := 1; i < len(r)-1; i++ {
+		x1, y1 := r[i].X-o.X, r[i].Y-o.Y
+		x2, y2 := r[i+1].X-o.X, r[i+1].Y-o.Y
+		c := x1*y2 - x2*y1
+		a += c
+		cx += (x1 + x2) * c
+		cy += (y1 + y2) * c
+	}
+	if a == 0 {
+		z.X, z.Y = o.X, o.Y
+		return 0
+	}
+	z.X = o.X + cx/(3*a)
+	z.Y = o.Y + cy/(3*a)
+	return a / 2
+}
+
+// ringContains returns true if p is inside ring r by the even-odd rule or
+// false otherwise. Points on the boundary may go either way.
+func ringContains(r []Vector2D, p *Vector2D) bool {
+	in := false
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		a, b := &r[i], &r[j]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			in = !in
+		}
+	}
+	return in
+}
+
+// ringDistanceSquared returns the squared distance between the edges of ring r
+// and point p.
+func ringDistanceSquared(r []Vector2D, p *Vector2D) float64 {
+	var e Line2D
+	d := math.Inf(1)
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		e.P = r[j]
+		e.V.Subtract(&r[i], &r[j])
+		d = math.Min(d, Distance2DLineSegmentPointSquared(&e, p))
+	}
+	return d
+}
+
+// ringEqual returns true if rings a and b have the same number of vertices
+// and every pair of vertices is equal by eq or false otherwise.
+func ringEqual(a, b []Vector2D, eq func(*Vector2D, *Vector2D) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !eq(&a[i], &b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ringOnBoundary returns true if p is within FuzzyEqual distance of an edge of
+// ring r or false otherwise.
+func ringOnBoundary(r []Vector2D, p *Vector2D) bool {
+	var e Line2D
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		e.P = r[j]
+		e.V.Subtract(&r[i], &r[j])
+		if FuzzyEqual(Distance2DLineSegmentPoint(&e, p), 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// ringPerimeter returns the length of the edges of ring r.
+func ringPerimeter(r []Vector2D) float64 {
+	p := 0.0
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		p += Distance2DPointPoint(&r[j], &r[i])
+	}
+	return p
+}
+
+// ringReverse reverses the order of the vertices of ring r.
+func ringReverse(r []Vector2D) {
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+}
+
+// cross2D returns the z component of the cross product of b-a and c-a, which
+// is positive if a, b, c turn counterclockwise.
+func cross2D(a, b, c *Vector2D) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+// onSegment returns true if p, known to be collinear with a and b, lies on
+// the line segment between them or false otherwise.
+func onSegment(a, b, p *Vector2D) bool {
+	return math.Min(a.X, b.X) <= p.X && p.X <= math.Max(a.X, b.X) &&
+		math.Min(a.Y, b.Y) <= p.Y && p.Y <= math.Max(a.Y, b.Y)
+}
+
+// segmentsIntersect returns true if the line segment from a1 to a2 crosses or
+// touches the line segment from b1 to b2 or false otherwise.
+func segmentsIntersect(a1, a2, b1, b2 *Vector2D) bool {
+	d1, d2 := cross2D(b1, b2, a1), cross2D(b1, b2, a2)
+	d3, d4 := cross2D(a1, a2, b1), cross2D(a1, a2, b2)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return (d1 == 0 && onSegment(b1, b2, a1)) || (d2 == 0 && onSegment(b1, b2, a2)) ||
+		(d3 == 0 && onSegment(a1, a2, b1)) || (d4 == 0 && onSegment(a1, a2, b2))
+}
+
+// segmentsOverlapAtJoint returns true if the line segments from a to joint
+// and from joint to b fold back over each other or false otherwise.
+func segmentsOverlapAtJoint(a, joint, b *Vector2D) bool {
+	if cross2D(a, joint, b) != 0 {
+		return false
+	}
+	return (joint.X-a.X)*(b.X-joint.X)+(joint.Y-a.Y)*(b.Y-joint.Y) <= 0
+}
@@ -0,0 +1,152 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+// a 4x4 square with a 2x2 square hole in the middle
+var polygon2DSquareWithHole = Polygon2D{
+	[]Vector2D{{0, 0}, {4, 0}, {4, 4}, {0, 4}},
+	[][]Vector2D{{{1, 1}, {1, 3}, {3, 3}, {3, 1}}},
+}
+
+// an L shape made of three unit squares
+var polygon2DL = Polygon2D{Outer: []Vector2D{{0, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2}}}
+
+func TestPolygon2DArea(t *testing.T) {
+	if got := polygon2DSquareWithHole.Area(); got != 12 {
+		t.Error("Polygon2D.Area", "want", 12, "got", got)
+	}
+	if got := polygon2DL.Area(); got != 3 {
+		t.Error("Polygon2D.Area", "want", 3, "got", got)
+	}
+	var p Polygon2D
+	if got := p.Copy(&polygon2DSquareWithHole).Reverse().Area(); got != -12 {
+		t.Error("Polygon2D.Area clockwise", "want", -12, "got", got)
+	}
+}
+
+func TestPolygon2DCentroid(t *testing.T) {
+	var c Vector2D
+	if !polygon2DSquareWithHole.Centroid(&c).FuzzyEqual(&Vector2D{2, 2}) {
+		t.Error("Polygon2D.Centroid", "got", c)
+	}
+	if !polygon2DL.Centroid(&c).FuzzyEqual(&Vector2D{5.0 / 6, 5.0 / 6}) {
+		t.Error("Polygon2D.Centroid", "got", c)
+	}
+	p := Polygon2D{Outer: []Vector2D{{0, 0}, {1, 1}, {2, 2}}}
+	if p.Centroid(&c); !math.IsNaN(c.X) {
+		t.Error("Polygon2D.Centroid degenerate", "got", c)
+	}
+}
+
+type polygon2DContainsData struct {
+	p    Vector2D
+	want int
+}
+
+var polygon2DContainsValues = []polygon2DContainsData{
+	{Vector2D{0.5, 0.5}, 1},
+	{Vector2D{2, 2}, -1},
+	{Vector2D{5, 2}, -1},
+	{Vector2D{0, 2}, 0},
+	{Vector2D{4, 4}, 0},
+	{Vector2D{2, 3}, 0},
+	{Vector2D{2, 3 + 1e-13}, 0},
+	{Vector2D{2, 3 + 1e-9}, 1},
+	{Vector2D{2, 3 - 1e-9}, -1},
+}
+
+func TestPolygon2DContains(t *testing.T) {
+	for _, v := range polygon2DContainsValues {
+		if got := polygon2DSquareWithHole.Contains(&v.p); got != v.want {
+			t.Error("Polygon2D.Contains", v.p, "want", v.want, "got", got)
+		}
+	}
+	if got := polygon2DL.Contains(&Vector2D{1.5, 1.5}); got != -1 {
+		t.Error("Polygon2D.Contains", "want", -1, "got", got)
+	}
+}
+
+func Benchmark_Polygon2D_Contains(b *testing.B) {
+	p := &Vector2D{0.5, 0.5}
+	for i := 0; i < b.N; i++ {
+		polygon2DSquareWithHole.Contains(p)
+	}
+}
+
+func TestPolygon2DCopy(t *testing.T) {
+	var p Polygon2D
+	if !p.Copy(&polygon2DSquareWithHole).Equal(&polygon2DSquareWithHole) {
+		t.Error("Polygon2D.Copy")
+	}
+	p.Holes[0][0].X = 2
+	if p.Equal(&polygon2DSquareWithHole) {
+		t.Error("Polygon2D.Copy shares holes")
+	}
+	if !p.FuzzyEqual(&p) {
+		t.Error("Polygon2D.FuzzyEqual")
+	}
+	// copying into a polygon doesn't write over the points it had
+	outer := append(make([]Vector2D, 0, 8), Vector2D{0, 0}, Vector2D{1, 0}, Vector2D{0, 1})
+	q := NewPolygon2D(outer)
+	q.Copy(&polygon2DL)
+	if outer[0] != (Vector2D{0, 0}) || outer[1] != (Vector2D{1, 0}) {
+		t.Error("Polygon2D.Copy overwrote", outer)
+	}
+}
+
+func TestPolygon2DIsConvex(t *testing.T) {
+	square := Polygon2D{Outer: []Vector2D{{0, 0}, {1, 0}, {2, 0}, {2, 2}, {0, 2}}}
+	star := Polygon2D{Outer: []Vector2D{{0, 2}, {-1.2, -1.6}, {1.9, 0.6}, {-1.9, 0.6}, {1.2, -1.6}}}
+	if !square.IsConvex() {
+		t.Error("Polygon2D.IsConvex square")
+	}
+	if polygon2DL.IsConvex() {
+		t.Error("Polygon2D.IsConvex L")
+	}
+	if star.IsConvex() {
+		t.Error("Polygon2D.IsConvex star")
+	}
+	if polygon2DSquareWithHole.IsConvex() {
+		t.Error("Polygon2D.IsConvex hole")
+	}
+}
+
+func TestPolygon2DIsSimple(t *testing.T) {
+	bowtie := Polygon2D{Outer: []Vector2D{{0, 0}, {1, 1}, {1, 0}, {0, 1}}}
+	spike := Polygon2D{Outer: []Vector2D{{0, 0}, {2, 0}, {1, 0}, {1, 1}}}
+	touching := Polygon2D{Outer: polygon2DSquareWithHole.Outer, Holes: [][]Vector2D{{{0, 2}, {1, 1}, {1, 3}}}}
+	if !polygon2DSquareWithHole.IsSimple() || !polygon2DL.IsSimple() {
+		t.Error("Polygon2D.IsSimple")
+	}
+	if bowtie.IsSimple() {
+		t.Error("Polygon2D.IsSimple bowtie")
+	}
+	if spike.IsSimple() {
+		t.Error("Polygon2D.IsSimple spike")
+	}
+	if touching.IsSimple() {
+		t.Error("Polygon2D.IsSimple touching hole")
+	}
+}
+
+func TestPolygon2DOrientation(t *testing.T) {
+	var p Polygon2D
+	if got := polygon2DL.Orientation(); got != 1 {
+		t.Error("Polygon2D.Orientation", "want", 1, "got", got)
+	}
+	if got := p.Copy(&polygon2DL).Reverse().Orientation(); got != -1 {
+		t.Error("Polygon2D.Orientation", "want", -1, "got", got)
+	}
+	if got := p.Orientation(); got != -1 {
+		t.Error("Polygon2D.Orientation", "want", -1, "got", got)
+	}
+}
+
+func TestPolygon2DPerimeter(t *testing.T) {
+	if got := polygon2DSquareWithHole.Perimeter(); got != 24 {
+		t.Error("Polygon2D.Perimeter", "want", 24, "got", got)
+	}
+}
@@ -0,0 +1,432 @@
+package geometry
+
+import (
+	"math"
+	"math/big"
+)
+
+// The predicates follow Shewchuk, "Adaptive Precision Floating-Point
+// Arithmetic and Fast Robust Geometric Predicates", 1997, see
+// http://www.cs.cmu.edu/~quake/robust.html. Each is evaluated in floating
+// point first and only when the rounding error could have changed the sign is
+// it evaluated again exactly, so they are nearly as fast as the naive
+// determinants for all but near degenerate input. Orient2D, Orient3D, and
+// InCircle are then evaluated exactly as expansions, sums of float64 values,
+// which is much faster than rational arithmetic. The error bounds don't hold
+// when products underflow, so input that small always uses exact rational
+// arithmetic. Coordinates must be finite.
+
+// error bounds of the floating point evaluations, in multiples of the
+// permanent of the determinant
+const (
+	orient2DErrorBound = (3 + 16*epsilon) * epsilon
+	orient3DErrorBound = (7 + 56*epsilon) * epsilon
+	inCircleErrorBound = (10 + 96*epsilon) * epsilon
+	inSphereErrorBound = (16 + 224*epsilon) * epsilon
+
+	// differences smaller than these may have products of the degree of the
+	// determinants small enough to lose precision to underflow
+	orient3DTiny = 0x1p-300
+	inCircleTiny = 0x1p-225
+	inSphereTiny = 0x1p-180
+
+	// nonzero coordinates at least this large are multiples of ulps large
+	// enough that products of the degree of the determinants are too, so
+	// none of the components of their expansions underflow
+	orient2DCoarse = 0x1p-480
+	orient3DCoarse = 0x1p-300
+	inCircleCoarse = 0x1p-210
+
+	// epsilon is half the distance from one to the next float64, the largest
+	// relative error of rounding
+	epsilon = 0x1p-53
+)
+
+// InCircle determines where d is relative to the circle through a, b, and c,
+// which must be in counterclockwise order or the result is negated. The
+// result is always correct.
+//
+// Possible return values are:
+// -1 if d is outside the circle.
+// 0 if d is on the circle or a, b, and c are collinear.
+// 1 if d is inside the circle.
+func InCircle(a, b, c, d *Vector2D) int {
+	adx, ady := a.X-d.X, a.Y-d.Y
+	bdx, bdy := b.X-d.X, b.Y-d.Y
+	cdx, cdy := c.X-d.X, c.Y-d.Y
+	if tiny(adx, inCircleTiny) || tiny(ady, inCircleTiny) || tiny(bdx, inCircleTiny) ||
+		tiny(bdy, inCircleTiny) || tiny(cdx, inCircleTiny) || tiny(cdy, inCircleTiny) {
+		return InCircleExact(a, b, c, d)
+	}
+	bdxcdy, cdxbdy := bdx*cdy, cdx*bdy
+	cdxady, adxcdy := cdx*ady, adx*cdy
+	adxbdy, bdxady := adx*bdy, bdx*ady
+	alift := adx*adx + ady*ady
+	blift := bdx*bdx + bdy*bdy
+	clift := cdx*cdx + cdy*cdy
+	det := alift*(bdxcdy-cdxbdy) + blift*(cdxady-adxcdy) + clift*(adxbdy-bdxady)
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*alift + (math.Abs(cdxady)+math.Abs(adxcdy))*blift +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*clift
+	if bound := inCircleErrorBound * permanent; det > bound || -det > bound {
+		return sign(det)
+	}
+	if !coarse(inCircleCoarse, a.X, a.Y, b.X, b.Y, c.X, c.Y, d.X, d.Y) {
+		return InCircleExact(a, b, c, d)
+	}
+	ax, ay := expansionDiff(a.X, d.X), expansionDiff(a.Y, d.Y)
+	bx, by := expansionDiff(b.X, d.X), expansionDiff(b.Y, d.Y)
+	cx, cy := expansionDiff(c.X, d.X), expansionDiff(c.Y, d.Y)
+	al, bl, cl := ax.mul(ax).add(ay.mul(ay)), bx.mul(bx).add(by.mul(by)), cx.mul(cx).add(cy.mul(cy))
+	bc := bx.mul(cy).add(cx.mul(by).neg())
+	ca := cx.mul(ay).add(ax.mul(cy).neg())
+	ab := ax.mul(by).add(bx.mul(ay).neg())
+	return al.mul(bc).add(bl.mul(ca)).add(cl.mul(ab)).sign()
+}
+
+// InCircleExact is like InCircle but always uses exact rational arithmetic.
+//
+// Possible return values are:
+// -1 if d is outside the circle.
+// 0 if d is on the circle or a, b, and c are collinear.
+// 1 if d is inside the circle.
+func InCircleExact(a, b, c, d *Vector2D) int {
+	dx, dy := ratFloat(d.X), ratFloat(d.Y)
+	var r [3][3]*big.Rat
+	for i, p := range [3]*Vector2D{a, b, c} {
+		x, y := ratSub(ratFloat(p.X), dx), ratSub(ratFloat(p.Y), dy)
+		r[i] = [3]*big.Rat{x, y, ratAdd(ratMul(x, x), ratMul(y, y))}
+	}
+	return ratDet3(&r).Sign()
+}
+
+// InSphere determines where e is relative to the sphere through a, b, c, and
+// d, which must be positively oriented as defined by Orient3D or the result
+// is negated. The result is always correct.
+//
+// Possible return values are:
+// -1 if e is outside the sphere.
+// 0 if e is on the sphere or a, b, c, and d are coplanar.
+// 1 if e is inside the sphere.
+func InSphere(a, b, c, d, e *Vector3D) int {
+	aex, aey, aez := a.X-e.X, a.Y-e.Y, a.Z-e.Z
+	bex, bey, bez := b.X-e.X, b.Y-e.Y, b.Z-e.Z
+	cex, cey, cez := c.X-e.X, c.Y-e.Y, c.Z-e.Z
+	dex, dey, dez := d.X-e.X, d.Y-e.Y, d.Z-e.Z
+	if tiny(aex, inSphereTiny) || tiny(aey, inSphereTiny) || tiny(aez, inSphereTiny) ||
+		tiny(bex, inSphereTiny) || tiny(bey, inSphereTiny) || tiny(bez, inSphereTiny) ||
+		tiny(cex, inSphereTiny) || tiny(cey, inSphereTiny) || tiny(cez, inSphereTiny) ||
+		tiny(dex, inSphereTiny) || tiny(dey, inSphereTiny) || tiny(dez, inSphereTiny) {
+		return InSphereExact(a, b, c, d, e)
+	}
+	aexbey, bexaey := aex*bey, bex*aey
+	bexcey, cexbey := bex*cey, cex*bey
+	cexdey, dexcey := cex*dey, dex*cey
+	dexaey, aexdey := dex*aey, aex*dey
+	aexcey, cexaey := aex*cey, cex*aey
+	bexdey, dexbey := bex*dey, dex*bey
+	ab, bc, cd, da := aexbey-bexaey, bexcey-cexbey, cexdey-dexcey, dexaey-aexdey
+	ac, bd := aexcey-cexaey, bexdey-dexbey
+	abc := aez*bc - bez*ac + cez*ab
+	bcd := bez*cd - cez*bd + dez*bc
+	cda := cez*da + dez*ac + aez*cd
+	dab := dez*ab + aez*bd + bez*da
+	alift := aex*aex + aey*aey + aez*aez
+	blift := bex*bex + bey*bey + bez*bez
+	clift := cex*cex + cey*cey + cez*cez
+	dlift := dex*dex + dey*dey + dez*dez
+	det := (dlift*abc - clift*dab) + (blift*cda - alift*bcd)
+
+	aez, bez, cez, dez = math.Abs(aez), math.Abs(bez), math.Abs(cez), math.Abs(dez)
+	abp := math.Abs(aexbey) + math.Abs(bexaey)
+	bcp := math.Abs(bexcey) + math.Abs(cexbey)
+	cdp := math.Abs(cexdey) + math.Abs(dexcey)
+	dap := math.Abs(dexaey) + math.Abs(aexdey)
+	acp := math.Abs(aexcey) + math.Abs(cexaey)
+	bdp := math.Abs(bexdey) + math.Abs(dexbey)
+	permanent := (cdp*bez+bdp*cez+bcp*dez)*alift + (dap*cez+acp*dez+cdp*aez)*blift +
+		(abp*dez+bdp*aez+dap*bez)*clift + (bcp*aez+acp*bez+abp*cez)*dlift
+	if bound := inSphereErrorBound * permanent; det > bound || -det > bound {
+		return sign(det)
+	}
+	return InSphereExact(a, b, c, d, e)
+}
+
+// InSphereExact is like InSphere but always uses exact rational arithmetic.
+//
+// Possible return values are:
+// -1 if e is outside the sphere.
+// 0 if e is on the sphere or a, b, c, and d are coplanar.
+// 1 if e is inside the sphere.
+func InSphereExact(a, b, c, d, e *Vector3D) int {
+	ex, ey, ez := ratFloat(e.X), ratFloat(e.Y), ratFloat(e.Z)
+	var r [4][4]*big.Rat
+	for i, p := range [4]*Vector3D{a, b, c, d} {
+		x, y, z := ratSub(ratFloat(p.X), ex), ratSub(ratFloat(p.Y), ey), ratSub(ratFloat(p.Z), ez)
+		r[i] = [4]*big.Rat{x, y, z, ratAdd(ratAdd(ratMul(x, x), ratMul(y, y)), ratMul(z, z))}
+	}
+	// expand along the lift column
+	det := new(big.Rat)
+	for i := 0; i < 4; i++ {
+		var m [3][3]*big.Rat
+		for j, k := 0, 0; j < 4; j++ {
+			if j != i {
+				m[k] = [3]*big.Rat{r[j][0], r[j][1], r[j][2]}
+				k++
+			}
+		}
+		t := ratMul(r[i][3], ratDet3(&m))
+		if i%2 == 0 {
+			det.Sub(det, t)
+		} else {
+			det.Add(det, t)
+		}
+	}
+	return det.Sign()
+}
+
+// Orient2D determines the orientation of a, b, and c. The result is always
+// correct.
+//
+// Possible return values are:
+// -1 if a, b, and c turn clockwise.
+// 0 if a, b, and c are collinear.
+// 1 if a, b, and c turn counterclockwise.
+func Orient2D(a, b, c *Vector2D) int {
+	acx, acy := a.X-c.X, a.Y-c.Y
+	bcx, bcy := b.X-c.X, b.Y-c.Y
+	l, r := acx*bcy, acy*bcx
+	// products this small may have lost precision to underflow
+	if (math.Abs(l) < 0x1p-900 && acx != 0 && bcy != 0) || (math.Abs(r) < 0x1p-900 && acy != 0 && bcx != 0) {
+		return Orient2DExact(a, b, c)
+	}
+	d := l - r
+	if l == 0 || r == 0 || (l > 0) != (r > 0) {
+		// no cancellation is possible so the sign of d is exact
+		return sign(d)
+	}
+	if bound := orient2DErrorBound * math.Abs(l+r); d >= bound || -d >= bound {
+		return sign(d)
+	}
+	if !coarse(orient2DCoarse, a.X, a.Y, b.X, b.Y, c.X, c.Y) {
+		return Orient2DExact(a, b, c)
+	}
+	ax, ay := expansionDiff(a.X, c.X), expansionDiff(a.Y, c.Y)
+	bx, by := expansionDiff(b.X, c.X), expansionDiff(b.Y, c.Y)
+	return ax.mul(by).add(ay.mul(bx).neg()).sign()
+}
+
+// Orient2DExact is like Orient2D but always uses exact rational arithmetic.
+//
+// Possible return values are:
+// -1 if a, b, and c turn clockwise.
+// 0 if a, b, and c are collinear.
+// 1 if a, b, and c turn counterclockwise.
+func Orient2DExact(a, b, c *Vector2D) int {
+	cx, cy := ratFloat(c.X), ratFloat(c.Y)
+	l := ratMul(ratSub(ratFloat(a.X), cx), ratSub(ratFloat(b.Y), cy))
+	r := ratMul(ratSub(ratFloat(a.Y), cy), ratSub(ratFloat(b.X), cx))
+	return l.Cmp(r)
+}
+
+// Orient3D determines which side of the plane through a, b, and c point d is
+// on. The result is always correct.
+//
+// Possible return values are:
+// -1 if d is above the plane, where a, b, and c appear counterclockwise.
+// 0 if a, b, c, and d are coplanar.
+// 1 if d is below the plane, where a, b, and c appear clockwise.
+func Orient3D(a, b, c, d *Vector3D) int {
+	adx, ady, adz := a.X-d.X, a.Y-d.Y, a.Z-d.Z
+	bdx, bdy, bdz := b.X-d.X, b.Y-d.Y, b.Z-d.Z
+	cdx, cdy, cdz := c.X-d.X, c.Y-d.Y, c.Z-d.Z
+	if tiny(adx, orient3DTiny) || tiny(ady, orient3DTiny) || tiny(adz, orient3DTiny) ||
+		tiny(bdx, orient3DTiny) || tiny(bdy, orient3DTiny) || tiny(bdz, orient3DTiny) ||
+		tiny(cdx, orient3DTiny) || tiny(cdy, orient3DTiny) || tiny(cdz, orient3DTiny) {
+		return Orient3DExact(a, b, c, d)
+	}
+	bdxcdy, cdxbdy := bdx*cdy, cdx*bdy
+	cdxady, adxcdy := cdx*ady, adx*cdy
+	adxbdy, bdxady := adx*bdy, bdx*ady
+	det := adz*(bdxcdy-cdxbdy) + bdz*(cdxady-adxcdy) + cdz*(adxbdy-bdxady)
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*math.Abs(adz) +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*math.Abs(bdz) + (math.Abs(adxbdy)+math.Abs(bdxady))*math.Abs(cdz)
+	if bound := orient3DErrorBound * permanent; det > bound || -det > bound {
+		return sign(det)
+	}
+	if !coarse(orient3DCoarse, a.X, a.Y, a.Z, b.X, b.Y, b.Z, c.X, c.Y, c.Z, d.X, d.Y, d.Z) {
+		return Orient3DExact(a, b, c, d)
+	}
+	ax, ay, az := expansionDiff(a.X, d.X), expansionDiff(a.Y, d.Y), expansionDiff(a.Z, d.Z)
+	bx, by, bz := expansionDiff(b.X, d.X), expansionDiff(b.Y, d.Y), expansionDiff(b.Z, d.Z)
+	cx, cy, cz := expansionDiff(c.X, d.X), expansionDiff(c.Y, d.Y), expansionDiff(c.Z, d.Z)
+	bc := bx.mul(cy).add(cx.mul(by).neg())
+	ca := cx.mul(ay).add(ax.mul(cy).neg())
+	ab := ax.mul(by).add(bx.mul(ay).neg())
+	return az.mul(bc).add(bz.mul(ca)).add(cz.mul(ab)).sign()
+}
+
+// Orient3DExact is like Orient3D but always uses exact rational arithmetic.
+//
+// Possible return values are:
+// -1 if d is above the plane, where a, b, and c appear counterclockwise.
+// 0 if a, b, c, and d are coplanar.
+// 1 if d is below the plane, where a, b, and c appear clockwise.
+func Orient3DExact(a, b, c, d *Vector3D) int {
+	dx, dy, dz := ratFloat(d.X), ratFloat(d.Y), ratFloat(d.Z)
+	var r [3][3]*big.Rat
+	for i, p := range [3]*Vector3D{a, b, c} {
+		r[i] = [3]*big.Rat{ratSub(ratFloat(p.X), dx), ratSub(ratFloat(p.Y), dy), ratSub(ratFloat(p.Z), dz)}
+	}
+	return ratDet3(&r).Sign()
+}
+
+// tiny returns true if x is nonzero and smaller in magnitude than limit or
+// false otherwise.
+func tiny(x, limit float64) bool {
+	return x != 0 && -limit < x && x < limit
+}
+
+// coarse returns true if every nonzero x is at least limit in magnitude or
+// false otherwise.
+func coarse(limit float64, x ...float64) bool {
+	for _, v := range x {
+		if tiny(v, limit) {
+			return false
+		}
+	}
+	return true
+}
+
+// An expansion is a sum of float64 components that is exact. Following
+// Shewchuk the components are nonoverlapping, in order of increasing
+// magnitude, and nonzero, so the sign of the sum is that of the last.
+type expansion []float64
+
+// twoSum returns a+b rounded and the rounding error, which is exact.
+func twoSum(a, b float64) (float64, float64) {
+	x := a + b
+	bv := x - a
+	av := x - bv
+	return x, (a - av) + (b - bv)
+}
+
+// twoProduct returns a*b rounded and the rounding error, which is exact if the
+// error does not underflow.
+func twoProduct(a, b float64) (float64, float64) {
+	x := a * b
+	return x, math.FMA(a, b, -x)
+}
+
+// expansionDiff returns the expansion of a-b.
+func expansionDiff(a, b float64) expansion {
+	x, y := twoSum(a, -b)
+	return expansion(nil).grow(y).grow(x)
+}
+
+// grow returns a new expansion of e+b.
+func (e expansion) grow(b float64) expansion {
+	z := make(expansion, 0, len(e)+1)
+	for _, c := range e {
+		var h float64
+		b, h = twoSum(b, c)
+		if h != 0 {
+			z = append(z, h)
+		}
+	}
+	if b != 0 {
+		z = append(z, b)
+	}
+	return z
+}
+
+// add returns a new expansion of e+f.
+func (e expansion) add(f expansion) expansion {
+	for _, c := range f {
+		e = e.grow(c)
+	}
+	return e
+}
+
+// mul returns a new expansion of e*f, which is exact if no product of
+// components underflows.
+func (e expansion) mul(f expansion) expansion {
+	var z expansion
+	for _, a := range e {
+		for _, b := range f {
+			x, y := twoProduct(a, b)
+			z = z.grow(y).grow(x)
+		}
+	}
+	return z
+}
+
+// neg returns a new expansion of -e.
+func (e expansion) neg() expansion {
+	z := make(expansion, len(e))
+	for i, c := range e {
+		z[i] = -c
+	}
+	return z
+}
+
+// sign returns the sign of e.
+func (e expansion) sign() int {
+	if len(e) == 0 {
+		return 0
+	}
+	return sign(e[len(e)-1])
+}
+
+// productSumSign returns the sign of x[0]*x[1] + x[2]*x[3] + ..., which is
+// always correct. There must be an even number of values.
+func productSumSign(x ...float64) int {
+	s, permanent, tiny := 0.0, 0.0, false
+	for i := 0; i < len(x); i += 2 {
+		p := x[i] * x[i+1]
+		s += p
+		permanent += math.Abs(p)
+		// products this small may have lost precision to underflow
+		tiny = tiny || (math.Abs(p) < 0x1p-900 && x[i] != 0 && x[i+1] != 0)
+	}
+	if !tiny {
+		// each product and sum adds at most one rounding error
+		if bound := float64(len(x)) * epsilon * permanent; s > bound || -s > bound {
+			return sign(s)
+		}
+		if permanent == 0 {
+			return 0
+		}
+	}
+	t := new(big.Rat)
+	for i := 0; i < len(x); i += 2 {
+		t.Add(t, ratMul(ratFloat(x[i]), ratFloat(x[i+1])))
+	}
+	return t.Sign()
+}
+
+// ratAdd returns a new rational set to a+b.
+func ratAdd(a, b *big.Rat) *big.Rat {
+	return new(big.Rat).Add(a, b)
+}
+
+// ratDet3 returns a new rational set to the determinant of m.
+func ratDet3(m *[3][3]*big.Rat) *big.Rat {
+	d := ratMul(m[0][0], ratSub(ratMul(m[1][1], m[2][2]), ratMul(m[1][2], m[2][1])))
+	d.Sub(d, ratMul(m[0][1], ratSub(ratMul(m[1][0], m[2][2]), ratMul(m[1][2], m[2][0]))))
+	return d.Add(d, ratMul(m[0][2], ratSub(ratMul(m[1][0], m[2][1]), ratMul(m[1][1], m[2][0]))))
+}
+
+// ratFloat returns a new rational set exactly to x, which must be finite.
+func ratFloat(x float64) *big.Rat {
+	return new(big.Rat).SetFloat64(x)
+}
+
+// ratMul returns a new rational set to a*b.
+func ratMul(a, b *big.Rat) *big.Rat {
+	return new(big.Rat).Mul(a, b)
+}
+
+// ratSub returns a new rational set to a-b.
+func ratSub(a, b *big.Rat) *big.Rat {
+	return new(big.Rat).Sub(a, b)
+}
@@ -0,0 +1,232 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestOrient2D(t *testing.T) {
+	a, b := &Vector2D{0, 0}, &Vector2D{1, 1}
+	for _, v := range []struct {
+		c    Vector2D
+		want int
+	}{
+		{Vector2D{0, 1}, 1},
+		{Vector2D{1, 0}, -1},
+		{Vector2D{2, 2}, 0},
+		{Vector2D{0.1, 0.1}, 0},
+	} {
+		if got := Orient2D(a, b, &v.c); got != v.want {
+			t.Error("Orient2D", a, b, v.c, "want", v.want, "got", got)
+		}
+	}
+
+	// points a few ulps from the line through (12, 12) and (24, 24), where
+	// the naive determinant is often wrong
+	p, q := &Vector2D{12, 12}, &Vector2D{24, 24}
+	for i := 0; i < 32; i++ {
+		for j := 0; j < 32; j++ {
+			c := &Vector2D{0.5 + float64(i)*0x1p-53, 0.5 + float64(j)*0x1p-53}
+			if got, want := Orient2D(c, p, q), Orient2DExact(c, p, q); got != want {
+				t.Fatal("Orient2D", c, p, q, "want", want, "got", got)
+			}
+		}
+	}
+}
+
+func TestOrient3D(t *testing.T) {
+	a, b, c := &Vector3D{0, 0, 0}, &Vector3D{1, 0, 0}, &Vector3D{0, 1, 0}
+	if o := Orient3D(a, b, c, &Vector3D{0, 0, -1}); o != 1 {
+		t.Error("Orient3D below want 1 got", o)
+	}
+	if o := Orient3D(a, b, c, &Vector3D{0, 0, 1}); o != -1 {
+		t.Error("Orient3D above want -1 got", o)
+	}
+	if o := Orient3D(a, b, c, &Vector3D{0.3, 0.7, 0}); o != 0 {
+		t.Error("Orient3D coplanar want 0 got", o)
+	}
+
+	// points very close to a plane that is not axis aligned
+	r := rand.New(rand.NewSource(1))
+	p, q, s := &Vector3D{0.1, 0.2, 0.3}, &Vector3D{1.7, -0.3, 0.9}, &Vector3D{-0.4, 1.1, 2.3}
+	for i := 0; i < 200; i++ {
+		u, v := r.Float64(), r.Float64()
+		d := &Vector3D{p.X + u*(q.X-p.X) + v*(s.X-p.X), p.Y + u*(q.Y-p.Y) + v*(s.Y-p.Y),
+			p.Z + u*(q.Z-p.Z) + v*(s.Z-p.Z)}
+		if got, want := Orient3D(p, q, s, d), Orient3DExact(p, q, s, d); got != want {
+			t.Fatal("Orient3D", p, q, s, d, "want", want, "got", got)
+		}
+	}
+}
+
+func TestInCircle(t *testing.T) {
+	a, b, c := &Vector2D{1, 0}, &Vector2D{0, 1}, &Vector2D{-1, 0}
+	for _, v := range []struct {
+		d    Vector2D
+		want int
+	}{
+		{Vector2D{0, 0}, 1},
+		{Vector2D{2, 0}, -1},
+		{Vector2D{0, -1}, 0},
+	} {
+		if got := InCircle(a, b, c, &v.d); got != v.want {
+			t.Error("InCircle", a, b, c, v.d, "want", v.want, "got", got)
+		}
+		if got := InCircle(c, b, a, &v.d); got != -v.want {
+			t.Error("InCircle", c, b, a, v.d, "want", -v.want, "got", got)
+		}
+	}
+
+	// points very close to the circle
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		s, c := math.Sincos(r.Float64() * 2 * math.Pi)
+		d := &Vector2D{c, s}
+		if got, want := InCircle(a, b, &Vector2D{-1, 0}, d), InCircleExact(a, b, &Vector2D{-1, 0}, d); got != want {
+			t.Fatal("InCircle", d, "want", want, "got", got)
+		}
+	}
+}
+
+func TestInSphere(t *testing.T) {
+	a, b, c, d := &Vector3D{1, 0, 0}, &Vector3D{0, 1, 0}, &Vector3D{-1, 0, 0}, &Vector3D{0, 0, 1}
+	if o := Orient3D(a, b, c, d); o != -1 {
+		t.Fatal("Orient3D", a, b, c, d, "want", -1, "got", o)
+	}
+	// swap two points for a positive orientation
+	a, b = b, a
+	for _, v := range []struct {
+		e    Vector3D
+		want int
+	}{
+		{Vector3D{0, 0, 0}, 1},
+		{Vector3D{0, 0, 2}, -1},
+		{Vector3D{0, -1, 0}, 0},
+	} {
+		if got := InSphere(a, b, c, d, &v.e); got != v.want {
+			t.Error("InSphere", a, b, c, d, v.e, "want", v.want, "got", got)
+		}
+		if got := InSphereExact(a, b, c, d, &v.e); got != v.want {
+			t.Error("InSphereExact", a, b, c, d, v.e, "want", v.want, "got", got)
+		}
+	}
+
+	// points very close to the sphere
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		e := &Vector3D{r.NormFloat64(), r.NormFloat64(), r.NormFloat64()}
+		e.Normalize()
+		if got, want := InSphere(a, b, c, d, e), InSphereExact(a, b, c, d, e); got != want {
+			t.Fatal("InSphere", e, "want", want, "got", got)
+		}
+	}
+}
+
+func TestPredicatesUnderflow(t *testing.T) {
+	a, b, c := &Vector2D{0, 0}, &Vector2D{1e-200, 0}, &Vector2D{0, 1e-200}
+	if got := Orient2D(a, b, c); got != 1 {
+		t.Error("Orient2D", a, b, c, "want 1 got", got)
+	}
+	// near degenerate input scaled down until its products underflow
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		s := math.Ldexp(1, -200-r.Intn(800))
+		v := func(x float64) float64 { return x * s }
+		p, q := Vector2D{v(1), v(1)}, Vector2D{v(3), v(3 + r.Float64()*0x1p-50)}
+		o := Vector2D{v(r.Float64()), v(r.Float64())}
+		if got, want := Orient2D(&p, &q, &o), Orient2DExact(&p, &q, &o); got != want {
+			t.Fatal("Orient2D", p, q, o, "want", want, "got", got)
+		}
+		d := Vector2D{v(1 + r.Float64()*0x1p-50), v(0)}
+		a, b, c := Vector2D{v(1), v(0)}, Vector2D{v(0), v(1)}, Vector2D{v(-1), v(0)}
+		if got, want := InCircle(&a, &b, &c, &d), InCircleExact(&a, &b, &c, &d); got != want {
+			t.Fatal("InCircle", a, b, c, d, "want", want, "got", got)
+		}
+		a3, b3, c3 := Vector3D{v(0), v(0), v(0)}, Vector3D{v(1), v(0), v(0)}, Vector3D{v(0), v(1), v(0)}
+		d3 := Vector3D{v(r.Float64()), v(r.Float64()), v(r.Float64() * 0x1p-50)}
+		if got, want := Orient3D(&a3, &b3, &c3, &d3), Orient3DExact(&a3, &b3, &c3, &d3); got != want {
+			t.Fatal("Orient3D", a3, b3, c3, d3, "want", want, "got", got)
+		}
+		e3 := Vector3D{v(0), v(-1), v(r.Float64() * 0x1p-50)}
+		b3, c3, d3 = Vector3D{v(0), v(1), v(0)}, Vector3D{v(-1), v(0), v(0)}, Vector3D{v(0), v(0), v(1)}
+		a3 = Vector3D{v(1), v(0), v(0)}
+		if got, want := InSphere(&b3, &a3, &c3, &d3, &e3), InSphereExact(&b3, &a3, &c3, &d3, &e3); got != want {
+			t.Fatal("InSphere", e3, "want", want, "got", got)
+		}
+	}
+}
+
+func TestPredicatesExpansion(t *testing.T) {
+	// near degenerate input of mixed magnitudes, so the differences are not
+	// exact and the floating point evaluation can't decide
+	r := rand.New(rand.NewSource(1))
+	v := func() float64 { return math.Ldexp(r.Float64()-0.5, r.Intn(40)-20) }
+	for i := 0; i < 2000; i++ {
+		p, q := Vector2D{v(), v()}, Vector2D{v(), v()}
+		u := r.Float64()
+		o := Vector2D{p.X + u*(q.X-p.X), p.Y + u*(q.Y-p.Y)}
+		if got, want := Orient2D(&p, &q, &o), Orient2DExact(&p, &q, &o); got != want {
+			t.Fatal("Orient2D", p, q, o, "want", want, "got", got)
+		}
+		c, s := Vector2D{v(), v()}, math.Ldexp(1, r.Intn(40)-20)
+		var a [3]Vector2D
+		for j := range a {
+			y, x := math.Sincos(float64(j) * 2)
+			a[j] = Vector2D{c.X + s*x, c.Y + s*y}
+		}
+		y, x := math.Sincos(r.Float64() * 2 * math.Pi)
+		d := Vector2D{c.X + s*x, c.Y + s*y}
+		if got, want := InCircle(&a[0], &a[1], &a[2], &d), InCircleExact(&a[0], &a[1], &a[2], &d); got != want {
+			t.Fatal("InCircle", a, d, "want", want, "got", got)
+		}
+		p3, q3, s3 := Vector3D{v(), v(), v()}, Vector3D{v(), v(), v()}, Vector3D{v(), v(), v()}
+		u, w := r.Float64(), r.Float64()
+		o3 := Vector3D{p3.X + u*(q3.X-p3.X) + w*(s3.X-p3.X), p3.Y + u*(q3.Y-p3.Y) + w*(s3.Y-p3.Y),
+			p3.Z + u*(q3.Z-p3.Z) + w*(s3.Z-p3.Z)}
+		if got, want := Orient3D(&p3, &q3, &s3, &o3), Orient3DExact(&p3, &q3, &s3, &o3); got != want {
+			t.Fatal("Orient3D", p3, q3, s3, o3, "want", want, "got", got)
+		}
+	}
+}
+
+func TestProductSumSign(t *testing.T) {
+	if s := productSumSign(1, 1, -1, 1); s != 0 {
+		t.Error("productSumSign want 0 got", s)
+	}
+	// the naive sum rounds to zero
+	if s := productSumSign(1, 1, 0x1p-60, 1, -1, 1); s != 1 {
+		t.Error("productSumSign want 1 got", s)
+	}
+	if s := productSumSign(1e-200, 1e-200, -1e-200, 0.5e-200); s != 1 {
+		t.Error("productSumSign underflow want 1 got", s)
+	}
+}
+
+func Benchmark_Orient2D(b *testing.B) {
+	p, q, r := &Vector2D{0.5, 0.5}, &Vector2D{12, 12}, &Vector2D{24, 24.5}
+	for i := 0; i < b.N; i++ {
+		Orient2D(p, q, r)
+	}
+}
+
+func Benchmark_Orient2DExact(b *testing.B) {
+	p, q, r := &Vector2D{0.5, 0.5}, &Vector2D{12, 12}, &Vector2D{24, 24.5}
+	for i := 0; i < b.N; i++ {
+		Orient2DExact(p, q, r)
+	}
+}
+
+func Benchmark_Orient2DDegenerate(b *testing.B) {
+	p, q, r := &Vector2D{0.5, 0.5}, &Vector2D{12, 12}, &Vector2D{24, 24}
+	for i := 0; i < b.N; i++ {
+		Orient2D(p, q, r)
+	}
+}
+
+func Benchmark_InSphere(b *testing.B) {
+	p, q, r, s, e := &Vector3D{0, 1, 0}, &Vector3D{1, 0, 0}, &Vector3D{-1, 0, 0}, &Vector3D{0, 0, 1}, &Vector3D{0.1, 0.2, 0.3}
+	for i := 0; i < b.N; i++ {
+		InSphere(p, q, r, s, e)
+	}
+}
@@ -0,0 +1,312 @@
+package geometry
+
+import (
+	"math"
+)
+
+// A Quaternion is W + Xi + Yj + Zk. Unit quaternions represent 3D rotations,
+// where q and -q represent the same rotation.
+type Quaternion struct {
+	W, X, Y, Z float64
+}
+
+// An EulerOrder is the sequence of axes a set of three Euler angles rotate
+// about. The rotations are extrinsic (about the fixed world axes) and applied
+// in the order named, so EulerXYZ rotates about x, then y, then z. This is the
+// same as intrinsic rotations (about the rotated axes) in the reverse order.
+type EulerOrder int
+
+// The six Tait-Bryan orders followed by the six proper Euler orders.
+const (
+	EulerXYZ EulerOrder = iota
+	EulerXZY
+	EulerYXZ
+	EulerYZX
+	EulerZXY
+	EulerZYX
+	EulerXYX
+	EulerXZX
+	EulerYXY
+	EulerYZY
+	EulerZXZ
+	EulerZYZ
+)
+
+var eulerOrderAxes = [...][3]int{
+	{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0},
+	{0, 1, 0}, {0, 2, 0}, {1, 0, 1}, {1, 2, 1}, {2, 0, 2}, {2, 1, 2},
+}
+
+// NewQuaternion returns a new Quaternion.
+func NewQuaternion(w, x, y, z float64) *Quaternion {
+	return &Quaternion{w, x, y, z}
+}
+
+// Conjugate sets z to the conjugate of x then returns z.
+func (z *Quaternion) Conjugate(x *Quaternion) *Quaternion {
+	z.W = x.W
+	z.X = -x.X
+	z.Y = -x.Y
+	z.Z = -x.Z
+	return z
+}
+
+// Copy sets z to x then returns z.
+func (z *Quaternion) Copy(x *Quaternion) *Quaternion {
+	*z = *x
+	return z
+}
+
+// DotProduct returns the dot product of a and b.
+func (a *Quaternion) DotProduct(b *Quaternion) float64 {
+	return a.W*b.W + a.X*b.X + a.Y*b.Y + a.Z*b.Z
+}
+
+// Equal compares a and b then returns true if they are exactly equal or false
+// otherwise.
+func (a *Quaternion) Equal(b *Quaternion) bool {
+	return *a == *b
+}
+
+// FromAxisAngle sets z to the rotation of angle radians counterclockwise about
+// axis (not necessarily normalized) then returns z.
+func (z *Quaternion) FromAxisAngle(axis *Vector3D, angle float64) *Quaternion {
+	s, c := math.Sincos(angle / 2)
+	s /= math.Sqrt(axis.X*axis.X + axis.Y*axis.Y + axis.Z*axis.Z)
+	z.W = c
+	z.X = axis.X * s
+	z.Y = axis.Y * s
+	z.Z = axis.Z * s
+	return z
+}
+
+// FromEuler sets z to the rotation by the Euler angles a, b, and c about the
+// axes of order then returns z.
+func (z *Quaternion) FromEuler(a, b, c float64, order EulerOrder) *Quaternion {
+	axes := eulerOrderAxes[order]
+	var q [3]Quaternion
+	for i, angle := range [3]float64{a, b, c} {
+		s, c := math.Sincos(angle / 2)
+		q[i].W = c
+		switch axes[i] {
+		case 0:
+			q[i].X = s
+		case 1:
+			q[i].Y = s
+		case 2:
+			q[i].Z = s
+		}
+	}
+	return z.Multiply(q[2].Multiply(&q[2], &q[1]), &q[0])
+}
+
+// FromMatrix sets z to the rotation represented by rotation matrix m then
+// returns z.
+func (z *Quaternion) FromMatrix(m *Matrix3x3) *Quaternion {
+	// http://www.euclideanspace.com/maths/geometry/rotations/conversions/matrixToQuaternion/
+	if t := m[0][0] + m[1][1] + m[2][2]; t > 0 {
+		s := 0.5 / math.Sqrt(t+1)
+		z.W, z.X, z.Y, z.Z = 0.25/s, (m[2][1]-m[1][2])*s, (m[0][2]-m[2][0])*s, (m[1][0]-m[0][1])*s
+	} else if m[0][0] > m[1][1] && m[0][0] > m[2][2] {
+		s := 2 * math.Sqrt(1+m[0][0]-m[1][1]-m[2][2])
+		z.W, z.X, z.Y, z.Z = (m[2][1]-m[1][2])/s, 0.25*s, (m[0][1]+m[1][0])/s, (m[0][2]+m[2][0])/s
+	} else if m[1][1] > m[2][2] {
+		s := 2 * math.Sqrt(1+m[1][1]-m[0][0]-m[2][2])
+		z.W, z.X, z.Y, z.Z = (m[0][2]-m[2][0])/s, (m[0][1]+m[1][0])/s, 0.25*s, (m[1][2]+m[2][1])/s
+	} else {
+		s := 2 * math.Sqrt(1+m[2][2]-m[0][0]-m[1][1])
+		z.W, z.X, z.Y, z.Z = (m[1][0]-m[0][1])/s, (m[0][2]+m[2][0])/s, (m[1][2]+m[2][1])/s, 0.25*s
+	}
+	return z
+}
+
+// FromTwoVectors sets z to the shortest rotation that turns the direction of a
+// into the direction of b then returns z. If a and b point in opposite
+// directions an arbitrary perpendicular axis is used.
+func (z *Quaternion) FromTwoVectors(a, b *Vector3D) *Quaternion {
+	var v Vector3D
+	l := math.Sqrt(a.MagnitudeSquared() * b.MagnitudeSquared())
+	w := l + a.DotProduct(b)
+	v.CrossProduct(a, b)
+	if FuzzyEqual(w/l, 0) {
+		// opposite directions, rotate half a turn about any perpendicular
+		w = 0
+		if math.Abs(a.X) > math.Abs(a.Z) {
+			v.X, v.Y, v.Z = -a.Y, a.X, 0
+		} else {
+			v.X, v.Y, v.Z = 0, -a.Z, a.Y
+		}
+	}
+	z.W, z.X, z.Y, z.Z = w, v.X, v.Y, v.Z
+	return z.Normalize()
+}
+
+// FuzzyEqual compares a and b then returns true if they are very close or
+// false otherwise. Since q and -q represent the same rotation they are
+// considered equal.
+func (a *Quaternion) FuzzyEqual(b *Quaternion) bool {
+	return DefaultTolerance.QuaternionEqual(a, b)
+}
+
+// QuaternionEqual is like Quaternion.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) QuaternionEqual(a, b *Quaternion) bool {
+	return (t.Equal(a.W, b.W) && t.Equal(a.X, b.X) && t.Equal(a.Y, b.Y) && t.Equal(a.Z, b.Z)) ||
+		(t.Equal(a.W, -b.W) && t.Equal(a.X, -b.X) && t.Equal(a.Y, -b.Y) && t.Equal(a.Z, -b.Z))
+}
+
+// Identity sets z to the identity rotation then returns z.
+func (z *Quaternion) Identity() *Quaternion {
+	*z = Quaternion{1, 0, 0, 0}
+	return z
+}
+
+// Inverse sets z to the multiplicative inverse of x then returns z.
+func (z *Quaternion) Inverse(x *Quaternion) *Quaternion {
+	s := 1 / (x.W*x.W + x.X*x.X + x.Y*x.Y + x.Z*x.Z)
+	z.W = x.W * s
+	z.X = -x.X * s
+	z.Y = -x.Y * s
+	z.Z = -x.Z * s
+	return z
+}
+
+// Magnitude returns the magnitude of x.
+func (x *Quaternion) Magnitude() float64 {
+	return math.Sqrt(x.W*x.W + x.X*x.X + x.Y*x.Y + x.Z*x.Z)
+}
+
+// Multiply sets z to the Hamilton product a*b, the rotation b followed by a,
+// then returns z.
+func (z *Quaternion) Multiply(a, b *Quaternion) *Quaternion {
+	z.W, z.X, z.Y, z.Z = a.W*b.W-a.X*b.X-a.Y*b.Y-a.Z*b.Z,
+		a.W*b.X+a.X*b.W+a.Y*b.Z-a.Z*b.Y,
+		a.W*b.Y-a.X*b.Z+a.Y*b.W+a.Z*b.X,
+		a.W*b.Z+a.X*b.Y-a.Y*b.X+a.Z*b.W
+	return z
+}
+
+// Nlerp sets z to the normalized linear interpolation from a to b by t, along
+// the shortest path, then returns z. It is cheaper than Slerp but does not
+// rotate at a constant rate.
+func (z *Quaternion) Nlerp(a, b *Quaternion, t float64) *Quaternion {
+	s := t
+	if a.DotProduct(b) < 0 {
+		s = -t
+	}
+	t = 1 - t
+	z.W = a.W*t + b.W*s
+	z.X = a.X*t + b.X*s
+	z.Y = a.Y*t + b.Y*s
+	z.Z = a.Z*t + b.Z*s
+	return z.Normalize()
+}
+
+// Normalize sets x to a unit quaternion in the same direction as x then
+// returns x.
+func (x *Quaternion) Normalize() *Quaternion {
+	l := 1 / math.Sqrt(x.W*x.W+x.X*x.X+x.Y*x.Y+x.Z*x.Z)
+	x.W *= l
+	x.X *= l
+	x.Y *= l
+	x.Z *= l
+	return x
+}
+
+// Slerp sets z to the spherical linear interpolation from a to b by t, along
+// the shortest path, then returns z. The inputs are assumed to be unit
+// quaternions.
+func (z *Quaternion) Slerp(a, b *Quaternion, t float64) *Quaternion {
+	d := a.DotProduct(b)
+	s := 1.0
+	if d < 0 {
+		d, s = -d, -1
+	}
+	if d > 0.9995 {
+		// nearly parallel, avoid dividing by a tiny sine
+		return z.Nlerp(a, b, t)
+	}
+	theta := math.Acos(d)
+	sin := 1 / math.Sin(theta)
+	s0 := math.Sin((1-t)*theta) * sin
+	s1 := math.Sin(t*theta) * sin * s
+	z.W = a.W*s0 + b.W*s1
+	z.X = a.X*s0 + b.X*s1
+	z.Y = a.Y*s0 + b.Y*s1
+	z.Z = a.Z*s0 + b.Z*s1
+	return z
+}
+
+// ToAxisAngle sets axis to the unit rotation axis of unit quaternion x then
+// returns the rotation angle. For the identity rotation the axis is set to
+// the x axis.
+func (x *Quaternion) ToAxisAngle(axis *Vector3D) float64 {
+	s := math.Sqrt(x.X*x.X + x.Y*x.Y + x.Z*x.Z)
+	if s == 0 {
+		axis.X, axis.Y, axis.Z = 1, 0, 0
+		return 0
+	}
+	axis.X, axis.Y, axis.Z = x.X/s, x.Y/s, x.Z/s
+	return 2 * math.Atan2(s, x.W)
+}
+
+// ToEuler returns the Euler angles about the axes of order that give the same
+// rotation as unit quaternion x. The first and last angles are in [-pi, pi].
+// The middle angle is in [-pi/2, pi/2] for Tait-Bryan orders and [0, pi] for
+// proper Euler orders. At gimbal lock the last angle is set to zero.
+func (x *Quaternion) ToEuler(order EulerOrder) (a, b, c float64) {
+	// Bernardes and Viollet, "Quaternion to Euler angles conversion: A direct,
+	// general and computationally efficient method", PLoS ONE, 2022.
+	axes := eulerOrderAxes[order]
+	i, j, k := axes[0], axes[1], axes[2]
+	proper := i == k
+	if proper {
+		k = 3 - i - j
+	}
+	sign := float64((i - j) * (j - k) * (k - i) / 2)
+	q := [3]float64{x.X, x.Y, x.Z}
+	var qa, qb, qc, qd float64
+	if proper {
+		qa, qb, qc, qd = x.W, q[i], q[j], q[k]*sign
+	} else {
+		qa, qb, qc, qd = x.W-q[j], q[i]+q[k]*sign, q[j]+x.W, q[k]*sign-q[i]
+	}
+	b = 2 * math.Atan2(math.Hypot(qc, qd), math.Hypot(qa, qb))
+	sum, diff := math.Atan2(qb, qa), math.Atan2(qd, qc)
+	switch {
+	case math.Abs(b) < 1e-9:
+		a = 2 * sum
+	case math.Abs(b-math.Pi) < 1e-9:
+		a = -2 * diff
+	default:
+		a, c = sum-diff, sum+diff
+	}
+	if !proper {
+		c *= sign
+		b -= math.Pi / 2
+	}
+	return wrapAngle(a), b, wrapAngle(c)
+}
+
+// ToMatrix sets z to the rotation matrix of unit quaternion x then returns z.
+func (x *Quaternion) ToMatrix(z *Matrix3x3) *Matrix3x3 {
+	xx, yy, zz := x.X*x.X, x.Y*x.Y, x.Z*x.Z
+	xy, xz, yz := x.X*x.Y, x.X*x.Z, x.Y*x.Z
+	wx, wy, wz := x.W*x.X, x.W*x.Y, x.W*x.Z
+	*z = Matrix3x3{
+		{1 - 2*(yy+zz), 2 * (xy - wz), 2 * (xz + wy)},
+		{2 * (xy + wz), 1 - 2*(xx+zz), 2 * (yz - wx)},
+		{2 * (xz - wy), 2 * (yz + wx), 1 - 2*(xx+yy)},
+	}
+	return z
+}
+
+// wrapAngle returns a equivalent angle to a in [-pi, pi].
+func wrapAngle(a float64) float64 {
+	if a > math.Pi {
+		return a - 2*math.Pi
+	}
+	if a < -math.Pi {
+		return a + 2*math.Pi
+	}
+	return a
+}
@@ -0,0 +1,159 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuaternionFromAxisAngle(t *testing.T) {
+	var q Quaternion
+	var v Vector3D
+	q.FromAxisAngle(&Vector3D{0, 0, 3}, math.Pi/2)
+	if !v.Rotate(&q, &Vector3D{1, 0, 0}).FuzzyEqual(&Vector3D{0, 1, 0}) {
+		t.Error("Quaternion.FromAxisAngle", q, "got", v)
+	}
+	angle := q.ToAxisAngle(&v)
+	if !FuzzyEqual(angle, math.Pi/2) || !v.FuzzyEqual(&Vector3D{0, 0, 1}) {
+		t.Error("Quaternion.ToAxisAngle", q, "got", v, angle)
+	}
+}
+
+type quaternionFromTwoVectorsData struct {
+	a, b Vector3D
+}
+
+var quaternionFromTwoVectorsValues = []quaternionFromTwoVectorsData{
+	{Vector3D{1, 0, 0}, Vector3D{0, 2, 0}},
+	{Vector3D{1, 2, 3}, Vector3D{-3, 1, 2}},
+	{Vector3D{1, 2, 3}, Vector3D{2, 4, 6}},
+	{Vector3D{1, 2, 3}, Vector3D{-1, -2, -3}},
+	{Vector3D{0, 0, 1}, Vector3D{0, 0, -1}},
+	{Vector3D{1, 0, 0}, Vector3D{-1, 0, 0}},
+}
+
+func testQuaternionFromTwoVectors(d quaternionFromTwoVectorsData, t *testing.T) {
+	var q Quaternion
+	var v, want Vector3D
+	q.FromTwoVectors(&d.a, &d.b)
+	v.Rotate(&q, &d.a).Normalize()
+	if !v.FuzzyEqual(want.Normalized(&d.b)) || !FuzzyEqual(q.Magnitude(), 1) {
+		t.Error("Quaternion.FromTwoVectors", d.a, d.b, "got", q, v)
+	}
+}
+
+func TestQuaternionFromTwoVectors(t *testing.T) {
+	for _, v := range quaternionFromTwoVectorsValues {
+		testQuaternionFromTwoVectors(v, t)
+	}
+}
+
+func TestQuaternionFuzzyEqual(t *testing.T) {
+	q := &Quaternion{0.5, 0.5, -0.5, 0.5}
+	if !q.FuzzyEqual(&Quaternion{-0.5, -0.5, 0.5, -0.5}) {
+		t.Error("Quaternion.FuzzyEqual negated")
+	}
+	if q.FuzzyEqual(&Quaternion{0.5, -0.5, 0.5, -0.5}) {
+		t.Error("Quaternion.FuzzyEqual conjugate")
+	}
+}
+
+func TestQuaternionInverse(t *testing.T) {
+	q := &Quaternion{1, 2, 3, 4}
+	var r Quaternion
+	if !r.Multiply(q, r.Inverse(q)).FuzzyEqual(new(Quaternion).Identity()) {
+		t.Error("Quaternion.Inverse", q, "got", r)
+	}
+	q.Normalize()
+	if !r.Inverse(q).FuzzyEqual(r.Conjugate(q)) {
+		t.Error("Quaternion.Conjugate", q, "got", r)
+	}
+}
+
+func TestQuaternionMultiply(t *testing.T) {
+	var a, b, q Quaternion
+	var v1, v2 Vector3D
+	a.FromAxisAngle(&Vector3D{1, 2, 3}, 0.4)
+	b.FromAxisAngle(&Vector3D{-1, 0, 2}, 1.3)
+	p := &Vector3D{3, -1, 2}
+	v1.Rotate(&a, v1.Rotate(&b, p))
+	if !v2.Rotate(q.Multiply(&a, &b), p).FuzzyEqual(&v1) {
+		t.Error("Quaternion.Multiply", "want", v1, "got", v2)
+	}
+}
+
+func Benchmark_Quaternion_Multiply(b *testing.B) {
+	q1, q2 := &Quaternion{1, 2, 3, 4}, &Quaternion{5, 6, 7, 8}
+	for i := 0; i < b.N; i++ {
+		q1.Multiply(q1, q2)
+	}
+}
+
+func TestQuaternionSlerp(t *testing.T) {
+	var a, b, q, want Quaternion
+	a.Identity()
+	b.FromAxisAngle(&Vector3D{0, 1, 0}, 2)
+	if !q.Slerp(&a, &b, 0.25).FuzzyEqual(want.FromAxisAngle(&Vector3D{0, 1, 0}, 0.5)) {
+		t.Error("Quaternion.Slerp", "want", want, "got", q)
+	}
+	// the shortest path is taken when the inputs are in opposite hemispheres
+	b.W, b.X, b.Y, b.Z = -b.W, -b.X, -b.Y, -b.Z
+	if !q.Slerp(&a, &b, 0.25).FuzzyEqual(&want) {
+		t.Error("Quaternion.Slerp negated", "want", want, "got", q)
+	}
+	if !q.Nlerp(&a, &b, 0.5).FuzzyEqual(want.FromAxisAngle(&Vector3D{0, 1, 0}, 1)) {
+		t.Error("Quaternion.Nlerp", "want", want, "got", q)
+	}
+}
+
+func TestQuaternionToMatrix(t *testing.T) {
+	var q, r Quaternion
+	var m, want Matrix3x3
+	axis := &Vector3D{1, -2, 0.5}
+	for _, angle := range []float64{0, 0.3, 2, math.Pi, -3} {
+		q.FromAxisAngle(axis, angle)
+		if !q.ToMatrix(&m).FuzzyEqual(want.Rotation(axis, angle)) {
+			t.Error("Quaternion.ToMatrix", q, "want", want, "got", m)
+		}
+		if !r.FromMatrix(&m).FuzzyEqual(&q) {
+			t.Error("Quaternion.FromMatrix", m, "want", q, "got", r)
+		}
+	}
+}
+
+var quaternionEulerAngles = [][3]float64{
+	{0.1, 0.2, 0.3},
+	{-2.5, 1.2, 3},
+	{1, -1.4, -0.5},
+	{0.7, 0, 0.2},
+	{0.3, math.Pi / 2, 0},
+	{0.3, -math.Pi / 2, 0},
+	{-1.2, math.Pi, 0},
+	{0.4, 0, 0},
+}
+
+func TestQuaternionEuler(t *testing.T) {
+	var q, r Quaternion
+	var m, mx Matrix3x3
+	unit := [3]Vector3D{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	for order := EulerXYZ; order <= EulerZYZ; order++ {
+		axes := eulerOrderAxes[order]
+		for _, e := range quaternionEulerAngles {
+			if axes[0] == axes[2] {
+				// proper Euler orders have the middle angle in [0, pi]
+				e[1] = math.Abs(e[1])
+			}
+			q.FromEuler(e[0], e[1], e[2], order)
+			m.Identity()
+			for i := 0; i < 3; i++ {
+				m.Multiply(mx.Rotation(&unit[axes[i]], e[i]), &m)
+			}
+			if !r.FromMatrix(&m).FuzzyEqual(&q) {
+				t.Error("Quaternion.FromEuler", order, e, "want", r, "got", q)
+			}
+			a, b, c := q.ToEuler(order)
+			if !r.FromEuler(a, b, c, order).FuzzyEqual(&q) {
+				t.Error("Quaternion.ToEuler", order, e, "got", a, b, c)
+			}
+		}
+	}
+}
@@ -0,0 +1,632 @@
+package geometry
+
+import (
+	"math"
+)
+
+// SimplifyDouglasPeucker2D returns the indices, in order, of the points of the
+// polyline through points kept by Douglas-Peucker simplification. The ends are
+// always kept and the points between two kept points are dropped when none is
+// further than tol from the line segment joining them. If topology is true
+// more points are kept where needed so the result only crosses or touches
+// itself where points does, a polyline whose first and last points are equal
+// being treated as a ring.
+func SimplifyDouglasPeucker2D(points []Vector2D, tol float64, topology bool) []int {
+	return simplifyDouglasPeucker(simplifyPoints2D(points), len(points), tol, topology)
+}
+
+// SimplifyDouglasPeucker3D is like SimplifyDouglasPeucker2D but for points in
+// 3D.
+func SimplifyDouglasPeucker3D(points []Vector3D, tol float64, topology bool) []int {
+	return simplifyDouglasPeucker(simplifyPoints3D(points), len(points), tol, topology)
+}
+
+// SimplifyVisvalingam2D returns the indices, in order, of the points of the
+// polyline through points kept by Visvalingam-Whyatt simplification. The ends
+// are always kept and the point forming the smallest triangle with its
+// neighbors is dropped, repeatedly, while that triangle's area is at most
+// area. If topology is true a point isn't dropped while doing so would make the
+// result cross or touch itself, a polyline whose first and last points are
+// equal being treated as a ring.
+func SimplifyVisvalingam2D(points []Vector2D, area float64, topology bool) []int {
+	return simplifyVisvalingam(simplifyPoints2D(points), len(points), area, topology)
+}
+
+// SimplifyVisvalingam3D is like SimplifyVisvalingam2D but for points in 3D.
+func SimplifyVisvalingam3D(points []Vector3D, area float64, topology bool) []int {
+	return simplifyVisvalingam(simplifyPoints3D(points), len(points), area, topology)
+}
+
+// simplifyPoints is the polyline being simplified, with the operations the
+// simplifications need from its points.
+type simplifyPoints interface {
+	// coord returns the coordinates of point i, Z being zero in 2D.
+	coord(i int) [3]float64
+
+	// farthest returns the point between a and b furthest from the line
+	// segment joining them and its distance.
+	farthest(a, b int) (int, float64)
+
+	// area returns the area of the triangle a, b, c.
+	area(a, b, c int) float64
+
+	// meet returns true if the line segment from a1 to a2 crosses or
+	// touches the line segment from b1 to b2, which is always correct.
+	meet(a1, a2, b1, b2 int) bool
+
+	// fold returns true if the line segments from a to joint and from joint
+	// to b overlap, which is always correct.
+	fold(a, joint, b int) bool
+}
+
+// simplifier is a polyline being simplified, the points still in it linked in
+// order. With topology kept, the segments are listed in a uniform grid under
+// the index of their first point in each cell their bounds overlap. Entries
+// go stale as segments are replaced, so found segments are checked against
+// the links.
+type simplifier struct {
+	p          simplifyPoints
+	n          int
+	next, prev []int
+	live       []bool
+	closed     bool
+	from, to   []int // the first and last of the run of equal points of each
+
+	topology bool
+	pending  []int // segments to check, with topology
+	segments int   // segments in the polyline
+	indexed  int   // segments when the grid was built
+	min, max [3]float64
+	size     float64
+	cells    [3]int
+	grid     [][]int
+	seen     []int
+	query    int
+}
+
+// newSimplifier returns the polyline through n points p, linked through all of
+// them if all is true or only its ends otherwise.
+func newSimplifier(p simplifyPoints, n int, all, topology bool) *simplifier {
+	s := &simplifier{p: p, n: n, next: make([]int, n), prev: make([]int, n), live: make([]bool, n), topology: topology}
+	for i := range s.next {
+		s.next[i], s.prev[i], s.live[i] = i+1, i-1, all
+	}
+	s.segments = n - 1
+	if !all {
+		s.next[0], s.prev[n-1], s.live[0] = n-1, 0, true
+		s.segments = 1
+	}
+	s.live[n-1] = true
+	s.closed = n > 2 && p.coord(0) == p.coord(n-1)
+	s.from, s.to = make([]int, n), make([]int, n)
+	for i := range s.from {
+		if i > 0 && p.coord(i) == p.coord(i-1) {
+			s.from[i] = s.from[i-1]
+		} else {
+			s.from[i] = i
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		if i < n-1 && s.from[i+1] == s.from[i] {
+			s.to[i] = s.to[i+1]
+		} else {
+			s.to[i] = i
+		}
+	}
+	if all {
+		// repeated points change nothing, keeping the last end
+		for i := 1; i < n; i++ {
+			if s.from[i] != i {
+				if i < n-1 {
+					s.unlink(i)
+				} else if j := s.prev[i]; j > 0 && s.from[j] == s.from[i] {
+					s.unlink(j)
+				}
+			}
+		}
+	}
+	return s
+}
+
+// unlink drops point i from the polyline.
+func (s *simplifier) unlink(i int) {
+	a, b := s.prev[i], s.next[i]
+	s.next[a], s.prev[b], s.live[i] = b, a, false
+	s.segments--
+}
+
+// original returns true if the points between a and b are all equal to one of
+// them, so the line segment between them is part of the polyline, or false
+// otherwise.
+func (s *simplifier) original(a, b int) bool {
+	return s.from[b] <= s.to[a]+1
+}
+
+// simplifyDouglasPeucker returns the indices kept by Douglas-Peucker
+// simplification of the n points p.
+func simplifyDouglasPeucker(p simplifyPoints, n int, tol float64, topology bool) []int {
+	if n < 3 {
+		return simplifyAll(n)
+	}
+	s := newSimplifier(p, n, false, false)
+	s.peucker(0, tol)
+	if topology {
+		s.topology = true
+		s.index()
+		for i := 0; i < n-1; i = s.next[i] {
+			s.pending = append(s.pending, i)
+		}
+		for len(s.pending) > 0 {
+			a := s.pending[len(s.pending)-1]
+			s.pending = s.pending[:len(s.pending)-1]
+			k := s.conflict(a, s.next[a], -1)
+			if k < 0 {
+				continue
+			}
+			// at least one of the segments skips points, split it at the
+			// point furthest from it and simplify the halves again
+			if s.original(a, s.next[a]) {
+				a, k = k, a
+			}
+			m, _ := p.farthest(a, s.next[a])
+			s.link(a, m)
+			s.peucker(a, tol)
+			s.peucker(m, tol)
+			s.pending = append(s.pending, k)
+		}
+	}
+	return s.indices()
+}
+
+// peucker keeps the points after a up to the next kept point that are further
+// than tol from the line segment between kept points. It keeps its own stack
+// of segments so that it can't overflow the call stack.
+func (s *simplifier) peucker(a int, tol float64) {
+	stack := [][2]int{{a, s.next[a]}}
+	for len(stack) > 0 {
+		r := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if r[1]-r[0] < 2 {
+			continue
+		}
+		if k, d := s.p.farthest(r[0], r[1]); d > tol {
+			s.link(r[0], k)
+			stack = append(stack, [2]int{r[0], k}, [2]int{k, r[1]})
+		}
+	}
+}
+
+// link keeps point m, which comes after a and before the next kept point.
+func (s *simplifier) link(a, m int) {
+	b := s.next[a]
+	s.next[a], s.prev[m], s.next[m], s.prev[b] = m, a, b, m
+	s.live[m] = true
+	s.segments++
+	if s.topology {
+		if s.segments > 2*s.indexed {
+			s.index()
+		} else {
+			s.insert(a)
+			s.insert(m)
+		}
+		s.pending = append(s.pending, a, m)
+	}
+}
+
+// simplifyHeap is a min heap of points by the area of the triangles they form
+// with their neighbors, which can be changed in place.
+type simplifyHeap struct {
+	area []float64
+	heap []int
+	pos  []int // the position of each point in heap or -1
+}
+
+// set sets the area of point i, adding it to the heap if it isn't there.
+func (h *simplifyHeap) set(i int, area float64) {
+	old := h.area[i]
+	h.area[i] = area
+	switch {
+	case h.pos[i] < 0:
+		h.pos[i] = len(h.heap)
+		h.heap = append(h.heap, i)
+		h.up(h.pos[i])
+	case area < old:
+		h.up(h.pos[i])
+	default:
+		h.down(h.pos[i])
+	}
+}
+
+// pop removes and returns the point with the smallest area.
+func (h *simplifyHeap) pop() int {
+	i := h.heap[0]
+	h.swap(0, len(h.heap)-1)
+	h.heap = h.heap[:len(h.heap)-1]
+	h.pos[i] = -1
+	h.down(0)
+	return i
+}
+
+func (h *simplifyHeap) up(k int) {
+	for k > 0 {
+		p := (k - 1) / 2
+		if h.area[h.heap[p]] <= h.area[h.heap[k]] {
+			return
+		}
+		h.swap(p, k)
+		k = p
+	}
+}
+
+func (h *simplifyHeap) down(k int) {
+	for {
+		c := 2*k + 1
+		if c >= len(h.heap) {
+			return
+		}
+		if c+1 < len(h.heap) && h.area[h.heap[c+1]] < h.area[h.heap[c]] {
+			c++
+		}
+		if h.area[h.heap[k]] <= h.area[h.heap[c]] {
+			return
+		}
+		h.swap(k, c)
+		k = c
+	}
+}
+
+func (h *simplifyHeap) swap(k, l int) {
+	h.heap[k], h.heap[l] = h.heap[l], h.heap[k]
+	h.pos[h.heap[k]], h.pos[h.heap[l]] = k, l
+}
+
+// simplifyVisvalingam returns the indices kept by Visvalingam-Whyatt
+// simplification of the n points p.
+func simplifyVisvalingam(p simplifyPoints, n int, area float64, topology bool) []int {
+	if n < 3 {
+		return simplifyAll(n)
+	}
+	s := newSimplifier(p, n, true, topology)
+	if topology {
+		s.index()
+	}
+	h := simplifyHeap{area: make([]float64, n), pos: make([]int, n)}
+	for i := range h.pos {
+		h.pos[i] = -1
+	}
+	for i := s.next[0]; i < n-1; i = s.next[i] {
+		h.set(i, p.area(s.prev[i], i, s.next[i]))
+	}
+	for len(h.heap) > 0 && h.area[h.heap[0]] <= area {
+		i := h.pop()
+		a, b := s.prev[i], s.next[i]
+		// a point that can't be dropped yet is queued again when one of its
+		// neighbors is
+		if topology && s.conflict(a, b, i) >= 0 {
+			continue
+		}
+		s.unlink(i)
+		if topology {
+			if 2*s.segments < s.indexed {
+				s.index()
+			} else {
+				s.insert(a)
+			}
+		}
+		for _, j := range [2]int{a, b} {
+			if j > 0 && j < n-1 {
+				h.set(j, p.area(s.prev[j], j, s.next[j]))
+			}
+		}
+	}
+	return s.indices()
+}
+
+// conflict returns the first point of a segment in the polyline that would
+// meet the line segment from a to b other than at their shared points and
+// where the polyline doesn't, or -1 if there are none. The segments from a to
+// skip and from skip to b, if skip isn't -1, are about to be replaced.
+func (s *simplifier) conflict(a, b, skip int) int {
+	last := s.n - 1
+	conflict := -1
+	s.visit(a, b, func(k int) bool {
+		if k == a || k == skip {
+			return false
+		}
+		switch {
+		case s.original(a, b) && s.original(k, s.next[k]):
+			return false
+		case k == s.prev[a]:
+			if !s.p.fold(k, a, b) {
+				return false
+			}
+		case k == b:
+			if !s.p.fold(a, b, s.next[k]) {
+				return false
+			}
+		case s.closed && a == 0 && s.next[k] == last:
+			if !s.p.fold(k, last, b) {
+				return false
+			}
+		case s.closed && k == 0 && b == last:
+			if !s.p.fold(a, last, s.next[0]) {
+				return false
+			}
+		case !s.p.meet(a, b, k, s.next[k]):
+			return false
+		}
+		conflict = k
+		return true
+	})
+	return conflict
+}
+
+// index builds the grid of the segments in the polyline, with about a cell
+// for each.
+func (s *simplifier) index() {
+	if s.seen == nil {
+		s.seen = make([]int, s.n)
+		s.min = s.p.coord(0)
+		s.max = s.min
+		for i := 1; i < s.n; i++ {
+			c := s.p.coord(i)
+			for j := range c {
+				s.min[j] = math.Min(s.min[j], c[j])
+				s.max[j] = math.Max(s.max[j], c[j])
+			}
+		}
+	}
+	s.size = 0
+	for j := range s.min {
+		s.size = math.Max(s.size, s.max[j]-s.min[j])
+	}
+	if s.size == 0 || math.IsInf(s.size, 0) || math.IsNaN(s.size) {
+		s.size = math.Inf(1)
+	}
+	for k := 0; k < 64; k++ {
+		cells := 1
+		for j := range s.cells {
+			s.cells[j] = int(math.Min((s.max[j]-s.min[j])/s.size, float64(s.n))) + 1
+			cells *= s.cells[j]
+		}
+		if cells >= s.segments || math.IsInf(s.size, 0) {
+			break
+		}
+		s.size /= 2
+	}
+	s.grid = make([][]int, s.cells[0]*s.cells[1]*s.cells[2])
+	s.indexed = s.segments
+	for i := 0; i < s.n-1; i = s.next[i] {
+		s.insert(i)
+	}
+}
+
+// cellRange returns the range of cells overlapped by the bounds of points a
+// and b.
+func (s *simplifier) cellRange(a, b int) (lo, hi [3]int) {
+	ca, cb := s.p.coord(a), s.p.coord(b)
+	for j := range ca {
+		lo[j] = s.cell(math.Min(ca[j], cb[j]), j)
+		hi[j] = s.cell(math.Max(ca[j], cb[j]), j)
+	}
+	return lo, hi
+}
+
+// cell returns the cell along axis j containing coordinate x.
+func (s *simplifier) cell(x float64, j int) int {
+	c := int(math.Max((x-s.min[j])/s.size, 0))
+	if c >= s.cells[j] || c < 0 {
+		c = s.cells[j] - 1
+	}
+	return c
+}
+
+// insert lists the segment starting at point a in the cells it overlaps.
+func (s *simplifier) insert(a int) {
+	lo, hi := s.cellRange(a, s.next[a])
+	for x := lo[0]; x <= hi[0]; x++ {
+		for y := lo[1]; y <= hi[1]; y++ {
+			for z := lo[2]; z <= hi[2]; z++ {
+				c := (x*s.cells[1]+y)*s.cells[2] + z
+				s.grid[c] = append(s.grid[c], a)
+			}
+		}
+	}
+}
+
+// visit calls f once with the first point of each segment in the polyline
+// listed in the cells overlapped by the bounds of points a and b, until f
+// returns true.
+func (s *simplifier) visit(a, b int, f func(k int) bool) {
+	s.query++
+	lo, hi := s.cellRange(a, b)
+	for x := lo[0]; x <= hi[0]; x++ {
+		for y := lo[1]; y <= hi[1]; y++ {
+			for z := lo[2]; z <= hi[2]; z++ {
+				for _, k := range s.grid[(x*s.cells[1]+y)*s.cells[2]+z] {
+					if s.seen[k] == s.query || !s.live[k] || k == s.n-1 {
+						continue
+					}
+					s.seen[k] = s.query
+					if f(k) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// indices returns the points in the polyline.
+func (s *simplifier) indices() []int {
+	z := make([]int, 0, s.segments+1)
+	for i := 0; i < s.n; i = s.next[i] {
+		z = append(z, i)
+	}
+	return z
+}
+
+// simplifyAll returns the indices of n points.
+func simplifyAll(n int) []int {
+	var z []int
+	for i := 0; i < n; i++ {
+		z = append(z, i)
+	}
+	return z
+}
+
+// simplifyPoints2D is a polyline in 2D.
+type simplifyPoints2D []Vector2D
+
+func (x simplifyPoints2D) coord(i int) [3]float64 {
+	return [3]float64{x[i].X, x[i].Y, 0}
+}
+
+func (x simplifyPoints2D) farthest(a, b int) (int, float64) {
+	l := Line2D{P: x[a]}
+	l.V.Subtract(&x[b], &x[a])
+	k, d := a+1, -1.0
+	for i := a + 1; i < b; i++ {
+		if e := Distance2DLineSegmentPoint(&l, &x[i]); e > d {
+			k, d = i, e
+		}
+	}
+	return k, d
+}
+
+func (x simplifyPoints2D) area(a, b, c int) float64 {
+	t := Triangle2D{x[a], x[b], x[c]}
+	return t.Area()
+}
+
+func (x simplifyPoints2D) meet(a1, a2, b1, b2 int) bool {
+	a, b := x.coord(a1), x.coord(a2)
+	c, d := x.coord(b1), x.coord(b2)
+	return boundsOverlap(&a, &b, &c, &d) && segmentsMeet2D(&x[a1], &x[a2], &x[b1], &x[b2])
+}
+
+func (x simplifyPoints2D) fold(a, joint, b int) bool {
+	return Orient2D(&x[a], &x[joint], &x[b]) == 0 &&
+		(x[joint].X-x[a].X)*(x[b].X-x[joint].X)+(x[joint].Y-x[a].Y)*(x[b].Y-x[joint].Y) < 0
+}
+
+// simplifyPoints3D is a polyline in 3D.
+type simplifyPoints3D []Vector3D
+
+func (x simplifyPoints3D) coord(i int) [3]float64 {
+	return [3]float64{x[i].X, x[i].Y, x[i].Z}
+}
+
+func (x simplifyPoints3D) farthest(a, b int) (int, float64) {
+	l := Line3D{P: x[a]}
+	l.V.Subtract(&x[b], &x[a])
+	k, d := a+1, -1.0
+	for i := a + 1; i < b; i++ {
+		var e float64
+		if x[a] == x[b] {
+			e = Distance3DPointPoint(&x[a], &x[i])
+		} else {
+			e = Distance3DLineSegmentPoint(&l, &x[i])
+		}
+		if e > d {
+			k, d = i, e
+		}
+	}
+	return k, d
+}
+
+func (x simplifyPoints3D) area(a, b, c int) float64 {
+	t := Triangle3D{x[a], x[b], x[c]}
+	return t.Area()
+}
+
+func (x simplifyPoints3D) meet(a1, a2, b1, b2 int) bool {
+	a, b := x.coord(a1), x.coord(a2)
+	c, d := x.coord(b1), x.coord(b2)
+	return boundsOverlap(&a, &b, &c, &d) && segmentsMeet3D(&x[a1], &x[a2], &x[b1], &x[b2])
+}
+
+func (x simplifyPoints3D) fold(a, joint, b int) bool {
+	if !collinear3D(&x[a], &x[joint], &x[b]) {
+		return false
+	}
+	var u, v Vector3D
+	u.Subtract(&x[joint], &x[a])
+	v.Subtract(&x[b], &x[joint])
+	// u and v are parallel so their dot product can't cancel
+	return u.DotProduct(&v) < 0
+}
+
+// boundsOverlap returns true if the bounds of a1 and a2 overlap the bounds of
+// b1 and b2 or false otherwise.
+func boundsOverlap(a1, a2, b1, b2 *[3]float64) bool {
+	for j := range a1 {
+		if math.Max(a1[j], a2[j]) < math.Min(b1[j], b2[j]) || math.Max(b1[j], b2[j]) < math.Min(a1[j], a2[j]) {
+			return false
+		}
+	}
+	return true
+}
+
+// segmentsMeet2D returns true if the line segment from a1 to a2 crosses or
+// touches the line segment from b1 to b2 or false otherwise, which is always
+// correct.
+func segmentsMeet2D(a1, a2, b1, b2 *Vector2D) bool {
+	d1, d2 := Orient2D(b1, b2, a1), Orient2D(b1, b2, a2)
+	d3, d4 := Orient2D(a1, a2, b1), Orient2D(a1, a2, b2)
+	if d1*d2 < 0 && d3*d4 < 0 {
+		return true
+	}
+	return (d1 == 0 && onSegment(b1, b2, a1)) || (d2 == 0 && onSegment(b1, b2, a2)) ||
+		(d3 == 0 && onSegment(a1, a2, b1)) || (d4 == 0 && onSegment(a1, a2, b2))
+}
+
+// segmentsMeet3D is like segmentsMeet2D but for line segments in 3D.
+func segmentsMeet3D(a1, a2, b1, b2 *Vector3D) bool {
+	if Orient3D(a1, a2, b1, b2) != 0 {
+		return false
+	}
+	// the segments lie in a plane, or on a line, which projecting onto a
+	// plane of the axes it isn't perpendicular to doesn't change the
+	// intersection of
+	var p [3][4]Vector2D
+	for j := range p {
+		for i, v := range [4]*Vector3D{a1, a2, b1, b2} {
+			p[j][i] = project3D(v, j)
+		}
+		q := &p[j]
+		if Orient2D(&q[0], &q[1], &q[2]) != 0 || Orient2D(&q[0], &q[1], &q[3]) != 0 || Orient2D(&q[2], &q[3], &q[0]) != 0 {
+			return segmentsMeet2D(&q[0], &q[1], &q[2], &q[3])
+		}
+	}
+	for j := range p {
+		q := &p[j]
+		if q[0] != q[1] || q[0] != q[2] || q[0] != q[3] {
+			return segmentsMeet2D(&q[0], &q[1], &q[2], &q[3])
+		}
+	}
+	return true
+}
+
+// collinear3D returns true if a, b, and c lie on a line or false otherwise,
+// which is always correct.
+func collinear3D(a, b, c *Vector3D) bool {
+	for j := 0; j < 3; j++ {
+		pa, pb, pc := project3D(a, j), project3D(b, j), project3D(c, j)
+		if Orient2D(&pa, &pb, &pc) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// project3D returns v projected onto the plane of the axes other than axis j.
+func project3D(v *Vector3D, j int) Vector2D {
+	switch j {
+	case 0:
+		return Vector2D{v.Y, v.Z}
+	case 1:
+		return Vector2D{v.Z, v.X}
+	}
+	return Vector2D{v.X, v.Y}
+}
@@ -0,0 +1,263 @@
+package geometry
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// checkSimplified returns a description of the first way kept fails to be a
+// simplification of the n points p, within tol if it isn't negative and
+// without new crossings if topology is true, or "" if it doesn't.
+func checkSimplified(p simplifyPoints, n int, kept []int, tol float64, topology bool) string {
+	if n == 0 {
+		if len(kept) != 0 {
+			return "points kept from nothing"
+		}
+		return ""
+	}
+	if len(kept) < 2 && n > 1 || kept[0] != 0 || kept[len(kept)-1] != n-1 {
+		return "ends not kept"
+	}
+	for i := 1; i < len(kept); i++ {
+		if kept[i] <= kept[i-1] {
+			return "indices out of order"
+		}
+		if kept[i]-kept[i-1] < 2 || tol < 0 {
+			continue
+		}
+		if _, d := p.farthest(kept[i-1], kept[i]); d > tol {
+			return "dropped point too far"
+		}
+	}
+	if !topology {
+		return ""
+	}
+	// segments only skipping points equal to their ends are part of p
+	original := func(a, b int) bool {
+		for i := a + 1; i < b; i++ {
+			if c := p.coord(i); c != p.coord(a) && c != p.coord(b) {
+				return false
+			}
+		}
+		return true
+	}
+	closed := n > 2 && p.coord(0) == p.coord(n-1)
+	for i := 1; i < len(kept); i++ {
+		for j := i + 1; j < len(kept); j++ {
+			a1, a2, b1, b2 := kept[i-1], kept[i], kept[j-1], kept[j]
+			if original(a1, a2) && original(b1, b2) {
+				continue
+			}
+			switch {
+			case j == i+1:
+				if p.fold(a1, a2, b2) {
+					return "segments fold back"
+				}
+			case closed && i == 1 && j == len(kept)-1:
+				if p.fold(b1, b2, a2) {
+					return "segments fold back at the ends"
+				}
+			case p.meet(a1, a2, b1, b2):
+				return "segments meet"
+			}
+		}
+	}
+	return ""
+}
+
+var (
+	// a bump which the end of the polyline comes up under, so dropping the
+	// bump crosses it
+	simplify2DHook = []Vector2D{{0, 0}, {2, 1}, {4, 0}, {10, 0}, {10, -3}, {2, -3}, {2, 0.5}}
+	simplify2DBump = []Vector2D{{0, 0}, {1, 0.1}, {2, -0.1}, {3, 0}, {4, 2}, {5, 0}}
+	// a ring which doubles back on itself when simplified too far
+	simplify2DRing = []Vector2D{{0, 0}, {4, 0.5}, {8, 0}, {4, -0.5}, {0, 0}}
+)
+
+var simplifyDouglasPeucker2DValues = []struct {
+	points   []Vector2D
+	tol      float64
+	topology bool
+	kept     []int
+}{
+	{simplify2DBump, 0, false, []int{0, 1, 2, 3, 4, 5}},
+	{simplify2DBump, 0.5, false, []int{0, 3, 4, 5}},
+	{simplify2DBump, 3, false, []int{0, 5}},
+	{simplify2DHook, 1.5, false, []int{0, 3, 4, 5, 6}},
+	{simplify2DHook, 1.5, true, []int{0, 1, 3, 4, 5, 6}},
+	{simplify2DRing, 1, false, []int{0, 2, 4}},
+	{simplify2DRing, 1, true, []int{0, 2, 3, 4}},
+	// collinear points are dropped
+	{[]Vector2D{{0, 0}, {1, 1}, {2, 2}, {2, 2}, {3, 3}}, 0, true, []int{0, 4}},
+	{[]Vector2D{{0, 0}, {1, 1}}, 5, true, []int{0, 1}},
+	{[]Vector2D{{0, 0}}, 5, true, []int{0}},
+	{nil, 5, true, nil},
+}
+
+func TestSimplifyDouglasPeucker2D(t *testing.T) {
+	for _, v := range simplifyDouglasPeucker2DValues {
+		if got := SimplifyDouglasPeucker2D(v.points, v.tol, v.topology); !reflect.DeepEqual(got, v.kept) {
+			t.Error("SimplifyDouglasPeucker2D", v.points, v.tol, v.topology, "want", v.kept, "got", got)
+		}
+	}
+}
+
+var simplifyVisvalingam2DValues = []struct {
+	points   []Vector2D
+	area     float64
+	topology bool
+	kept     []int
+}{
+	{simplify2DBump, 0.1, false, []int{0, 1, 2, 3, 4, 5}},
+	{simplify2DBump, 0.5, false, []int{0, 3, 4, 5}},
+	{simplify2DBump, 2.5, false, []int{0, 5}},
+	{simplify2DHook, 3.5, false, []int{0, 3, 4, 5, 6}},
+	{simplify2DHook, 3.5, true, []int{0, 1, 3, 4, 5, 6}},
+	{simplify2DRing, 3, false, []int{0, 4}},
+	{simplify2DRing, 3, true, []int{0, 2, 3, 4}},
+	{[]Vector2D{{0, 0}, {1, 1}, {2, 2}, {2, 2}, {3, 3}}, 0, true, []int{0, 4}},
+	{[]Vector2D{{0, 0}, {1, 1}}, 5, true, []int{0, 1}},
+	{nil, 5, true, nil},
+}
+
+func TestSimplifyVisvalingam2D(t *testing.T) {
+	for _, v := range simplifyVisvalingam2DValues {
+		if got := SimplifyVisvalingam2D(v.points, v.area, v.topology); !reflect.DeepEqual(got, v.kept) {
+			t.Error("SimplifyVisvalingam2D", v.points, v.area, v.topology, "want", v.kept, "got", got)
+		}
+	}
+}
+
+// simplify3DPlanes lie 2D points in planes in 3D exactly, for small integers
+// and halves, the first two without changing distances.
+var simplify3DPlanes = []func(v Vector2D) Vector3D{
+	func(v Vector2D) Vector3D { return Vector3D{v.X, v.Y, 0} },
+	func(v Vector2D) Vector3D { return Vector3D{v.Y, 1, v.X} },
+	func(v Vector2D) Vector3D { return Vector3D{v.X, v.Y, v.X + v.Y} },
+}
+
+func TestSimplify3D(t *testing.T) {
+	// in a plane the results are the same as in 2D
+	for _, f := range simplify3DPlanes[:2] {
+		for _, v := range simplifyDouglasPeucker2DValues {
+			var points []Vector3D
+			for _, p := range v.points {
+				points = append(points, f(p))
+			}
+			if got := SimplifyDouglasPeucker3D(points, v.tol, v.topology); !reflect.DeepEqual(got, v.kept) {
+				t.Error("SimplifyDouglasPeucker3D", points, v.tol, v.topology, "want", v.kept, "got", got)
+			}
+		}
+		for _, v := range simplifyVisvalingam2DValues {
+			var points []Vector3D
+			for _, p := range v.points {
+				points = append(points, f(p))
+			}
+			if got := SimplifyVisvalingam3D(points, v.area, v.topology); !reflect.DeepEqual(got, v.kept) {
+				t.Error("SimplifyVisvalingam3D", points, v.area, v.topology, "want", v.kept, "got", got)
+			}
+		}
+	}
+	// and in a tilted plane crossings are still found
+	var points []Vector3D
+	for _, p := range simplify2DHook {
+		points = append(points, simplify3DPlanes[2](p))
+	}
+	for _, got := range [][]int{SimplifyDouglasPeucker3D(points, 1.5, true), SimplifyVisvalingam3D(points, 6, true)} {
+		if err := checkSimplified(simplifyPoints3D(points), len(points), got, -1, true); err != "" || len(got) == len(points) {
+			t.Error("Simplify3D", points, err, got)
+		}
+	}
+}
+
+// simplify2DWalk returns a random walk of n steps, on a small grid of integers
+// if grid is true so it often retraces and crosses itself exactly.
+func simplify2DWalk(r *rand.Rand, n int, grid bool) []Vector2D {
+	var p Vector2D
+	z := make([]Vector2D, n)
+	for i := range z {
+		if grid {
+			p.X += float64(r.Intn(5) - 2)
+			p.Y += float64(r.Intn(5) - 2)
+		} else {
+			p.X += r.NormFloat64()
+			p.Y += r.NormFloat64()
+		}
+		z[i] = p
+	}
+	return z
+}
+
+func TestSimplifyRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		points := simplify2DWalk(r, 3+r.Intn(100), i%2 == 0)
+		if i%4 < 2 {
+			points = append(points, points[0])
+		}
+		var points3 []Vector3D
+		for _, p := range points {
+			points3 = append(points3, simplify3DPlanes[2](p))
+		}
+		tol, area := r.Float64()*5, r.Float64()*20
+		for _, topology := range []bool{false, true} {
+			if err := checkSimplified(simplifyPoints2D(points), len(points), SimplifyDouglasPeucker2D(points, tol, topology), tol, topology); err != "" {
+				t.Fatal("SimplifyDouglasPeucker2D", points, tol, topology, err)
+			}
+			if err := checkSimplified(simplifyPoints2D(points), len(points), SimplifyVisvalingam2D(points, area, topology), -1, topology); err != "" {
+				t.Fatal("SimplifyVisvalingam2D", points, area, topology, err)
+			}
+			if err := checkSimplified(simplifyPoints3D(points3), len(points3), SimplifyDouglasPeucker3D(points3, tol, topology), tol, topology); err != "" {
+				t.Fatal("SimplifyDouglasPeucker3D", points3, tol, topology, err)
+			}
+			if err := checkSimplified(simplifyPoints3D(points3), len(points3), SimplifyVisvalingam3D(points3, area, topology), -1, topology); err != "" {
+				t.Fatal("SimplifyVisvalingam3D", points3, area, topology, err)
+			}
+		}
+	}
+}
+
+func TestSimplifyLarge(t *testing.T) {
+	// a million points, which recursing on could go as many calls deep
+	points := simplify2DWalk(rand.New(rand.NewSource(1)), 1000000, false)
+	if kept := SimplifyDouglasPeucker2D(points, 0, false); len(kept) != len(points) {
+		t.Error("SimplifyDouglasPeucker2D kept", len(kept), "of", len(points))
+	}
+	points = points[:20000]
+	if kept := SimplifyDouglasPeucker2D(points, 10, true); len(kept) < 2 || len(kept) >= len(points) {
+		t.Error("SimplifyDouglasPeucker2D kept", len(kept), "of", len(points))
+	}
+}
+
+func Benchmark_SimplifyDouglasPeucker2D(b *testing.B) {
+	points := simplify2DWalk(rand.New(rand.NewSource(1)), 100000, false)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SimplifyDouglasPeucker2D(points, 1, false)
+	}
+}
+
+func Benchmark_SimplifyDouglasPeucker2DTopology(b *testing.B) {
+	points := simplify2DWalk(rand.New(rand.NewSource(1)), 100000, false)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SimplifyDouglasPeucker2D(points, 1, true)
+	}
+}
+
+func Benchmark_SimplifyVisvalingam2D(b *testing.B) {
+	points := simplify2DWalk(rand.New(rand.NewSource(1)), 100000, false)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SimplifyVisvalingam2D(points, 1, false)
+	}
+}
+
+func Benchmark_SimplifyVisvalingam2DTopology(b *testing.B) {
+	points := simplify2DWalk(rand.New(rand.NewSource(1)), 100000, false)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SimplifyVisvalingam2D(points, 1, true)
+	}
+}
@@ -1,7 +1,113 @@
 package geometry
 
+import (
+	"math"
+)
+
 // A Sphere represents all points a given distance, R, from a point, C.
 type Sphere struct {
 	C Vector3D
 	R float64
 }
+
+// Bounds sets z to the smallest box containing x then returns z.
+func (x *Sphere) Bounds(z *AABB3D) *AABB3D {
+	z.Min.X, z.Min.Y, z.Min.Z = x.C.X-x.R, x.C.Y-x.R, x.C.Z-x.R
+	z.Max.X, z.Max.Y, z.Max.Z = x.C.X+x.R, x.C.Y+x.R, x.C.Z+x.R
+	return z
+}
+
+// Contains returns true if point p is inside or on the surface of sphere x or
+// false otherwise.
+func (x *Sphere) Contains(p *Vector3D) bool {
+	dx, dy, dz := p.X-x.C.X, p.Y-x.C.Y, p.Z-x.C.Z
+	return dx*dx+dy*dy+dz*dz <= x.R*x.R
+}
+
+// Copy sets z to x then returns z.
+func (z *Sphere) Copy(x *Sphere) *Sphere {
+	z.C = x.C
+	z.R = x.R
+	return z
+}
+
+// Equal returns true if the two spheres are exactly equal or false otherwise.
+func (a *Sphere) Equal(b *Sphere) bool {
+	return a.C == b.C && a.R == b.R
+}
+
+// FromFourPoints sets z to the sphere through the four points, then returns
+// z. If the points are coplanar (or very close to it) there is no such sphere
+// and the center and radius of z are set to NaN.
+func (z *Sphere) FromFourPoints(p1, p2, p3, p4 *Vector3D) *Sphere {
+	var a, b, c, bc, ca, ab Vector3D
+	a.Subtract(p2, p1)
+	b.Subtract(p3, p1)
+	c.Subtract(p4, p1)
+	bc.CrossProduct(&b, &c)
+	d := a.DotProduct(&bc)
+	if FuzzyEqual(d/math.Sqrt(a.MagnitudeSquared()*b.MagnitudeSquared()*c.MagnitudeSquared()), 0) {
+		z.C.X, z.C.Y, z.C.Z, z.R = math.NaN(), math.NaN(), math.NaN(), math.NaN()
+		return z
+	}
+	ca.CrossProduct(&c, &a)
+	ab.CrossProduct(&a, &b)
+	bc.Scale(&bc, a.MagnitudeSquared())
+	ca.Scale(&ca, b.MagnitudeSquared())
+	ab.Scale(&ab, c.MagnitudeSquared())
+	bc.Add(&bc, &ca).Add(&bc, &ab).Scale(&bc, 1/(2*d))
+	z.R = bc.Magnitude()
+	z.C.Add(p1, &bc)
+	return z
+}
+
+// FuzzyEqual returns true if the two spheres are very close or false
+// otherwise.
+func (a *Sphere) FuzzyEqual(b *Sphere) bool {
+	return DefaultTolerance.SphereEqual(a, b)
+}
+
+// SphereEqual is like Sphere.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) SphereEqual(a, b *Sphere) bool {
+	return t.Vector3DEqual(&a.C, &b.C) && t.Equal(a.R, b.R)
+}
+
+// Support returns the point of x furthest in direction d.
+func (x *Sphere) Support(d Vector3D) Vector3D {
+	return sphereSupport(&x.C, x.R, &d)
+}
+
+// SurfaceArea returns the surface area of the sphere.
+func (x *Sphere) SurfaceArea() float64 {
+	return 4 * math.Pi * x.R * x.R
+}
+
+// Transform sets z to sphere x transformed by m then returns z. The transform
+// must be a similarity (see Matrix4x4.IsSimilarity) for the result to be a
+// sphere; if it is not z's radius is set to NaN.
+func (z *Sphere) Transform(m *Matrix4x4, x *Sphere) *Sphere {
+	r := math.NaN()
+	if m.IsSimilarity() {
+		var l Matrix3x3
+		r = x.R * math.Cbrt(math.Abs(l.FromMatrix4x4(m).Determinant()))
+	}
+	z.C.TransformPoint(m, &x.C)
+	z.R = r
+	return z
+}
+
+// Volume returns the volume of the sphere.
+func (x *Sphere) Volume() float64 {
+	return 4 * math.Pi * x.R * x.R * x.R / 3
+}
+
+// sphereSupport returns the point of the sphere with center c and radius r
+// furthest in direction d, or c if d is zero.
+func sphereSupport(c *Vector3D, r float64, d *Vector3D) Vector3D {
+	m := d.Magnitude()
+	if m == 0 {
+		return *c
+	}
+	m = r / m
+	return Vector3D{c.X + d.X*m, c.Y + d.Y*m, c.Z + d.Z*m}
+}
@@ -0,0 +1,123 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSphereContains(t *testing.T) {
+	s := &Sphere{Vector3D{1, 1, 1}, 2}
+	if !s.Contains(&Vector3D{1, 1, 3}) || !s.Contains(&Vector3D{2, 2, 2}) || s.Contains(&Vector3D{3, 3, 1}) {
+		t.Error("Sphere.Contains", s)
+	}
+}
+
+func TestSphereCopy(t *testing.T) {
+	var s Sphere
+	x := &Sphere{Vector3D{1, 2, 3}, 4}
+	if s.Equal(x) || !s.Copy(x).Equal(x) {
+		t.Error("Sphere.Copy")
+	}
+}
+
+type sphereEqualData struct {
+	s1, s2 Sphere
+	equal  bool
+}
+
+var sphereEqualValues = []sphereEqualData{
+	{Sphere{Vector3D{1, 2, 3}, 4}, Sphere{Vector3D{1, 2, 3}, 4}, true},
+	{Sphere{Vector3D{1, 2, 3}, 4}, Sphere{Vector3D{1, 2, 3}, 5}, false},
+	{Sphere{Vector3D{1, 2, 3}, 4}, Sphere{Vector3D{1, 2, 4}, 4}, false},
+}
+
+func testSphereEqual(d sphereEqualData, t *testing.T) {
+	if d.s1.Equal(&d.s2) != d.equal {
+		t.Error("Sphere.Equal", d.s1, d.s2, d.equal)
+	}
+}
+
+func TestSphereEqual(t *testing.T) {
+	for _, v := range sphereEqualValues {
+		testSphereEqual(v, t)
+	}
+}
+
+type sphereFromFourPointsData struct {
+	p1, p2, p3, p4 Vector3D
+	s              Sphere
+}
+
+var sphereFromFourPointsValues = []sphereFromFourPointsData{
+	{Vector3D{1, 0, 0}, Vector3D{0, 1, 0}, Vector3D{0, 0, 1}, Vector3D{-1, 0, 0}, Sphere{Vector3D{}, 1}},
+	{Vector3D{3, 2, 3}, Vector3D{1, 4, 3}, Vector3D{1, 2, 5}, Vector3D{1, 2, 1}, Sphere{Vector3D{1, 2, 3}, 2}},
+	{Vector3D{0, 0, 0}, Vector3D{2, 0, 0}, Vector3D{0, 2, 0}, Vector3D{0, 0, 2}, Sphere{Vector3D{1, 1, 1}, math.Sqrt(3)}},
+	// coplanar
+	{Vector3D{0, 0, 0}, Vector3D{1, 0, 0}, Vector3D{0, 1, 0}, Vector3D{1, 1, 0},
+		Sphere{Vector3D{math.NaN(), math.NaN(), math.NaN()}, math.NaN()}},
+	{Vector3D{0, 0, 0}, Vector3D{1, 1, 1}, Vector3D{2, 2, 2}, Vector3D{1, 0, 0},
+		Sphere{Vector3D{math.NaN(), math.NaN(), math.NaN()}, math.NaN()}},
+}
+
+func testSphereFromFourPoints(d sphereFromFourPointsData, t *testing.T) {
+	var s Sphere
+	s.FromFourPoints(&d.p1, &d.p2, &d.p3, &d.p4)
+	if math.IsNaN(d.s.R) {
+		if !math.IsNaN(s.R) || !math.IsNaN(s.C.X) {
+			t.Error("Sphere.FromFourPoints", d.p1, d.p2, d.p3, d.p4, "want NaN got", s)
+		}
+		return
+	}
+	if !s.FuzzyEqual(&d.s) {
+		t.Error("Sphere.FromFourPoints", d.p1, d.p2, d.p3, d.p4, d.s, "got", s)
+	}
+}
+
+func TestSphereFromFourPoints(t *testing.T) {
+	for _, v := range sphereFromFourPointsValues {
+		testSphereFromFourPoints(v, t)
+	}
+}
+
+func Benchmark_Sphere_FromFourPoints(b *testing.B) {
+	var s Sphere
+	p1, p2, p3, p4 := Vector3D{3, 2, 3}, Vector3D{1, 4, 3}, Vector3D{1, 2, 5}, Vector3D{1, 2, 1}
+	for i := 0; i < b.N; i++ {
+		s.FromFourPoints(&p1, &p2, &p3, &p4)
+	}
+}
+
+func TestSphereFuzzyEqual(t *testing.T) {
+	s := &Sphere{Vector3D{1, 1, 1}, 1}
+	if !s.FuzzyEqual(&Sphere{Vector3D{1, 1, 1 + 1e-13}, 1}) || s.FuzzyEqual(&Sphere{Vector3D{1, 1, 1}, 1 + 1e-12}) {
+		t.Error("Sphere.FuzzyEqual")
+	}
+}
+
+func TestSphereSurfaceArea(t *testing.T) {
+	s := &Sphere{Vector3D{}, 2}
+	if got := s.SurfaceArea(); got != 16*math.Pi {
+		t.Error("Sphere.SurfaceArea", s, "got", got)
+	}
+}
+
+func TestSphereTransform(t *testing.T) {
+	var m, r Matrix4x4
+	var s Sphere
+	m.Multiply(m.Translation(&Vector3D{1, 2, 3}), r.Multiply(r.RotationY(0.4), new(Matrix4x4).Scaling(&Vector3D{2, 2, 2})))
+	want := &Sphere{Vector3D{1, 2, 3}, 6}
+	if !s.Transform(&m, &Sphere{Vector3D{}, 3}).FuzzyEqual(want) {
+		t.Error("Sphere.Transform", "want", want, "got", s)
+	}
+	m.Scaling(&Vector3D{1, 2, 1})
+	if s.Transform(&m, &Sphere{Vector3D{}, 3}); !math.IsNaN(s.R) {
+		t.Error("Sphere.Transform non-uniform scale", "got", s)
+	}
+}
+
+func TestSphereVolume(t *testing.T) {
+	s := &Sphere{Vector3D{}, 3}
+	if got := s.Volume(); !FuzzyEqual(got, 36*math.Pi) {
+		t.Error("Sphere.Volume", s, "got", got)
+	}
+}
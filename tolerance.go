@@ -0,0 +1,121 @@
+package geometry
+
+import (
+	"math"
+)
+
+// A ToleranceMode selects how a Tolerance compares two values.
+type ToleranceMode int
+
+const (
+	// ToleranceAroundOne compares values relative to their magnitude, but
+	// values with a magnitude below one are compared around one instead of
+	// zero so values near zero are compared absolutely. It is the mode of
+	// DefaultTolerance.
+	ToleranceAroundOne ToleranceMode = iota
+
+	// ToleranceAbsolute compares the difference between values to E.
+	ToleranceAbsolute
+
+	// ToleranceRelative compares the difference between values to E times
+	// the larger magnitude. Only zero is close to zero.
+	ToleranceRelative
+
+	// ToleranceULP compares the number of representable float64 values
+	// between the values, units in the last place, to E.
+	ToleranceULP
+)
+
+// A Tolerance decides if two values are close enough to be treated as equal.
+// The Fuzzy functions and methods use DefaultTolerance, each has a Tolerance
+// method equivalent to use another.
+type Tolerance struct {
+	Mode ToleranceMode
+	E    float64
+}
+
+// DefaultTolerance is the tolerance used by FuzzyEqual and the other Fuzzy
+// functions and methods.
+var DefaultTolerance = Tolerance{ToleranceAroundOne, 1e-12}
+
+// AbsoluteTolerance returns a tolerance treating values at most e apart as
+// equal.
+func AbsoluteTolerance(e float64) Tolerance {
+	return Tolerance{ToleranceAbsolute, e}
+}
+
+// RelativeTolerance returns a tolerance treating values whose difference is
+// at most e times the larger magnitude as equal.
+func RelativeTolerance(e float64) Tolerance {
+	return Tolerance{ToleranceRelative, e}
+}
+
+// ULPTolerance returns a tolerance treating values at most n representable
+// float64 values apart as equal.
+func ULPTolerance(n int) Tolerance {
+	return Tolerance{ToleranceULP, float64(n)}
+}
+
+// Equal returns true if a and b are within tolerance t of each other or false
+// otherwise.
+func (t *Tolerance) Equal(a, b float64) bool {
+	switch t.Mode {
+	case ToleranceAbsolute:
+		return math.Abs(a-b) <= t.E
+	case ToleranceRelative:
+		return math.Abs(a-b) <= t.E*math.Max(math.Abs(a), math.Abs(b))
+	case ToleranceULP:
+		if math.IsNaN(a) || math.IsNaN(b) {
+			return false
+		}
+		return a == b || float64(ulpDistance(a, b)) <= t.E
+	}
+
+	// handle case when a and b are near zero and on opposite sides of it
+	if a*b < 0 {
+		a += 1
+		b += 1
+	}
+
+	if a < 0 {
+		a = -a
+	}
+	if b < 0 {
+		b = -b
+	}
+
+	// if values are small compare around one instead of zero
+	if a < 1 || b < 1 {
+		a += 1
+		b += 1
+	}
+
+	if a < b {
+		return b-a <= t.E*a
+	}
+	return a-b <= t.E*b
+}
+
+// between returns true if x is between lo and hi or within tolerance t of
+// either or false otherwise.
+func (t *Tolerance) between(x, lo, hi float64) bool {
+	return (lo <= x || t.Equal(x, lo)) && (x <= hi || t.Equal(x, hi))
+}
+
+// ulpDistance returns the number of representable float64 values from a to
+// b.
+func ulpDistance(a, b float64) uint64 {
+	// map the bits to integers ordered like the values they represent, with
+	// both zeros at zero
+	ia, ib := int64(math.Float64bits(a)), int64(math.Float64bits(b))
+	if ia < 0 {
+		ia = math.MinInt64 - ia
+	}
+	if ib < 0 {
+		ib = math.MinInt64 - ib
+	}
+	if ia < ib {
+		return uint64(ib) - uint64(ia)
+	}
+	return uint64(ia) - uint64(ib)
+}
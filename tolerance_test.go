@@ -0,0 +1,83 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+type toleranceTestData struct {
+	t    Tolerance
+	a, b float64
+	want bool
+	desc string
+}
+
+var toleranceTestValues = []toleranceTestData{
+	{AbsoluteTolerance(1e-3), 1000, 1000.0009, true, "absolute within"},
+	{AbsoluteTolerance(1e-3), 1000, 1000.0011, false, "absolute outside"},
+	{AbsoluteTolerance(1e-3), 0, -1e-3, true, "absolute across zero"},
+	{AbsoluteTolerance(1e-3), math.Inf(1), math.Inf(1), false, "absolute infinity"},
+	{RelativeTolerance(1e-6), 1e6, 1e6 + 0.5, true, "relative within"},
+	{RelativeTolerance(1e-6), 1e6, 1e6 + 2, false, "relative outside"},
+	{RelativeTolerance(1e-6), 0, 1e-300, false, "relative near zero"},
+	{RelativeTolerance(1e-6), 0, 0, true, "relative zero"},
+	{ULPTolerance(2), 1, math.Nextafter(math.Nextafter(1, 2), 2), true, "ulp within"},
+	{ULPTolerance(2), 1, math.Nextafter(math.Nextafter(math.Nextafter(1, 2), 2), 2), false, "ulp outside"},
+	{ULPTolerance(2), math.Copysign(0, -1), math.Nextafter(0, 1), true, "ulp across zero"},
+	{ULPTolerance(2), math.Nextafter(0, -1), math.Nextafter(math.Nextafter(0, 1), 1), false, "ulp across zero outside"},
+	{ULPTolerance(2), math.Inf(1), math.Inf(1), true, "ulp infinity"},
+	{ULPTolerance(2), math.NaN(), math.NaN(), false, "ulp nan"},
+	{Tolerance{ToleranceAroundOne, 1e-3}, 0.5, 0.5009, true, "around one within"},
+	{Tolerance{ToleranceAroundOne, 1e-3}, 0.5, 0.502, false, "around one outside"},
+}
+
+func TestToleranceEqual(t *testing.T) {
+	for _, v := range toleranceTestValues {
+		if got := v.t.Equal(v.a, v.b); got != v.want {
+			t.Error("Tolerance.Equal", v.desc, v.a, v.b, "want", v.want, "got", got)
+		}
+		if got := v.t.Equal(v.b, v.a); got != v.want {
+			t.Error("Tolerance.Equal", v.desc, v.b, v.a, "want", v.want, "got", got)
+		}
+	}
+	for _, v := range fuzzyEqualTestValues {
+		if DefaultTolerance.Equal(v.a, v.far) != FuzzyEqual(v.a, v.far) ||
+			DefaultTolerance.Equal(v.a, v.near) != FuzzyEqual(v.a, v.near) {
+			t.Error("Tolerance.Equal", v.a, "differs from FuzzyEqual")
+		}
+	}
+}
+
+func TestToleranceMethods(t *testing.T) {
+	mm := AbsoluteTolerance(1e-3)
+	a := &Vector2D{1000, 2000}
+	b := &Vector2D{1000.0005, 1999.9995}
+	if a.FuzzyEqual(b) || !mm.Vector2DEqual(a, b) {
+		t.Error("Tolerance.Vector2DEqual", a, b)
+	}
+	c := &Circle{Vector2D{0, 0}, 1}
+	d := &Circle{Vector2D{2.0005, 0}, 1}
+	var y, z Vector2D
+	if n := Intersection2DFuzzyCircleCircle(c, d, &y, &z); n != 0 {
+		t.Error("Intersection2D.FuzzyCircleCircle", c, d, "want", 0, "got", n)
+	}
+	if n := mm.Intersection2DCircleCircle(c, d, &y, &z); n != 1 || !mm.Vector2DEqual(&y, &Vector2D{1, 0}) {
+		t.Error("Tolerance.Intersection2DCircleCircle", c, d, "want", 1, "got", n, y)
+	}
+	p := &Plane{0, 0, 1, -1}
+	l := &Line3D{Vector3D{0, 0, 0.5}, Vector3D{1, 0, 1e-4}}
+	var q Vector3D
+	if n := Intersection3DFuzzyPlaneLine(p, l, &q); n != 1 {
+		t.Error("Intersection3D.FuzzyPlaneLine", p, l, "want", 1, "got", n)
+	}
+	if n := mm.Intersection3DPlaneLine(p, l, &q); n != 0 {
+		t.Error("Tolerance.Intersection3DPlaneLine", p, l, "want", 0, "got", n)
+	}
+}
+
+func Benchmark_Tolerance_Equal(b *testing.B) {
+	t := ULPTolerance(4)
+	for i := 0; i < b.N; i++ {
+		t.Equal(-1+1e-13, -1)
+	}
+}
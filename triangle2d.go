@@ -0,0 +1,157 @@
+package geometry
+
+import (
+	"math"
+)
+
+// A Triangle2D is a triangle with vertices A, B, and C.
+type Triangle2D struct {
+	A, B, C Vector2D
+}
+
+// NewTriangle2D returns a new Triangle2D.
+func NewTriangle2D(ax, ay, bx, by, cx, cy float64) *Triangle2D {
+	return &Triangle2D{Vector2D{ax, ay}, Vector2D{bx, by}, Vector2D{cx, cy}}
+}
+
+// Area returns the area of x.
+func (x *Triangle2D) Area() float64 {
+	return math.Abs(x.SignedArea())
+}
+
+// Barycentric returns the barycentric coordinates of point p with respect to
+// x, the weights of A, B, and C that sum to one. The coordinates are all in
+// [0, 1] if p is inside x.
+func (x *Triangle2D) Barycentric(p *Vector2D) (u, v, w float64) {
+	// Ericson, Real-Time Collision Detection, 3.4
+	v0x, v0y := x.B.X-x.A.X, x.B.Y-x.A.Y
+	v1x, v1y := x.C.X-x.A.X, x.C.Y-x.A.Y
+	v2x, v2y := p.X-x.A.X, p.Y-x.A.Y
+	d := 1 / (v0x*v1y - v1x*v0y)
+	v = (v2x*v1y - v1x*v2y) * d
+	w = (v0x*v2y - v2x*v0y) * d
+	return 1 - v - w, v, w
+}
+
+// Bounds sets z to the smallest box containing x then returns z.
+func (x *Triangle2D) Bounds(z *AABB2D) *AABB2D {
+	return z.FromPoints(x.A, x.B, x.C)
+}
+
+// Centroid sets z to the centroid of x then returns z.
+func (x *Triangle2D) Centroid(z *Vector2D) *Vector2D {
+	z.X = (x.A.X + x.B.X + x.C.X) / 3
+	z.Y = (x.A.Y + x.B.Y + x.C.Y) / 3
+	return z
+}
+
+// Circumcircle sets z to the circle through the vertices of x then returns z.
+func (x *Triangle2D) Circumcircle(z *Circle) *Circle {
+	return z.FromThreePoints(&x.A, &x.B, &x.C)
+}
+
+// ClosestPoint sets z to the point in x closest to p then returns z.
+func (x *Triangle2D) ClosestPoint(p, z *Vector2D) *Vector2D {
+	// Ericson, Real-Time Collision Detection, 5.1.5
+	abx, aby := x.B.X-x.A.X, x.B.Y-x.A.Y
+	acx, acy := x.C.X-x.A.X, x.C.Y-x.A.Y
+	apx, apy := p.X-x.A.X, p.Y-x.A.Y
+	d1, d2 := abx*apx+aby*apy, acx*apx+acy*apy
+	if d1 <= 0 && d2 <= 0 {
+		return z.Copy(&x.A)
+	}
+	bpx, bpy := p.X-x.B.X, p.Y-x.B.Y
+	d3, d4 := abx*bpx+aby*bpy, acx*bpx+acy*bpy
+	if d3 >= 0 && d4 <= d3 {
+		return z.Copy(&x.B)
+	}
+	if vc := d1*d4 - d3*d2; vc <= 0 && d1 >= 0 && d3 <= 0 {
+		v := d1 / (d1 - d3)
+		z.X, z.Y = x.A.X+v*abx, x.A.Y+v*aby
+		return z
+	}
+	cpx, cpy := p.X-x.C.X, p.Y-x.C.Y
+	d5, d6 := abx*cpx+aby*cpy, acx*cpx+acy*cpy
+	if d6 >= 0 && d5 <= d6 {
+		return z.Copy(&x.C)
+	}
+	if vb := d5*d2 - d1*d6; vb <= 0 && d2 >= 0 && d6 <= 0 {
+		w := d2 / (d2 - d6)
+		z.X, z.Y = x.A.X+w*acx, x.A.Y+w*acy
+		return z
+	}
+	if va := d3*d6 - d5*d4; va <= 0 && d4-d3 >= 0 && d5-d6 >= 0 {
+		w := (d4 - d3) / ((d4 - d3) + (d5 - d6))
+		z.X, z.Y = x.B.X+w*(x.C.X-x.B.X), x.B.Y+w*(x.C.Y-x.B.Y)
+		return z
+	}
+	return z.Copy(p)
+}
+
+// Copy sets z to x then returns z.
+func (z *Triangle2D) Copy(x *Triangle2D) *Triangle2D {
+	*z = *x
+	return z
+}
+
+// Equal compares a and b then returns true if they have exactly equal
+// vertices in the same order or false otherwise.
+func (a *Triangle2D) Equal(b *Triangle2D) bool {
+	return *a == *b
+}
+
+// FromBarycentric sets z to the point with barycentric coordinates u, v, and
+// w with respect to x then returns z.
+func (x *Triangle2D) FromBarycentric(u, v, w float64, z *Vector2D) *Vector2D {
+	z.X = u*x.A.X + v*x.B.X + w*x.C.X
+	z.Y = u*x.A.Y + v*x.B.Y + w*x.C.Y
+	return z
+}
+
+// FuzzyEqual compares a and b then returns true if they have very close
+// vertices in the same order or false otherwise.
+func (a *Triangle2D) FuzzyEqual(b *Triangle2D) bool {
+	return DefaultTolerance.Triangle2DEqual(a, b)
+}
+
+// Triangle2DEqual is like Triangle2D.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) Triangle2DEqual(a, b *Triangle2D) bool {
+	return t.Vector2DEqual(&a.A, &b.A) && t.Vector2DEqual(&a.B, &b.B) && t.Vector2DEqual(&a.C, &b.C)
+}
+
+// Incircle sets z to the largest circle inside x then returns z.
+func (x *Triangle2D) Incircle(z *Circle) *Circle {
+	a := Distance2DPointPoint(&x.B, &x.C)
+	b := Distance2DPointPoint(&x.C, &x.A)
+	c := Distance2DPointPoint(&x.A, &x.B)
+	p := a + b + c
+	z.R = 2 * x.Area() / p
+	z.C.X = (a*x.A.X + b*x.B.X + c*x.C.X) / p
+	z.C.Y = (a*x.A.Y + b*x.B.Y + c*x.C.Y) / p
+	return z
+}
+
+// IsDegenerate returns true if x has very close to zero area relative to the
+// length of its longest edge, so its vertices are nearly collinear, or false
+// otherwise.
+func (x *Triangle2D) IsDegenerate() bool {
+	l := math.Max(Distance2DPointPointSquared(&x.A, &x.B),
+		math.Max(Distance2DPointPointSquared(&x.B, &x.C), Distance2DPointPointSquared(&x.C, &x.A)))
+	return l == 0 || FuzzyEqual(x.SignedArea()/l, 0)
+}
+
+// Perimeter returns the perimeter of x.
+func (x *Triangle2D) Perimeter() float64 {
+	return Distance2DPointPoint(&x.A, &x.B) + Distance2DPointPoint(&x.B, &x.C) + Distance2DPointPoint(&x.C, &x.A)
+}
+
+// Support returns the vertex of x furthest in direction d.
+func (x *Triangle2D) Support(d Vector2D) Vector2D {
+	return supportPoints2D([]Vector2D{x.A, x.B, x.C}, &d)
+}
+
+// SignedArea returns the area of x, positive if the vertices are
+// counterclockwise or negative if they are clockwise.
+func (x *Triangle2D) SignedArea() float64 {
+	return ((x.B.X-x.A.X)*(x.C.Y-x.A.Y) - (x.C.X-x.A.X)*(x.B.Y-x.A.Y)) / 2
+}
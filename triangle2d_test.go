@@ -0,0 +1,87 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTriangle2DArea(t *testing.T) {
+	x := NewTriangle2D(0, 0, 4, 0, 0, 3)
+	if got := x.Area(); got != 6 {
+		t.Error("Triangle2D.Area", x, "got", got)
+	}
+	x.B, x.C = x.C, x.B
+	if got := x.SignedArea(); got != -6 {
+		t.Error("Triangle2D.SignedArea", x, "got", got)
+	}
+	if got := x.Perimeter(); got != 12 {
+		t.Error("Triangle2D.Perimeter", x, "got", got)
+	}
+}
+
+func TestTriangle2DBarycentric(t *testing.T) {
+	x := NewTriangle2D(1, 1, 5, 2, 2, 6)
+	for _, v := range [][3]float64{{1, 0, 0}, {0.2, 0.3, 0.5}, {-1, 1.5, 0.5}} {
+		var p Vector2D
+		x.FromBarycentric(v[0], v[1], v[2], &p)
+		u, vv, w := x.Barycentric(&p)
+		if !FuzzyEqual(u, v[0]) || !FuzzyEqual(vv, v[1]) || !FuzzyEqual(w, v[2]) {
+			t.Error("Triangle2D.Barycentric", v, "got", u, vv, w)
+		}
+	}
+}
+
+func TestTriangle2DCircumcircle(t *testing.T) {
+	var c Circle
+	x := NewTriangle2D(0, 0, 2, 0, 0, 2)
+	if !x.Circumcircle(&c).FuzzyEqual(&Circle{Vector2D{1, 1}, math.Sqrt2}) {
+		t.Error("Triangle2D.Circumcircle", x, "got", c)
+	}
+	x = NewTriangle2D(0, 0, 4, 0, 0, 3)
+	if !x.Incircle(&c).FuzzyEqual(&Circle{Vector2D{1, 1}, 1}) {
+		t.Error("Triangle2D.Incircle", x, "got", c)
+	}
+	var p Vector2D
+	if !x.Centroid(&p).FuzzyEqual(&Vector2D{4.0 / 3, 1}) {
+		t.Error("Triangle2D.Centroid", x, "got", p)
+	}
+}
+
+type triangle2DClosestPointData struct {
+	p, c Vector2D
+}
+
+var triangle2DClosestPointValues = []triangle2DClosestPointData{
+	{Vector2D{1, 1}, Vector2D{1, 1}},
+	{Vector2D{-1, -1}, Vector2D{0, 0}},
+	{Vector2D{5, -1}, Vector2D{4, 0}},
+	{Vector2D{-1, 4}, Vector2D{0, 4}},
+	{Vector2D{2, -1}, Vector2D{2, 0}},
+	{Vector2D{-1, 2}, Vector2D{0, 2}},
+	{Vector2D{4, 4}, Vector2D{2, 2}},
+}
+
+func TestTriangle2DClosestPoint(t *testing.T) {
+	x := NewTriangle2D(0, 0, 4, 0, 0, 4)
+	for _, v := range triangle2DClosestPointValues {
+		var c Vector2D
+		if !x.ClosestPoint(&v.p, &c).FuzzyEqual(&v.c) {
+			t.Error("Triangle2D.ClosestPoint", v.p, "want", v.c, "got", c)
+		}
+		if d := Distance2DTrianglePoint(x, &v.p); !FuzzyEqual(d, Distance2DPointPoint(&v.p, &v.c)) {
+			t.Error("Distance2D.TrianglePoint", v.p, "got", d)
+		}
+	}
+}
+
+func TestTriangle2DIsDegenerate(t *testing.T) {
+	if NewTriangle2D(0, 0, 1, 0, 0, 1).IsDegenerate() {
+		t.Error("Triangle2D.IsDegenerate")
+	}
+	if !NewTriangle2D(0, 0, 1e6, 1e6, 2e6, 2e6+1e-9).IsDegenerate() {
+		t.Error("Triangle2D.IsDegenerate collinear")
+	}
+	if !NewTriangle2D(1, 1, 1, 1, 1, 1).IsDegenerate() {
+		t.Error("Triangle2D.IsDegenerate point")
+	}
+}
@@ -0,0 +1,183 @@
+package geometry
+
+import (
+	"math"
+)
+
+// A Triangle3D is a triangle with vertices A, B, and C. Its front face is the
+// one from which the vertices appear counterclockwise.
+type Triangle3D struct {
+	A, B, C Vector3D
+}
+
+// Area returns the area of x.
+func (x *Triangle3D) Area() float64 {
+	var n Vector3D
+	return x.Normal(&n).Magnitude() / 2
+}
+
+// Barycentric returns the barycentric coordinates of point p, projected onto
+// the plane of x, with respect to x, the weights of A, B, and C that sum to
+// one. The coordinates are all in [0, 1] if the projection is inside x.
+func (x *Triangle3D) Barycentric(p *Vector3D) (u, v, w float64) {
+	// Ericson, Real-Time Collision Detection, 3.4
+	var v0, v1, v2 Vector3D
+	v0.Subtract(&x.B, &x.A)
+	v1.Subtract(&x.C, &x.A)
+	v2.Subtract(p, &x.A)
+	d00, d01, d11 := v0.DotProduct(&v0), v0.DotProduct(&v1), v1.DotProduct(&v1)
+	d20, d21 := v2.DotProduct(&v0), v2.DotProduct(&v1)
+	d := 1 / (d00*d11 - d01*d01)
+	v = (d11*d20 - d01*d21) * d
+	w = (d00*d21 - d01*d20) * d
+	return 1 - v - w, v, w
+}
+
+// Bounds sets z to the smallest box containing x then returns z.
+func (x *Triangle3D) Bounds(z *AABB3D) *AABB3D {
+	return z.FromPoints(x.A, x.B, x.C)
+}
+
+// Centroid sets z to the centroid of x then returns z.
+func (x *Triangle3D) Centroid(z *Vector3D) *Vector3D {
+	z.X = (x.A.X + x.B.X + x.C.X) / 3
+	z.Y = (x.A.Y + x.B.Y + x.C.Y) / 3
+	z.Z = (x.A.Z + x.B.Z + x.C.Z) / 3
+	return z
+}
+
+// Circumsphere sets z to the smallest sphere through the vertices of x, which
+// is centered on the plane of x, then returns z.
+func (x *Triangle3D) Circumsphere(z *Sphere) *Sphere {
+	// http://en.wikipedia.org/wiki/Circumscribed_circle#Higher_dimensions
+	var ab, ac, n, t1, t2 Vector3D
+	ab.Subtract(&x.B, &x.A)
+	ac.Subtract(&x.C, &x.A)
+	n.CrossProduct(&ab, &ac)
+	t1.CrossProduct(&n, &ab).Scale(&t1, ac.MagnitudeSquared())
+	t2.CrossProduct(&ac, &n).Scale(&t2, ab.MagnitudeSquared())
+	t1.Add(&t1, &t2).Scale(&t1, 1/(2*n.MagnitudeSquared()))
+	z.R = t1.Magnitude()
+	z.C.Add(&x.A, &t1)
+	return z
+}
+
+// ClosestPoint sets z to the point in x closest to p then returns z.
+func (x *Triangle3D) ClosestPoint(p, z *Vector3D) *Vector3D {
+	// Ericson, Real-Time Collision Detection, 5.1.5
+	var ab, ac, ap, bp, cp Vector3D
+	ab.Subtract(&x.B, &x.A)
+	ac.Subtract(&x.C, &x.A)
+	ap.Subtract(p, &x.A)
+	d1, d2 := ab.DotProduct(&ap), ac.DotProduct(&ap)
+	if d1 <= 0 && d2 <= 0 {
+		return z.Copy(&x.A)
+	}
+	bp.Subtract(p, &x.B)
+	d3, d4 := ab.DotProduct(&bp), ac.DotProduct(&bp)
+	if d3 >= 0 && d4 <= d3 {
+		return z.Copy(&x.B)
+	}
+	vc := d1*d4 - d3*d2
+	if vc <= 0 && d1 >= 0 && d3 <= 0 {
+		return z.Add(&x.A, ab.Scale(&ab, d1/(d1-d3)))
+	}
+	cp.Subtract(p, &x.C)
+	d5, d6 := ab.DotProduct(&cp), ac.DotProduct(&cp)
+	if d6 >= 0 && d5 <= d6 {
+		return z.Copy(&x.C)
+	}
+	vb := d5*d2 - d1*d6
+	if vb <= 0 && d2 >= 0 && d6 <= 0 {
+		return z.Add(&x.A, ac.Scale(&ac, d2/(d2-d6)))
+	}
+	va := d3*d6 - d5*d4
+	if va <= 0 && d4-d3 >= 0 && d5-d6 >= 0 {
+		w := (d4 - d3) / ((d4 - d3) + (d5 - d6))
+		return z.Add(&x.B, bp.Subtract(&x.C, &x.B).Scale(&bp, w))
+	}
+	// inside the face
+	d := 1 / (va + vb + vc)
+	ab.Scale(&ab, vb*d)
+	ac.Scale(&ac, vc*d)
+	return z.Add(&x.A, ab.Add(&ab, &ac))
+}
+
+// Copy sets z to x then returns z.
+func (z *Triangle3D) Copy(x *Triangle3D) *Triangle3D {
+	*z = *x
+	return z
+}
+
+// Equal compares a and b then returns true if they have exactly equal
+// vertices in the same order or false otherwise.
+func (a *Triangle3D) Equal(b *Triangle3D) bool {
+	return *a == *b
+}
+
+// FromBarycentric sets z to the point with barycentric coordinates u, v, and
+// w with respect to x then returns z.
+func (x *Triangle3D) FromBarycentric(u, v, w float64, z *Vector3D) *Vector3D {
+	z.X = u*x.A.X + v*x.B.X + w*x.C.X
+	z.Y = u*x.A.Y + v*x.B.Y + w*x.C.Y
+	z.Z = u*x.A.Z + v*x.B.Z + w*x.C.Z
+	return z
+}
+
+// FuzzyEqual compares a and b then returns true if they have very close
+// vertices in the same order or false otherwise.
+func (a *Triangle3D) FuzzyEqual(b *Triangle3D) bool {
+	return DefaultTolerance.Triangle3DEqual(a, b)
+}
+
+// Triangle3DEqual is like Triangle3D.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) Triangle3DEqual(a, b *Triangle3D) bool {
+	return t.Vector3DEqual(&a.A, &b.A) && t.Vector3DEqual(&a.B, &b.B) && t.Vector3DEqual(&a.C, &b.C)
+}
+
+// Incircle sets z to the center of the largest circle inside x then returns
+// its radius.
+func (x *Triangle3D) Incircle(z *Vector3D) float64 {
+	a := Distance3DPointPoint(&x.B, &x.C)
+	b := Distance3DPointPoint(&x.C, &x.A)
+	c := Distance3DPointPoint(&x.A, &x.B)
+	p := a + b + c
+	z.X = (a*x.A.X + b*x.B.X + c*x.C.X) / p
+	z.Y = (a*x.A.Y + b*x.B.Y + c*x.C.Y) / p
+	z.Z = (a*x.A.Z + b*x.B.Z + c*x.C.Z) / p
+	return 2 * x.Area() / p
+}
+
+// IsDegenerate returns true if x has very close to zero area relative to the
+// length of its longest edge, so its vertices are nearly collinear, or false
+// otherwise.
+func (x *Triangle3D) IsDegenerate() bool {
+	l := math.Max(Distance3DPointPointSquared(&x.A, &x.B),
+		math.Max(Distance3DPointPointSquared(&x.B, &x.C), Distance3DPointPointSquared(&x.C, &x.A)))
+	return l == 0 || FuzzyEqual(x.Area()/l, 0)
+}
+
+// Normal sets z to the normal of the front face of x, with a magnitude of
+// twice the area of x, then returns z.
+func (x *Triangle3D) Normal(z *Vector3D) *Vector3D {
+	var ab, ac Vector3D
+	ab.Subtract(&x.B, &x.A)
+	ac.Subtract(&x.C, &x.A)
+	return z.CrossProduct(&ab, &ac)
+}
+
+// Perimeter returns the perimeter of x.
+func (x *Triangle3D) Perimeter() float64 {
+	return Distance3DPointPoint(&x.A, &x.B) + Distance3DPointPoint(&x.B, &x.C) + Distance3DPointPoint(&x.C, &x.A)
+}
+
+// Plane sets z to the plane containing x, with the normal of its front face,
+// then returns z.
+func (x *Triangle3D) Plane(z *Plane) *Plane {
+	return z.FromPoints(&x.A, &x.B, &x.C)
+}
+
+// Support returns the vertex of x furthest in direction d.
+func (x *Triangle3D) Support(d Vector3D) Vector3D {
+	return supportPoints3D([]Vector3D{x.A, x.B, x.C}, &d)
+}
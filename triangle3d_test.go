@@ -0,0 +1,94 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+var triangle3DTestValue = Triangle3D{Vector3D{0, 0, 1}, Vector3D{4, 0, 1}, Vector3D{0, 4, 1}}
+
+func TestTriangle3DArea(t *testing.T) {
+	var n Vector3D
+	if got := triangle3DTestValue.Area(); got != 8 {
+		t.Error("Triangle3D.Area", "got", got)
+	}
+	if !triangle3DTestValue.Normal(&n).Equal(&Vector3D{0, 0, 16}) {
+		t.Error("Triangle3D.Normal", "got", n)
+	}
+	var p Plane
+	if !triangle3DTestValue.Plane(&p).Equal(&Plane{0, 0, 1, -1}) {
+		t.Error("Triangle3D.Plane", "got", p)
+	}
+}
+
+func TestTriangle3DBarycentric(t *testing.T) {
+	x := &Triangle3D{Vector3D{1, 1, 0}, Vector3D{5, 2, 1}, Vector3D{2, 6, -1}}
+	for _, v := range [][3]float64{{1, 0, 0}, {0.2, 0.3, 0.5}, {-1, 1.5, 0.5}} {
+		var p, n Vector3D
+		x.FromBarycentric(v[0], v[1], v[2], &p)
+		// moving off the plane does not change the coordinates
+		p.Add(&p, x.Normal(&n))
+		u, vv, w := x.Barycentric(&p)
+		if !FuzzyEqual(u, v[0]) || !FuzzyEqual(vv, v[1]) || !FuzzyEqual(w, v[2]) {
+			t.Error("Triangle3D.Barycentric", v, "got", u, vv, w)
+		}
+	}
+}
+
+func TestTriangle3DCircumsphere(t *testing.T) {
+	var s Sphere
+	x := &Triangle3D{Vector3D{1, 0, 0}, Vector3D{0, 1, 0}, Vector3D{0, 0, 1}}
+	want := &Sphere{Vector3D{1.0 / 3, 1.0 / 3, 1.0 / 3}, math.Sqrt(6) / 3}
+	if !x.Circumsphere(&s).FuzzyEqual(want) {
+		t.Error("Triangle3D.Circumsphere", x, "want", want, "got", s)
+	}
+	var c Vector3D
+	x = &Triangle3D{Vector3D{0, 0, 2}, Vector3D{0, 4, 2}, Vector3D{0, 0, 5}}
+	if r := x.Incircle(&c); !FuzzyEqual(r, 1) || !c.FuzzyEqual(&Vector3D{0, 1, 3}) {
+		t.Error("Triangle3D.Incircle", x, "got", c, r)
+	}
+}
+
+type triangle3DClosestPointData struct {
+	p, c Vector3D
+}
+
+var triangle3DClosestPointValues = []triangle3DClosestPointData{
+	{Vector3D{1, 1, 3}, Vector3D{1, 1, 1}},
+	{Vector3D{-1, -1, 0}, Vector3D{0, 0, 1}},
+	{Vector3D{5, -1, 1}, Vector3D{4, 0, 1}},
+	{Vector3D{-1, 5, 2}, Vector3D{0, 4, 1}},
+	{Vector3D{2, -1, -3}, Vector3D{2, 0, 1}},
+	{Vector3D{-1, 2, 1}, Vector3D{0, 2, 1}},
+	{Vector3D{4, 4, 1}, Vector3D{2, 2, 1}},
+}
+
+func TestTriangle3DClosestPoint(t *testing.T) {
+	for _, v := range triangle3DClosestPointValues {
+		var c Vector3D
+		if !triangle3DTestValue.ClosestPoint(&v.p, &c).FuzzyEqual(&v.c) {
+			t.Error("Triangle3D.ClosestPoint", v.p, "want", v.c, "got", c)
+		}
+		if d := Distance3DTrianglePoint(&triangle3DTestValue, &v.p); !FuzzyEqual(d, Distance3DPointPoint(&v.p, &v.c)) {
+			t.Error("Distance3D.TrianglePoint", v.p, "got", d)
+		}
+	}
+}
+
+func Benchmark_Triangle3D_ClosestPoint(b *testing.B) {
+	var c Vector3D
+	p := &Vector3D{1, 1, 3}
+	for i := 0; i < b.N; i++ {
+		triangle3DTestValue.ClosestPoint(p, &c)
+	}
+}
+
+func TestTriangle3DIsDegenerate(t *testing.T) {
+	if triangle3DTestValue.IsDegenerate() {
+		t.Error("Triangle3D.IsDegenerate")
+	}
+	x := &Triangle3D{Vector3D{0, 0, 0}, Vector3D{1, 1, 1}, Vector3D{3, 3, 3}}
+	if !x.IsDegenerate() {
+		t.Error("Triangle3D.IsDegenerate collinear")
+	}
+}
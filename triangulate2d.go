@@ -0,0 +1,595 @@
+package geometry
+
+import (
+	"math"
+	"sort"
+)
+
+// Triangulate returns triangles covering x by ear clipping, each a triple of
+// vertex indices. Indices below len(x.Outer) are of the outer ring and the
+// holes' vertices follow in order, so index len(x.Outer) is x.Holes[0][0].
+// Triangles turn the same way as the outer ring, and the holes may turn
+// either way.
+//
+// Each hole is first joined to the outer ring by a pair of edges from its
+// rightmost vertex to a vertex it can see, then ears are cut from the single
+// ring left. A simple polygon of n vertices and h holes has n+2h-2
+// triangles. Repeated vertices, and the vertices of rings with fewer than
+// three or no area, are skipped. The time taken is O(n^2), see
+// TriangulateMonotone for large polygons.
+func (x *Polygon2D) Triangulate() [][3]int {
+	points, rings, cw := x.triangulationRings()
+	if rings == nil {
+		return nil
+	}
+	return orientTriangles2D(earClip2D(points, bridgeHoles2D(points, rings)), cw)
+}
+
+// TriangulateMonotone returns triangles covering x as Triangulate does, but
+// in O(n log n) time by first splitting x into pieces that are monotone in Y
+// with a sweep line and then triangulating each piece in linear time. The
+// triangles are thinner than those of Triangulate.
+func (x *Polygon2D) TriangulateMonotone() [][3]int {
+	points, rings, cw := x.triangulationRings()
+	if rings == nil {
+		return nil
+	}
+	next := make([]int, len(points))
+	for _, r := range rings {
+		for i, v := range r {
+			next[v] = r[(i+1)%len(r)]
+		}
+	}
+	// every edge, and both ways along every diagonal, has a piece on its
+	// left
+	out := make([][]int, len(points))
+	for _, r := range rings {
+		for _, v := range r {
+			out[v] = append(out[v], next[v])
+		}
+	}
+	for _, d := range monotoneDiagonals2D(points, rings, next) {
+		out[d[0]] = append(out[d[0]], d[1])
+		out[d[1]] = append(out[d[1]], d[0])
+	}
+	var z [][3]int
+	b := boolean2D{points: points}
+	used := make([][]bool, len(points))
+	for u := range out {
+		used[u] = make([]bool, len(out[u]))
+	}
+	for u := range out {
+		for k := range out[u] {
+			if used[u][k] {
+				continue
+			}
+			// trace the piece turning as far left as possible at each
+			// vertex
+			var piece []int
+			for u, k := u, k; !used[u][k]; {
+				used[u][k] = true
+				piece = append(piece, u)
+				v, w := out[u][k], 0
+				for i, c := range out[v] {
+					if b.ccw(v, u, out[v][w], c) {
+						w = i
+					}
+				}
+				u, k = v, w
+			}
+			z = triangulateMonotone2D(points, piece, z)
+		}
+	}
+	return orientTriangles2D(z, cw)
+}
+
+// triangulationRings returns the vertices of x numbered as Triangulate
+// documents and its rings as vertex indices, without repeated vertices, with
+// the outer ring counterclockwise and holes clockwise, and whether the outer
+// ring was clockwise. The rings are nil if the outer ring has no area.
+func (x *Polygon2D) triangulationRings() (points []Vector2D, rings [][]int, cw bool) {
+	points = append(points, x.Outer...)
+	for _, h := range x.Holes {
+		points = append(points, h...)
+	}
+	ring := func(start, n int, sign float64) []int {
+		var r []int
+		for i := start; i < start+n; i++ {
+			if len(r) == 0 || points[r[len(r)-1]] != points[i] {
+				r = append(r, i)
+			}
+		}
+		for len(r) > 1 && points[r[len(r)-1]] == points[r[0]] {
+			r = r[:len(r)-1]
+		}
+		v := make([]Vector2D, len(r))
+		for i := range r {
+			v[i] = points[r[i]]
+		}
+		a := ringArea(v)
+		if len(r) < 3 || a == 0 {
+			return nil
+		}
+		if a*sign < 0 {
+			for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+				r[i], r[j] = r[j], r[i]
+			}
+		}
+		return r
+	}
+	outer := ring(0, len(x.Outer), 1)
+	if outer == nil {
+		return points, nil, false
+	}
+	cw = ringArea(x.Outer) < 0
+	rings = append(rings, outer)
+	start := len(x.Outer)
+	for _, h := range x.Holes {
+		if r := ring(start, len(h), -1); r != nil {
+			rings = append(rings, r)
+		}
+		start += len(h)
+	}
+	return points, rings, cw
+}
+
+// orientTriangles2D reverses triangles if cw is true then returns them.
+func orientTriangles2D(triangles [][3]int, cw bool) [][3]int {
+	if cw {
+		for i := range triangles {
+			triangles[i][1], triangles[i][2] = triangles[i][2], triangles[i][1]
+		}
+	}
+	return triangles
+}
+
+// bridgeHoles2D returns the outer ring, rings[0], with each of the holes
+// that follow joined to it by edges to and from a vertex it can see. Holes
+// are joined rightmost first, each from its rightmost vertex to the nearest
+// vertex right of it that nothing blocks.
+func bridgeHoles2D(points []Vector2D, rings [][]int) []int {
+	holes := make([][]int, len(rings)-1)
+	right := make([]int, len(holes))
+	for i, h := range rings[1:] {
+		holes[i] = h
+		for j, v := range h {
+			if p, q := &points[v], &points[h[right[i]]]; p.X > q.X || (p.X == q.X && p.Y < q.Y) {
+				right[i] = j
+			}
+		}
+	}
+	order := make([]int, len(holes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return points[holes[order[i]][right[order[i]]]].X > points[holes[order[j]][right[order[j]]]].X
+	})
+
+	list := append([]int(nil), rings[0]...)
+	for _, i := range order {
+		h, k := holes[i], right[i]
+		m := &points[h[k]]
+		// the nearest edge crossing the ray to the right of m runs upward,
+		// the inside being on the left, and the ray hits it at c
+		at, c := -1, Vector2D{math.Inf(1), m.Y}
+		for j := range list {
+			a, b := &points[list[j]], &points[list[(j+1)%len(list)]]
+			if a.Y > m.Y || b.Y < m.Y || a.Y == b.Y {
+				continue
+			}
+			x := a.X + (m.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+			if x < m.X || x >= c.X || Orient2D(a, b, m) <= 0 {
+				continue
+			}
+			c.X = x
+			switch {
+			case a.Y == m.Y:
+				at = j
+			case b.Y == m.Y || b.X > a.X:
+				at = (j + 1) % len(list)
+			default:
+				at = j
+			}
+		}
+		if at < 0 {
+			continue
+		}
+		// a vertex already joined to a hole is in list more than once, and
+		// only one copy has m inside its angle
+		cone := func(j int) bool {
+			a, b := &points[list[(j+len(list)-1)%len(list)]], &points[list[(j+1)%len(list)]]
+			return earInCone2D(a, &points[list[j]], b, m)
+		}
+		// the end of the edge may be hidden by reflex vertices inside the
+		// triangle from m to c to it, in which case the one at the least
+		// angle from the ray is visible
+		if p := &points[list[at]]; *p != c {
+			best, dist := math.Inf(1), math.Inf(1)
+			for j := range list {
+				v := &points[list[j]]
+				if *v == *p || !earInside2D(m, &c, p, v) {
+					continue
+				}
+				a, b := &points[list[(j+len(list)-1)%len(list)]], &points[list[(j+1)%len(list)]]
+				if Orient2D(a, v, b) >= 0 || !cone(j) {
+					continue
+				}
+				t := math.Abs(v.Y-m.Y) / (v.X - m.X)
+				if d := Distance2DPointPoint(m, v); t < best || (t == best && d < dist) {
+					at, best, dist = j, t, d
+				}
+			}
+		}
+		if !cone(at) {
+			for j := range list {
+				if list[j] == list[at] && cone(j) {
+					at = j
+					break
+				}
+			}
+		}
+		joined := make([]int, 0, len(list)+len(h)+2)
+		joined = append(joined, list[:at+1]...)
+		for j := 0; j <= len(h); j++ {
+			joined = append(joined, h[(k+j)%len(h)])
+		}
+		list = append(joined, list[at:]...)
+	}
+	return list
+}
+
+// earInside2D returns true if p is inside or on the edges of the triangle a,
+// b, c of either orientation or false otherwise.
+func earInside2D(a, b, c, p *Vector2D) bool {
+	o1, o2, o3 := Orient2D(a, b, p), Orient2D(b, c, p), Orient2D(c, a, p)
+	return (o1 >= 0 && o2 >= 0 && o3 >= 0) || (o1 <= 0 && o2 <= 0 && o3 <= 0)
+}
+
+// earInCone2D returns true if p is strictly inside the angle at v between the
+// edges from a and to b of a counterclockwise ring or false otherwise.
+func earInCone2D(a, v, b, p *Vector2D) bool {
+	if Orient2D(a, v, b) >= 0 {
+		return Orient2D(a, v, p) > 0 && Orient2D(v, b, p) > 0
+	}
+	return Orient2D(a, v, p) > 0 || Orient2D(v, b, p) > 0
+}
+
+// earClip2D returns the triangles cut from the counterclockwise ring of
+// vertex indices r, in which vertices may repeat where holes were joined.
+func earClip2D(points []Vector2D, r []int) [][3]int {
+	n := len(r)
+	prev, next := make([]int, n), make([]int, n)
+	for i := range r {
+		prev[i], next[i] = (i+n-1)%n, (i+1)%n
+	}
+	at := func(i int) *Vector2D { return &points[r[i]] }
+	turn := func(i int) int { return Orient2D(at(prev[i]), at(i), at(next[i])) }
+	// i is an ear if it is convex and no other vertex is in its triangle,
+	// other than copies of the triangle's own
+	ear := func(i int) bool {
+		if turn(i) <= 0 {
+			return false
+		}
+		a, b, c := at(prev[i]), at(i), at(next[i])
+		for j := next[next[i]]; j != prev[i]; j = next[j] {
+			if p := at(j); *p != *a && *p != *b && *p != *c && earInside2D(a, b, c, p) {
+				return false
+			}
+		}
+		return true
+	}
+	remove := func(i int) {
+		next[prev[i]], prev[next[i]] = next[i], prev[i]
+	}
+
+	z := make([][3]int, 0, n-2)
+	i := 0
+	for left, tried := n, 0; left > 3; {
+		if ear(i) {
+			z = append(z, [3]int{r[prev[i]], r[i], r[next[i]]})
+			remove(i)
+			i, left, tried = next[i], left-1, 0
+			continue
+		}
+		if i, tried = next[i], tried+1; tried < left {
+			continue
+		}
+		// without an ear what's left is degenerate, so a vertex that
+		// doesn't turn is dropped or nothing more can be done
+		for tried = 0; tried < left && turn(i) != 0; tried++ {
+			i = next[i]
+		}
+		if tried == left {
+			return z
+		}
+		remove(i)
+		i, left, tried = next[i], left-1, 0
+	}
+	if turn(i) > 0 {
+		z = append(z, [3]int{r[prev[i]], r[i], r[next[i]]})
+	}
+	return z
+}
+
+// monotoneAbove2D returns true if p comes before q sweeping down in Y, and
+// across in X where they're level, or false otherwise.
+func monotoneAbove2D(p, q *Vector2D) bool {
+	return p.Y > q.Y || (p.Y == q.Y && p.X < q.X)
+}
+
+// monotoneDiagonals2D returns diagonals splitting the polygon with rings of
+// vertex indices rings, the outer ring counterclockwise and holes clockwise,
+// into pieces monotone in Y. next is the vertex following each in its ring.
+//
+// A line sweeping down stops at each vertex, keeping the edges it crosses
+// that have the inside on their right, sorted left to right, and for each
+// the lowest vertex above the line that it sees to its right, its helper.
+// Vertices with both neighbors below and the inside between them, split
+// vertices, and those with both above, merge vertices, are joined to a
+// helper above or below.
+func monotoneDiagonals2D(points []Vector2D, rings [][]int, next []int) [][2]int {
+	var order []int
+	prev := make([]int, len(points))
+	for _, r := range rings {
+		order = append(order, r...)
+		for _, v := range r {
+			prev[next[v]] = v
+		}
+	}
+	sort.Slice(order, func(i, j int) bool { return monotoneAbove2D(&points[order[i]], &points[order[j]]) })
+
+	status := newMonotoneStatus2D(points, next)
+	helper := make([]int, len(points))
+	merge := make([]bool, len(points))
+	insert := func(e int) {
+		status.insert(e)
+		helper[e] = e
+	}
+	var z [][2]int
+	// finish ends edge e at v, joining v to a merge vertex helping it
+	finish := func(e, v int) {
+		if merge[helper[e]] {
+			z = append(z, [2]int{v, helper[e]})
+		}
+		status.remove(e)
+	}
+	// help makes v the helper of the edge left of it, joining v to a merge
+	// vertex helping it
+	help := func(v int) {
+		if e := status.leftOf(v); e >= 0 {
+			if merge[helper[e]] {
+				z = append(z, [2]int{v, helper[e]})
+			}
+			helper[e] = v
+		}
+	}
+
+	for _, v := range order {
+		p, n := prev[v], next[v]
+		pAbove, nAbove := monotoneAbove2D(&points[p], &points[v]), monotoneAbove2D(&points[n], &points[v])
+		convex := Orient2D(&points[p], &points[v], &points[n]) > 0
+		switch {
+		case !pAbove && !nAbove && convex:
+			// start
+			insert(v)
+		case !pAbove && !nAbove:
+			// split, joined to the helper of the edge left of it
+			if e := status.leftOf(v); e >= 0 {
+				z = append(z, [2]int{v, helper[e]})
+				helper[e] = v
+			}
+			insert(v)
+		case pAbove && nAbove:
+			// end, or merge which helps the edge left of it
+			finish(p, v)
+			if !convex {
+				merge[v] = true
+				help(v)
+			}
+		case pAbove:
+			// on the left of the inside
+			finish(p, v)
+			insert(v)
+		default:
+			// on the right of the inside
+			help(v)
+		}
+	}
+	return z
+}
+
+// A monotoneStatus2D is the edges crossing the sweep line of
+// monotoneDiagonals2D ordered left to right, in a treap so each is added and
+// removed in O(log n) time. Edges are numbered by their first vertex and each
+// points down, so a vertex is right of an edge if it's on its left.
+type monotoneStatus2D struct {
+	points   []Vector2D
+	next     []int
+	root     int
+	child    [][2]int // left and right child of each edge, or -1
+	parent   []int    // of each edge, or -1 at the root
+	priority []uint32 // greater at parents than their children
+	in       []bool   // whether each edge is in the treap
+	seed     uint32
+}
+
+// newMonotoneStatus2D returns an empty status for the edges from each vertex
+// to the next.
+func newMonotoneStatus2D(points []Vector2D, next []int) *monotoneStatus2D {
+	return &monotoneStatus2D{
+		points:   points,
+		next:     next,
+		root:     -1,
+		child:    make([][2]int, len(points)),
+		parent:   make([]int, len(points)),
+		priority: make([]uint32, len(points)),
+		in:       make([]bool, len(points)),
+	}
+}
+
+// right returns true if vertex v is right of edge e or false otherwise.
+func (s *monotoneStatus2D) right(e, v int) bool {
+	return Orient2D(&s.points[e], &s.points[s.next[e]], &s.points[v]) > 0
+}
+
+// insert adds edge e, placed by its first vertex.
+func (s *monotoneStatus2D) insert(e int) {
+	s.seed = s.seed*1664525 + 1013904223
+	s.child[e], s.parent[e], s.priority[e], s.in[e] = [2]int{-1, -1}, -1, s.seed, true
+	if s.root < 0 {
+		s.root = e
+		return
+	}
+	for f := s.root; ; {
+		d := 0
+		if s.right(f, e) {
+			d = 1
+		}
+		if s.child[f][d] < 0 {
+			s.child[f][d], s.parent[e] = e, f
+			break
+		}
+		f = s.child[f][d]
+	}
+	for s.parent[e] >= 0 && s.priority[s.parent[e]] < s.priority[e] {
+		s.rotateUp(e)
+	}
+}
+
+// remove removes edge e if it's there. It's found by its place in the treap
+// rather than by comparisons, which a degenerate polygon can get wrong.
+func (s *monotoneStatus2D) remove(e int) {
+	if !s.in[e] {
+		return
+	}
+	s.in[e] = false
+	for {
+		l, r := s.child[e][0], s.child[e][1]
+		if l < 0 && r < 0 {
+			break
+		}
+		if l < 0 || (r >= 0 && s.priority[r] > s.priority[l]) {
+			l = r
+		}
+		s.rotateUp(l)
+	}
+	s.replace(e, -1)
+}
+
+// leftOf returns the edge immediately left of vertex v, or -1 if there is
+// none.
+func (s *monotoneStatus2D) leftOf(v int) int {
+	z := -1
+	for e := s.root; e >= 0; {
+		if s.right(e, v) {
+			z, e = e, s.child[e][1]
+		} else {
+			e = s.child[e][0]
+		}
+	}
+	return z
+}
+
+// rotateUp swaps edge e with its parent, keeping the order of the edges.
+func (s *monotoneStatus2D) rotateUp(e int) {
+	p := s.parent[e]
+	d := 0
+	if s.child[p][1] == e {
+		d = 1
+	}
+	c := s.child[e][1-d]
+	s.child[p][d] = c
+	if c >= 0 {
+		s.parent[c] = p
+	}
+	s.replace(p, e)
+	s.child[e][1-d], s.parent[p] = p, e
+}
+
+// replace puts f, which may be -1, where e is under e's parent.
+func (s *monotoneStatus2D) replace(e, f int) {
+	g := s.parent[e]
+	switch {
+	case g < 0:
+		s.root = f
+	case s.child[g][0] == e:
+		s.child[g][0] = f
+	default:
+		s.child[g][1] = f
+	}
+	if f >= 0 {
+		s.parent[f] = g
+	}
+}
+
+// triangulateMonotone2D appends to z the triangles of the counterclockwise
+// ring of vertex indices r, which is monotone in Y, then returns z. Vertices
+// are taken from the top down, each cutting off the triangles it can see
+// between the vertices above it not yet cut off, which form a chain.
+func triangulateMonotone2D(points []Vector2D, r []int, z [][3]int) [][3]int {
+	n := len(r)
+	top, bottom := 0, 0
+	for i := range r {
+		if monotoneAbove2D(&points[r[i]], &points[r[top]]) {
+			top = i
+		}
+		if monotoneAbove2D(&points[r[bottom]], &points[r[i]]) {
+			bottom = i
+		}
+	}
+	// counterclockwise from the top runs down the left side, and the sides
+	// are merged into the order of the sweep
+	sorted := make([]int, 0, n)
+	left := make(map[int]bool, n)
+	sorted = append(sorted, r[top])
+	for i, j := (top+1)%n, (top+n-1)%n; len(sorted) < n; {
+		if i != bottom && (j == bottom || monotoneAbove2D(&points[r[i]], &points[r[j]])) {
+			left[r[i]] = true
+			sorted = append(sorted, r[i])
+			i = (i + 1) % n
+		} else {
+			sorted = append(sorted, r[j])
+			j = (j + n - 1) % n
+		}
+	}
+	triangle := func(a, b, c int) {
+		switch Orient2D(&points[a], &points[b], &points[c]) {
+		case 1:
+			z = append(z, [3]int{a, b, c})
+		case -1:
+			z = append(z, [3]int{a, c, b})
+		}
+	}
+
+	stack := []int{sorted[0], sorted[1]}
+	for j := 2; j < n-1; j++ {
+		v := sorted[j]
+		if left[v] != left[stack[len(stack)-1]] {
+			// v sees the whole chain across from it
+			for k := 1; k < len(stack); k++ {
+				triangle(v, stack[k-1], stack[k])
+			}
+			stack = append(stack[:0], sorted[j-1], v)
+			continue
+		}
+		// v sees up its own side while the chain turns toward the inside
+		last := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			o := Orient2D(&points[u], &points[last], &points[v])
+			if (left[v] && o <= 0) || (!left[v] && o >= 0) {
+				break
+			}
+			triangle(v, last, u)
+			last = u
+			stack = stack[:len(stack)-1]
+		}
+		stack = append(stack, last, v)
+	}
+	for k := 1; k < len(stack); k++ {
+		triangle(sorted[n-1], stack[k-1], stack[k])
+	}
+	return z
+}
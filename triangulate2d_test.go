@@ -0,0 +1,184 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// checkTriangulation2D returns a description of the first way triangles fail
+// to be a triangulation of x as Triangulate documents, or "" if they don't.
+func checkTriangulation2D(x *Polygon2D, triangles [][3]int) string {
+	points := append([]Vector2D(nil), x.Outer...)
+	for _, h := range x.Holes {
+		points = append(points, h...)
+	}
+	if len(triangles) != len(points)+2*len(x.Holes)-2 {
+		return "wrong number of triangles"
+	}
+	area := 0.0
+	for _, v := range triangles {
+		for _, i := range v {
+			if i < 0 || i >= len(points) {
+				return "index out of range"
+			}
+		}
+		t := Triangle2D{points[v[0]], points[v[1]], points[v[2]]}
+		if Orient2D(&t.A, &t.B, &t.C) != x.Orientation() {
+			return "triangle turns the wrong way"
+		}
+		var c Vector2D
+		if x.Contains(t.Centroid(&c)) != 1 {
+			return "triangle outside polygon"
+		}
+		area += t.Area()
+	}
+	if a := math.Abs(x.Area()); math.Abs(area-a) > 1e-9*a {
+		return "triangles don't cover polygon"
+	}
+	return ""
+}
+
+// triangulate2DComb returns a comb with n teeth, its edges all horizontal or
+// vertical.
+func triangulate2DComb(n int) Polygon2D {
+	k := float64(n)
+	p := Polygon2D{Outer: []Vector2D{{0, 0}, {2*k - 1, 0}, {2*k - 1, 2}, {2*k - 2, 2}}}
+	for j := k - 1; j > 0; j-- {
+		p.Outer = append(p.Outer, Vector2D{2 * j, 1}, Vector2D{2*j - 1, 1}, Vector2D{2*j - 1, 2}, Vector2D{2*j - 2, 2})
+	}
+	return p
+}
+
+var triangulate2DValues = []Polygon2D{
+	boolean2DSquare(0, 0, 1, 1),
+	{Outer: []Vector2D{{0, 0}, {0, 1}, {1, 1}, {1, 0}}},
+	polygon2DL,
+	polygon2DSquareWithHole,
+	// holes of either orientation, one left of the other and one whose
+	// rightmost vertex sees only a vertex of the other
+	{
+		Outer: []Vector2D{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+		Holes: [][]Vector2D{
+			{{1, 1}, {2, 1}, {2, 2}, {1, 2}},
+			{{4, 4}, {4, 6}, {6, 6}, {6, 4}},
+			{{7, 4.5}, {8, 5}, {7, 5.5}},
+		},
+	},
+	// horizontal edges level with each other and with vertices
+	triangulate2DComb(1),
+	triangulate2DComb(5),
+	{
+		Outer: []Vector2D{{0, 0}, {4, 0}, {4, 4}, {3, 4}, {3, 2}, {2, 4}, {1, 2}, {1, 4}, {0, 4}},
+		Holes: [][]Vector2D{{{1, 0.5}, {1, 1}, {3, 1}, {3, 0.5}}},
+	},
+}
+
+func TestPolygon2DTriangulate(t *testing.T) {
+	for i := range triangulate2DValues {
+		x := &triangulate2DValues[i]
+		if err := checkTriangulation2D(x, x.Triangulate()); err != "" {
+			t.Error("Polygon2D.Triangulate", *x, err, x.Triangulate())
+		}
+		if err := checkTriangulation2D(x, x.TriangulateMonotone()); err != "" {
+			t.Error("Polygon2D.TriangulateMonotone", *x, err, x.TriangulateMonotone())
+		}
+	}
+	// nothing to triangulate
+	for _, x := range []Polygon2D{{}, {Outer: []Vector2D{{0, 0}, {1, 1}}}, {Outer: []Vector2D{{0, 0}, {1, 1}, {2, 2}}}} {
+		if got := x.Triangulate(); got != nil {
+			t.Error("Polygon2D.Triangulate", x, "want nil got", got)
+		}
+		if got := x.TriangulateMonotone(); got != nil {
+			t.Error("Polygon2D.TriangulateMonotone", x, "want nil got", got)
+		}
+	}
+}
+
+// triangulate2DPolygon returns a random star shaped polygon with n vertices
+// and, if it has enough vertices for them to fit, up to three square holes of
+// random orientation.
+func triangulate2DPolygon(r *rand.Rand, n int) Polygon2D {
+	var p Polygon2D
+	for i := 0; i < n; i++ {
+		a := 2 * math.Pi * (float64(i) + r.Float64()*0.5) / float64(n)
+		d := 0.6 + r.Float64()*0.6
+		p.Outer = append(p.Outer, Vector2D{d * math.Cos(a), d * math.Sin(a)})
+	}
+	for i := r.Intn(4); i > 0 && n >= 8; i-- {
+		c := Vector2D{r.Float64()*0.6 - 0.3, r.Float64()*0.6 - 0.3}
+		for _, h := range p.Holes {
+			if math.Abs(h[0].X-c.X) < 0.5 && math.Abs(h[0].Y-c.Y) < 0.5 {
+				c.X = math.NaN()
+			}
+		}
+		if math.IsNaN(c.X) {
+			continue
+		}
+		a := r.Float64() * math.Pi
+		var h []Vector2D
+		for j := 0; j < 4; j++ {
+			s, c0 := math.Sincos(a + float64(j)*math.Pi/2)
+			h = append(h, Vector2D{c.X + 0.1*c0, c.Y + 0.1*s})
+		}
+		if r.Intn(2) == 0 {
+			ringReverse(h)
+		}
+		p.Holes = append(p.Holes, h)
+	}
+	return p
+}
+
+func TestPolygon2DTriangulateRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		x := triangulate2DPolygon(r, 3+r.Intn(40))
+		if i%2 == 1 {
+			x.Reverse()
+		}
+		if err := checkTriangulation2D(&x, x.Triangulate()); err != "" {
+			t.Fatal("Polygon2D.Triangulate", x, err)
+		}
+		if err := checkTriangulation2D(&x, x.TriangulateMonotone()); err != "" {
+			t.Fatal("Polygon2D.TriangulateMonotone", x, err)
+		}
+	}
+}
+
+func Benchmark_Polygon2DTriangulate(b *testing.B) {
+	x := triangulate2DPolygon(rand.New(rand.NewSource(1)), 1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Triangulate()
+	}
+}
+
+func Benchmark_Polygon2DTriangulateMonotone(b *testing.B) {
+	x := triangulate2DPolygon(rand.New(rand.NewSource(1)), 1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.TriangulateMonotone()
+	}
+}
+
+// benchmarkTriangulateMonotoneComb times a comb of n teeth, whose sweep
+// crosses n edges at once.
+func benchmarkTriangulateMonotoneComb(b *testing.B, n int) {
+	x := triangulate2DComb(n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.TriangulateMonotone()
+	}
+}
+
+func Benchmark_Polygon2DTriangulateMonotoneComb1000(b *testing.B) {
+	benchmarkTriangulateMonotoneComb(b, 1000)
+}
+
+func Benchmark_Polygon2DTriangulateMonotoneComb10000(b *testing.B) {
+	benchmarkTriangulateMonotoneComb(b, 10000)
+}
+
+func Benchmark_Polygon2DTriangulateMonotoneComb100000(b *testing.B) {
+	benchmarkTriangulateMonotoneComb(b, 100000)
+}
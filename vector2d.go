@@ -41,15 +41,21 @@ func (a *Vector2D) DirectionEqual(b *Vector2D) bool {
 // DirectionFuzzyEqual compares the direction of a and b then returns true if
 // they are very close or false otherwise.
 func (a *Vector2D) DirectionFuzzyEqual(b *Vector2D) bool {
-	if FuzzyEqual(math.Abs(a.X)+math.Abs(b.X), 0) {
+	return DefaultTolerance.Vector2DDirectionEqual(a, b)
+}
+
+// Vector2DDirectionEqual is like Vector2D.DirectionFuzzyEqual but compares
+// with tolerance t.
+func (t *Tolerance) Vector2DDirectionEqual(a, b *Vector2D) bool {
+	if t.Equal(math.Abs(a.X)+math.Abs(b.X), 0) {
 		return a.Y*b.Y > 0
 	}
 	if a.X > b.X {
 		s := a.X / b.X
-		return s > 0 && FuzzyEqual(a.Y, s*b.Y)
+		return s > 0 && t.Equal(a.Y, s*b.Y)
 	}
 	s := b.X / a.X
-	return s > 0 && FuzzyEqual(s*a.Y, b.Y)
+	return s > 0 && t.Equal(s*a.Y, b.Y)
 }
 
 // Divide sets z to the piecewise quotient a/b then returns z.
@@ -73,7 +79,12 @@ func (a *Vector2D) Equal(b *Vector2D) bool {
 // FuzzyEqual compares a and b then returns true if they are very close or
 // false otherwise.
 func (a *Vector2D) FuzzyEqual(b *Vector2D) bool {
-	return FuzzyEqual(a.X, b.X) && FuzzyEqual(a.Y, b.Y)
+	return DefaultTolerance.Vector2DEqual(a, b)
+}
+
+// Vector2DEqual is like Vector2D.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) Vector2DEqual(a, b *Vector2D) bool {
+	return t.Equal(a.X, b.X) && t.Equal(a.Y, b.Y)
 }
 
 // Magnitude returns the magnitude of x.
@@ -135,3 +146,16 @@ func (z *Vector2D) Subtract(a, b *Vector2D) *Vector2D {
 	z.Y = a.Y - b.Y
 	return z
 }
+
+// TransformPoint sets z to point x transformed by m then returns z.
+func (z *Vector2D) TransformPoint(m *Affine2D, x *Vector2D) *Vector2D {
+	z.X, z.Y = m[0][0]*x.X+m[0][1]*x.Y+m[0][2], m[1][0]*x.X+m[1][1]*x.Y+m[1][2]
+	return z
+}
+
+// TransformVector sets z to vector x transformed by m, ignoring the
+// translation, then returns z.
+func (z *Vector2D) TransformVector(m *Affine2D, x *Vector2D) *Vector2D {
+	z.X, z.Y = m[0][0]*x.X+m[0][1]*x.Y, m[1][0]*x.X+m[1][1]*x.Y
+	return z
+}
@@ -234,3 +234,22 @@ func Benchmark_Vector2D_Subtract(b *testing.B) {
 		r.Subtract(v1, v2)
 	}
 }
+
+func TestVector2DTransformPoint(t *testing.T) {
+	m := &Affine2D{{1, 2, 3}, {4, 5, 6}}
+	v := &Vector2D{1, 1}
+	if !v.TransformPoint(m, v).Equal(&Vector2D{6, 15}) {
+		t.Error("Vector2D.TransformPoint", "got", v)
+	}
+	if !v.TransformVector(m, v).Equal(&Vector2D{36, 99}) {
+		t.Error("Vector2D.TransformVector", "got", v)
+	}
+}
+
+func Benchmark_Vector2D_TransformPoint(b *testing.B) {
+	m := &Affine2D{{1, 2, 3}, {4, 5, 6}}
+	v := &Vector2D{1, 1}
+	for i := 0; i < b.N; i++ {
+		v.TransformPoint(m, v)
+	}
+}
@@ -59,24 +59,30 @@ func (a *Vector3D) DirectionEqual(b *Vector3D) bool {
 // DirectionFuzzyEqual compares the direction of a and b then returns true if
 //they are very close or false otherwise.
 func (a *Vector3D) DirectionFuzzyEqual(b *Vector3D) bool {
-	if FuzzyEqual(math.Abs(a.X)+math.Abs(b.X), 0) {
-		if FuzzyEqual(math.Abs(a.Y)+math.Abs(b.Y), 0) {
+	return DefaultTolerance.Vector3DDirectionEqual(a, b)
+}
+
+// Vector3DDirectionEqual is like Vector3D.DirectionFuzzyEqual but compares
+// with tolerance t.
+func (t *Tolerance) Vector3DDirectionEqual(a, b *Vector3D) bool {
+	if t.Equal(math.Abs(a.X)+math.Abs(b.X), 0) {
+		if t.Equal(math.Abs(a.Y)+math.Abs(b.Y), 0) {
 			return a.Z*b.Z > 0
 		} else {
 			if a.Y > b.Y {
 				s := a.Y / b.Y
-				return s > 0 && FuzzyEqual(a.Z, s*b.Z)
+				return s > 0 && t.Equal(a.Z, s*b.Z)
 			}
 			s := b.Y / a.Y
-			return s > 0 && FuzzyEqual(s*a.Z, b.Z)
+			return s > 0 && t.Equal(s*a.Z, b.Z)
 		}
 	}
 	if a.X > b.X {
 		s := a.X / b.X
-		return s > 0 && FuzzyEqual(a.Y, s*b.Y) && FuzzyEqual(a.Z, s*b.Z)
+		return s > 0 && t.Equal(a.Y, s*b.Y) && t.Equal(a.Z, s*b.Z)
 	}
 	s := b.X / a.X
-	return s > 0 && FuzzyEqual(s*a.Y, b.Y) && FuzzyEqual(s*a.Z, b.Z)
+	return s > 0 && t.Equal(s*a.Y, b.Y) && t.Equal(s*a.Z, b.Z)
 }
 
 // Divide sets z to the piecewise quotient a/b then returns z.
@@ -101,7 +107,12 @@ func (a *Vector3D) Equal(b *Vector3D) bool {
 // FuzzyEqual compares a and b and returns true if they are very close or false
 // otherwise.
 func (a *Vector3D) FuzzyEqual(b *Vector3D) bool {
-	return FuzzyEqual(a.X, b.X) && FuzzyEqual(a.Y, b.Y) && FuzzyEqual(a.Z, b.Z)
+	return DefaultTolerance.Vector3DEqual(a, b)
+}
+
+// Vector3DEqual is like Vector3D.FuzzyEqual but compares with tolerance t.
+func (t *Tolerance) Vector3DEqual(a, b *Vector3D) bool {
+	return t.Equal(a.X, b.X) && t.Equal(a.Y, b.Y) && t.Equal(a.Z, b.Z)
 }
 
 // Magnitude returns the magnitude of x.
@@ -149,6 +160,18 @@ func (z *Vector3D) Projection(a, b *Vector3D) *Vector3D {
 	return z
 }
 
+// Rotate sets z to x rotated by unit quaternion q then returns z.
+func (z *Vector3D) Rotate(q *Quaternion, x *Vector3D) *Vector3D {
+	// v + 2w(u x v) + 2u x (u x v) where u is the vector part of q
+	tx := 2 * (q.Y*x.Z - q.Z*x.Y)
+	ty := 2 * (q.Z*x.X - q.X*x.Z)
+	tz := 2 * (q.X*x.Y - q.Y*x.X)
+	z.X, z.Y, z.Z = x.X+q.W*tx+q.Y*tz-q.Z*ty,
+		x.Y+q.W*ty+q.Z*tx-q.X*tz,
+		x.Z+q.W*tz+q.X*ty-q.Y*tx
+	return z
+}
+
 // ScalarProjection returns the scalar projection of a onto b.
 func (a *Vector3D) ScalarProjection(b *Vector3D) float64 {
 	return (a.X*b.X + a.Y*b.Y + a.Z*b.Z) / (b.X*b.X + b.Y*b.Y + b.Z*b.Z)
@@ -169,3 +192,30 @@ func (z *Vector3D) Subtract(a, b *Vector3D) *Vector3D {
 	z.Z = a.Z - b.Z
 	return z
 }
+
+// Transform sets z to the matrix product m*x then returns z.
+func (z *Vector3D) Transform(m *Matrix3x3, x *Vector3D) *Vector3D {
+	z.X, z.Y, z.Z = m[0][0]*x.X+m[0][1]*x.Y+m[0][2]*x.Z,
+		m[1][0]*x.X+m[1][1]*x.Y+m[1][2]*x.Z,
+		m[2][0]*x.X+m[2][1]*x.Y+m[2][2]*x.Z
+	return z
+}
+
+// TransformDirection sets z to direction x transformed by m, ignoring any
+// translation or projection, then returns z.
+func (z *Vector3D) TransformDirection(m *Matrix4x4, x *Vector3D) *Vector3D {
+	z.X, z.Y, z.Z = m[0][0]*x.X+m[0][1]*x.Y+m[0][2]*x.Z,
+		m[1][0]*x.X+m[1][1]*x.Y+m[1][2]*x.Z,
+		m[2][0]*x.X+m[2][1]*x.Y+m[2][2]*x.Z
+	return z
+}
+
+// TransformPoint sets z to point x transformed by m, including the divide by
+// the homogeneous coordinate for projective transforms, then returns z.
+func (z *Vector3D) TransformPoint(m *Matrix4x4, x *Vector3D) *Vector3D {
+	w := 1 / (m[3][0]*x.X + m[3][1]*x.Y + m[3][2]*x.Z + m[3][3])
+	z.X, z.Y, z.Z = (m[0][0]*x.X+m[0][1]*x.Y+m[0][2]*x.Z+m[0][3])*w,
+		(m[1][0]*x.X+m[1][1]*x.Y+m[1][2]*x.Z+m[1][3])*w,
+		(m[2][0]*x.X+m[2][1]*x.Y+m[2][2]*x.Z+m[2][3])*w
+	return z
+}
@@ -281,3 +281,24 @@ func Benchmark_Vector3D_Subtract(b *testing.B) {
 		r.Subtract(v1, v2)
 	}
 }
+
+func TestVector3DTransformPoint(t *testing.T) {
+	var m Matrix4x4
+	v := &Vector3D{1, 2, 3}
+	m.Translation(&Vector3D{1, 1, 1})
+	if !v.TransformPoint(&m, v).Equal(&Vector3D{2, 3, 4}) {
+		t.Error("Vector3D.TransformPoint", "got", v)
+	}
+	if !v.TransformDirection(&m, v).Equal(&Vector3D{2, 3, 4}) {
+		t.Error("Vector3D.TransformDirection", "got", v)
+	}
+}
+
+func Benchmark_Vector3D_TransformPoint(b *testing.B) {
+	var m Matrix4x4
+	m.RotationX(1)
+	v := &Vector3D{1, 2, 3}
+	for i := 0; i < b.N; i++ {
+		v.TransformPoint(&m, v)
+	}
+}
@@ -0,0 +1,181 @@
+package geometry
+
+import (
+	"math"
+)
+
+// A VoronoiEdge2D is an edge of a Voronoi diagram, the part of the bisector of
+// two sites that borders both of their cells.
+type VoronoiEdge2D struct {
+	A, B int    // the sites either side, A < B
+	Edge Line2D // from P to P+V with the cell of site A on the left
+}
+
+// Voronoi2D returns the Voronoi diagram of sites clipped to bounds. cells[i]
+// is the region of bounds closer to sites[i] than to any other site, as a
+// counterclockwise convex polygon without holes, and edges are the edges
+// shared by two cells. Edges on the boundary of bounds are not included.
+//
+// The diagram is built as the dual of the Delaunay triangulation, each cell
+// being bounds clipped by the bisectors between its site and the site's
+// Delaunay neighbors. Of duplicate sites only the one with the lowest index
+// has a cell, the others and any site whose cell is outside bounds have an
+// empty polygon. Sites need not be inside bounds.
+func Voronoi2D(sites []Vector2D, bounds *AABB2D) (cells []Polygon2D, edges []VoronoiEdge2D) {
+	cells = make([]Polygon2D, len(sites))
+	if bounds.IsEmpty() {
+		return cells, nil
+	}
+
+	neighbors := make([][]int, len(sites))
+	join := func(a, b int) {
+		for _, v := range neighbors[a] {
+			if v == b {
+				return
+			}
+		}
+		neighbors[a] = append(neighbors[a], b)
+		neighbors[b] = append(neighbors[b], a)
+	}
+	triangles, _ := Delaunay2D(sites)
+	for _, v := range triangles {
+		join(v[0], v[1])
+		join(v[1], v[2])
+		join(v[2], v[0])
+	}
+	// collinear sites have no triangles but neighbor those either side, and
+	// either way each distinct site has a cell
+	var used []int
+	if triangles == nil {
+		used = ConvexHull2D(sites, true)
+		for i := 1; i < len(used); i++ {
+			join(used[i-1], used[i])
+		}
+	} else {
+		first := make(map[Vector2D]bool, len(sites))
+		for i := range sites {
+			if !first[sites[i]] {
+				first[sites[i]] = true
+				used = append(used, i)
+			}
+		}
+	}
+
+	// points within eps of a bisector are taken to be on it, so that the
+	// bisectors of cocircular sites don't leave slivers
+	var size Vector2D
+	scale := bounds.Size(&size).Magnitude() + bounds.Min.Magnitude() + bounds.Max.Magnitude()
+	eps := 1e-12 * scale
+	for _, i := range used {
+		c := voronoiCell2D{
+			points: []Vector2D{
+				bounds.Min,
+				{bounds.Max.X, bounds.Min.Y},
+				bounds.Max,
+				{bounds.Min.X, bounds.Max.Y},
+			},
+			sites: []int{-1, -1, -1, -1},
+		}
+		for _, j := range neighbors[i] {
+			c.clip(&sites[i], &sites[j], j, eps)
+		}
+		// a site outside bounds may leave only a segment or point
+		if c.merge(eps); len(c.points) < 3 || ringArea(c.points) <= eps*scale {
+			continue
+		}
+		cells[i].Outer = c.points
+		for k, j := range c.sites {
+			if j <= i {
+				continue
+			}
+			e := VoronoiEdge2D{A: i, B: j}
+			e.Edge.P = c.points[k]
+			e.Edge.V.Subtract(&c.points[(k+1)%len(c.points)], &c.points[k])
+			edges = append(edges, e)
+		}
+	}
+	return cells, edges
+}
+
+// A voronoiCell2D is a convex polygon being clipped to a Voronoi cell.
+// sites[k] is the site on the other side of the edge from points[k] to
+// points[k+1], or -1 if that edge is on the bounds.
+type voronoiCell2D struct {
+	points []Vector2D
+	sites  []int
+}
+
+// clip removes the part of the cell closer to site b, numbered j, than to
+// site a. Points within eps of the bisector are kept and not split.
+func (x *voronoiCell2D) clip(a, b *Vector2D, j int, eps float64) {
+	var d, m Vector2D
+	d.Subtract(b, a)
+	m.Add(a, b)
+	m.Scale(&m, 0.5)
+	scale := d.Magnitude()
+	side := func(p *Vector2D) (float64, int) {
+		var v Vector2D
+		f := v.Subtract(p, &m).DotProduct(&d)
+		if math.Abs(f) <= eps*scale {
+			return f, 0
+		}
+		return f, sign(f)
+	}
+
+	var points []Vector2D
+	var sites []int
+	n := len(x.points)
+	for k := range x.points {
+		p, q := &x.points[k], &x.points[(k+1)%n]
+		fp, sp := side(p)
+		fq, sq := side(q)
+		var cross Vector2D
+		if sp*sq < 0 {
+			cross.Subtract(q, p)
+			cross.Scale(&cross, fp/(fp-fq))
+			cross.Add(&cross, p)
+		}
+		switch {
+		case sp > 0:
+			if sq < 0 {
+				points = append(points, cross)
+				sites = append(sites, x.sites[k])
+			}
+		case sp == 0:
+			points = append(points, *p)
+			if sq > 0 {
+				sites = append(sites, j)
+			} else {
+				sites = append(sites, x.sites[k])
+			}
+		default:
+			points = append(points, *p)
+			sites = append(sites, x.sites[k])
+			if sq > 0 {
+				points = append(points, cross)
+				sites = append(sites, j)
+			}
+		}
+	}
+	x.points, x.sites = points, sites
+}
+
+// merge removes edges shorter than eps, keeping the site of the edge after
+// each one removed.
+func (x *voronoiCell2D) merge(eps float64) {
+	var points []Vector2D
+	var sites []int
+	for k := range x.points {
+		n := len(points)
+		if n > 0 && Distance2DPointPoint(&points[n-1], &x.points[k]) <= eps {
+			sites[n-1] = x.sites[k]
+			continue
+		}
+		points = append(points, x.points[k])
+		sites = append(sites, x.sites[k])
+	}
+	for n := len(points); n > 1 && Distance2DPointPoint(&points[n-1], &points[0]) <= eps; n-- {
+		points, sites = points[:n-1], sites[:n-1]
+	}
+	x.points, x.sites = points, sites
+}
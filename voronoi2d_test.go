@@ -0,0 +1,147 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// checkVoronoi2D returns a description of the first way cells and edges fail
+// to be the Voronoi diagram of sites clipped to bounds, or "" if they don't.
+func checkVoronoi2D(sites []Vector2D, bounds *AABB2D, cells []Polygon2D, edges []VoronoiEdge2D) string {
+	if len(cells) != len(sites) {
+		return "wrong number of cells"
+	}
+	const eps = 1e-9
+	// nearest returns true if no site is closer to p than site i
+	nearest := func(p *Vector2D, i int) bool {
+		d := Distance2DPointPoint(p, &sites[i])
+		for j := range sites {
+			if Distance2DPointPoint(p, &sites[j]) < d-eps {
+				return false
+			}
+		}
+		return true
+	}
+	var grown AABB2D
+	grown.Expand(bounds, eps)
+	area := 0.0
+	for i := range cells {
+		c := &cells[i]
+		for j := 0; j < i; j++ {
+			if sites[j] == sites[i] && c.Outer != nil {
+				return "duplicate site has a cell"
+			}
+		}
+		if c.Outer == nil {
+			continue
+		}
+		if c.Area() <= 0 || !c.IsConvex() || c.Holes != nil {
+			return "cell not a counterclockwise convex polygon"
+		}
+		area += c.Area()
+		for _, p := range c.Outer {
+			if !grown.Contains(&p) {
+				return "cell outside bounds"
+			}
+			if !nearest(&p, i) {
+				return "cell vertex closer to another site"
+			}
+		}
+	}
+	if len(sites) > 0 && !bounds.IsEmpty() && math.Abs(area-bounds.Area()) > eps*bounds.Area() {
+		return "cells don't cover bounds"
+	}
+	for _, e := range edges {
+		if e.A >= e.B || e.Edge.V.Magnitude() == 0 {
+			return "edge degenerate"
+		}
+		var q Vector2D
+		q.Add(&e.Edge.P, &e.Edge.V)
+		for _, p := range []*Vector2D{&e.Edge.P, &q} {
+			a := Distance2DPointPoint(p, &sites[e.A])
+			b := Distance2DPointPoint(p, &sites[e.B])
+			if math.Abs(a-b) > eps || !nearest(p, e.A) {
+				return "edge not on the bisector"
+			}
+		}
+		if Orient2D(&e.Edge.P, &q, &sites[e.A]) <= 0 {
+			return "edge not counterclockwise around site A"
+		}
+	}
+	return ""
+}
+
+var voronoi2DValues = []struct {
+	sites  []Vector2D
+	bounds AABB2D
+	edges  int
+}{
+	{nil, AABB2D{Vector2D{0, 0}, Vector2D{1, 1}}, 0},
+	{[]Vector2D{{0.5, 0.5}}, AABB2D{Vector2D{0, 0}, Vector2D{1, 1}}, 0},
+	{[]Vector2D{{0.25, 0.5}, {0.75, 0.5}, {0.25, 0.5}}, AABB2D{Vector2D{0, 0}, Vector2D{1, 1}}, 1},
+	// collinear sites, some outside the bounds
+	{[]Vector2D{{-1, 0}, {0, 0}, {1, 0}, {3, 0}, {5, 0}}, AABB2D{Vector2D{-0.5, -1}, Vector2D{2, 1}}, 1},
+	// cocircular sites meet at a single vertex without a zero length edge
+	{[]Vector2D{{0, 0}, {1, 0}, {1, 1}, {0, 1}}, AABB2D{Vector2D{-1, -1}, Vector2D{2, 2}}, 4},
+	{[]Vector2D{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0.5, 0.5}}, AABB2D{Vector2D{-1, -1}, Vector2D{2, 2}}, 8},
+	// a bounds away from every site
+	{[]Vector2D{{0, 0}, {1, 0}, {0, 1}}, AABB2D{Vector2D{5, 5}, Vector2D{6, 6}}, 1},
+	{[]Vector2D{{0, 0}, {1, 0}, {0, 1}}, *new(AABB2D).Empty(), 0},
+}
+
+func TestVoronoi2D(t *testing.T) {
+	for _, v := range voronoi2DValues {
+		cells, edges := Voronoi2D(v.sites, &v.bounds)
+		if err := checkVoronoi2D(v.sites, &v.bounds, cells, edges); err != "" {
+			t.Error("Voronoi2D", v.sites, v.bounds, err, cells, edges)
+		}
+		if len(edges) != v.edges {
+			t.Error("Voronoi2D", v.sites, v.bounds, "want", v.edges, "edges got", edges)
+		}
+	}
+	r := rand.New(rand.NewSource(1))
+	bounds := AABB2D{Vector2D{1, 2}, Vector2D{6, 5}}
+	for i := 0; i < 20; i++ {
+		// a coarse grid for duplicate and cocircular sites, then random
+		// sites, some outside the bounds
+		sites := make([]Vector2D, 60)
+		for j := range sites {
+			if i%2 == 0 {
+				sites[j] = Vector2D{float64(r.Intn(8)), float64(r.Intn(8))}
+			} else {
+				sites[j] = Vector2D{r.Float64() * 7, r.Float64() * 7}
+			}
+		}
+		cells, edges := Voronoi2D(sites, &bounds)
+		if err := checkVoronoi2D(sites, &bounds, cells, edges); err != "" {
+			t.Fatal("Voronoi2D", sites, err)
+		}
+		// every point is in the cell of its nearest site
+		for j := 0; j < 100; j++ {
+			p := Vector2D{1 + r.Float64()*5, 2 + r.Float64()*3}
+			n := 0
+			for k := range sites {
+				if Distance2DPointPoint(&p, &sites[k]) < Distance2DPointPoint(&p, &sites[n]) {
+					n = k
+				}
+			}
+			if cells[n].Contains(&p) < 0 {
+				t.Fatal("Voronoi2D", p, "not in cell of nearest site", sites[n])
+			}
+		}
+	}
+}
+
+func Benchmark_Voronoi2D(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	sites := make([]Vector2D, 1000)
+	for i := range sites {
+		sites[i] = Vector2D{r.Float64(), r.Float64()}
+	}
+	bounds := AABB2D{Vector2D{0, 0}, Vector2D{1, 1}}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Voronoi2D(sites, &bounds)
+	}
+}